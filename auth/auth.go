@@ -0,0 +1,171 @@
+// Package auth is authenticating the callers of the gRPC server with the bearer tokens
+// of their requests or with their verified TLS client certificates
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNoToken is returned by BearerToken if the request has no bearer token
+var ErrNoToken = errors.New("no bearer token")
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	// Subject is the identifier of the caller, the sub claim of the token
+	// or the mapped field of the client certificate
+	Subject string
+	// Issuer is who vouched for the caller, the iss claim of the token
+	// or the issuer of the client certificate
+	Issuer string
+	// Certificate is true if the caller is authenticated by its client certificate
+	Certificate bool
+}
+
+// ownerEscaper is escaping the colons of the issuers of the tokens and the certificates in the owners
+var ownerEscaper = strings.NewReplacer("%", "%25", ":", "%3A")
+
+// Owner returns with the owner of the todos and the lists of the principal, it is namespaced by how the caller
+// is authenticated, so a token and a client certificate of the same subject do not own the same todos.
+// It is cert:<issuer>:<subject> for the client certificates, the issuer is the distinguished name of the CA,
+// and jwt:<issuer>:<subject> for the tokens. The colons of the issuer are escaped, so the tokens and
+// the certificates of different issuers do not collide either.
+func (p *Principal) Owner() string {
+	if p.Certificate {
+		return "cert:" + ownerEscaper.Replace(p.Issuer) + ":" + p.Subject
+	}
+
+	return "jwt:" + ownerEscaper.Replace(p.Issuer) + ":" + p.Subject
+}
+
+// Verifier is checking the bearer tokens, it returns with the principal of a valid token
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Principal, error)
+}
+
+type principalKey struct{}
+
+// NewContext returns with a context of the authenticated principal
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns with the authenticated principal of the context
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// BearerToken returns with the token of the authorization metadata of the request
+func BearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", ErrNoToken
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", ErrNoToken
+	}
+
+	return strings.TrimSpace(token), nil
+}
+
+// UnaryServerInterceptor returns with an interceptor rejecting the requests without a valid
+// bearer token with UNAUTHENTICATED error, the principal of the token is set in the context
+func UnaryServerInterceptor(v Verifier) grpc.UnaryServerInterceptor {
+	return (&Authenticator{Tokens: v}).UnaryServerInterceptor()
+}
+
+// StreamServerInterceptor returns with an interceptor rejecting the streams without a valid
+// bearer token with UNAUTHENTICATED error, the principal of the token is set in the context
+func StreamServerInterceptor(v Verifier) grpc.StreamServerInterceptor {
+	return (&Authenticator{Tokens: v}).StreamServerInterceptor()
+}
+
+// Authenticator is authenticating the requests by their bearer tokens or by their client certificates.
+// The bearer token is verified if the request has one, otherwise the principal is mapped from
+// the verified client certificate of the connection.
+type Authenticator struct {
+	// Tokens is verifying the bearer tokens, the tokens are not accepted if it is nil
+	Tokens Verifier
+	// Certificates is mapping the client certificates to the principals, the certificates are not accepted if it is nil
+	Certificates CertificateMapper
+}
+
+// UnaryServerInterceptor returns with an interceptor rejecting the requests without a valid
+// bearer token or client certificate with UNAUTHENTICATED error, the principal is set in the context
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns with an interceptor rejecting the streams without a valid
+// bearer token or client certificate with UNAUTHENTICATED error, the principal is set in the context
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// serverStream is a server stream with the principal in its context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate returns with the context of the principal of the request
+func (a *Authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	token, err := BearerToken(ctx)
+	if err == nil && a.Tokens != nil {
+		p, err := a.Tokens.Verify(ctx, token)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Unauthenticated,
+				fmt.Sprintf("Invalid bearer token: %v", err),
+			)
+		}
+
+		return NewContext(ctx, p), nil
+	}
+
+	if a.Certificates != nil {
+		if p := a.Certificates.principal(ctx); p != nil {
+			return NewContext(ctx, p), nil
+		}
+
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			fmt.Sprintf("Could not get a bearer token or a client certificate"),
+		)
+	}
+
+	return nil, status.Errorf(
+		codes.Unauthenticated,
+		fmt.Sprintf("Could not get a bearer token"),
+	)
+}
@@ -0,0 +1,238 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/halimi/todo-list-service/auth"
+)
+
+var secret = []byte("secret")
+
+func claims(subject string, expiresIn time.Duration) jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   subject,
+		Issuer:    "https://issuer.example.com",
+		Audience:  jwt.ClaimStrings{"todo-list-service"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, c jwt.Claims, key interface{}) string {
+	token := jwt.NewWithClaims(method, c)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func newVerifier() *auth.JWTVerifier {
+	keys := &auth.KeySet{}
+	keys.AddSecret("", secret)
+
+	return &auth.JWTVerifier{Keys: keys, Issuer: "https://issuer.example.com", Audience: "todo-list-service"}
+}
+
+func TestJWTVerifier(t *testing.T) {
+	v := newVerifier()
+
+	noExpiry := claims("alice", time.Hour)
+	noExpiry.ExpiresAt = nil
+
+	wrongIssuer := claims("alice", time.Hour)
+	wrongIssuer.Issuer = "https://other.example.com"
+
+	wrongAudience := claims("alice", time.Hour)
+	wrongAudience.Audience = jwt.ClaimStrings{"other-service"}
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"Valid", sign(t, jwt.SigningMethodHS256, "", claims("alice", time.Hour), secret), true},
+		{"Expired", sign(t, jwt.SigningMethodHS256, "", claims("alice", -time.Minute), secret), false},
+		{"NoExpiry", sign(t, jwt.SigningMethodHS256, "", noExpiry, secret), false},
+		{"Issuer", sign(t, jwt.SigningMethodHS256, "", wrongIssuer, secret), false},
+		{"Audience", sign(t, jwt.SigningMethodHS256, "", wrongAudience, secret), false},
+		{"NoSubject", sign(t, jwt.SigningMethodHS256, "", claims("", time.Hour), secret), false},
+		{"Secret", sign(t, jwt.SigningMethodHS256, "", claims("alice", time.Hour), []byte("other")), false},
+		{"Algorithm", sign(t, jwt.SigningMethodHS512, "", claims("alice", time.Hour), secret), false},
+		{"None", sign(t, jwt.SigningMethodNone, "", claims("alice", time.Hour), jwt.UnsafeAllowNoneSignatureType), false},
+		{"UnknownKey", sign(t, jwt.SigningMethodHS256, "other", claims("alice", time.Hour), secret), false},
+		{"Malformed", "not.a.token", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.Verify(context.Background(), tt.token)
+			if tt.valid && (err != nil || p.Subject != "alice" || p.Issuer != "https://issuer.example.com") {
+				t.Fatalf("Want: alice, Got: %v %v\n", p, err)
+			}
+
+			if !tt.valid && err == nil {
+				t.Fatalf("Want: error, Got: %v\n", p)
+			}
+		})
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "alg": "RS256", "n": encode(key.N.Bytes()), "e": encode(big.NewInt(int64(key.E)).Bytes())},
+			{"kty": "RSA", "kid": "rsa-enc", "use": "enc", "n": encode(other.N.Bytes()), "e": encode(big.NewInt(int64(other.E)).Bytes())},
+			{"kty": "oct", "kid": "hmac-1", "k": encode(secret)},
+		},
+	}
+
+	b, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := &auth.KeySet{}
+	if err := keys.LoadJWKS(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// the encryption key is not used for signatures
+	if keys.Len() != 2 {
+		t.Fatalf("Want: 2 keys, Got: %v\n", keys.Len())
+	}
+
+	v := &auth.JWTVerifier{Keys: keys}
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"RS256", sign(t, jwt.SigningMethodRS256, "rsa-1", claims("alice", time.Hour), key), true},
+		{"RS256NoKid", sign(t, jwt.SigningMethodRS256, "", claims("alice", time.Hour), key), true},
+		{"HS256", sign(t, jwt.SigningMethodHS256, "hmac-1", claims("alice", time.Hour), secret), true},
+		{"OtherKey", sign(t, jwt.SigningMethodRS256, "rsa-1", claims("alice", time.Hour), other), false},
+		{"EncryptionKey", sign(t, jwt.SigningMethodRS256, "rsa-enc", claims("alice", time.Hour), other), false},
+		// the RSA public key can not be used as an HMAC secret
+		{"AlgorithmConfusion", sign(t, jwt.SigningMethodHS256, "rsa-1", claims("alice", time.Hour), key.N.Bytes()), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.Verify(context.Background(), tt.token)
+			if tt.valid && (err != nil || p.Subject != "alice") {
+				t.Fatalf("Want: alice, Got: %v %v\n", p, err)
+			}
+
+			if !tt.valid && err == nil {
+				t.Fatalf("Want: error, Got: %v\n", p)
+			}
+		})
+	}
+
+	if err := keys.LoadJWKS(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatalf("Want: error, Got: nil\n")
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestInterceptors(t *testing.T) {
+	v := newVerifier()
+	token := sign(t, jwt.SigningMethodHS256, "", claims("alice", time.Hour), secret)
+
+	tests := []struct {
+		name          string
+		authorization string
+		code          codes.Code
+	}{
+		{"Valid", "Bearer " + token, codes.OK},
+		{"Scheme", "bearer " + token, codes.OK},
+		{"NoToken", "", codes.Unauthenticated},
+		{"Basic", "Basic YWxpY2U6c2VjcmV0", codes.Unauthenticated},
+		{"Invalid", "Bearer invalid", codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if tt.authorization != "" {
+				md.Set("authorization", tt.authorization)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+
+			var got *auth.Principal
+			unary := func(ctx context.Context, req interface{}) (interface{}, error) {
+				got, _ = auth.FromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := auth.UnaryServerInterceptor(v)(ctx, nil, &grpc.UnaryServerInfo{}, unary)
+			if status.Code(err) != tt.code {
+				t.Fatalf("Want: %v, Got: %v\n", tt.code, err)
+			}
+
+			if tt.code == codes.OK && got.Subject != "alice" {
+				t.Fatalf("Want: alice, Got: %v\n", got)
+			}
+
+			got = nil
+			stream := func(srv interface{}, ss grpc.ServerStream) error {
+				got, _ = auth.FromContext(ss.Context())
+				return nil
+			}
+
+			err = auth.StreamServerInterceptor(v)(nil, &serverStream{ctx: ctx}, &grpc.StreamServerInfo{}, stream)
+			if status.Code(err) != tt.code {
+				t.Fatalf("Want: %v, Got: %v\n", tt.code, err)
+			}
+
+			if tt.code == codes.OK && got.Subject != "alice" {
+				t.Fatalf("Want: alice, Got: %v\n", got)
+			}
+		})
+	}
+}
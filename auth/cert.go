@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// CertificateMapper returns with the subject of the principal of a verified client certificate,
+// the certificate is rejected if the subject is empty
+type CertificateMapper func(*x509.Certificate) string
+
+// NewCertificateMapper returns with the mapper of the field of the client certificates,
+// the field is common-name, uri or email. The first URI or email SAN is used.
+func NewCertificateMapper(field string) (CertificateMapper, error) {
+	switch field {
+	case "common-name":
+		return func(cert *x509.Certificate) string {
+			return cert.Subject.CommonName
+		}, nil
+	case "uri":
+		return func(cert *x509.Certificate) string {
+			if len(cert.URIs) == 0 {
+				return ""
+			}
+			return cert.URIs[0].String()
+		}, nil
+	case "email":
+		return func(cert *x509.Certificate) string {
+			if len(cert.EmailAddresses) == 0 {
+				return ""
+			}
+			return cert.EmailAddresses[0]
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported client certificate field: %v", field)
+	}
+}
+
+// principal returns with the principal of the verified client certificate of the connection,
+// nil if the connection has no verified client certificate
+func (m CertificateMapper) principal(ctx context.Context) *Principal {
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		return nil
+	}
+
+	subject := m(cert)
+	if subject == "" {
+		return nil
+	}
+
+	return &Principal{Subject: subject, Issuer: cert.Issuer.String(), Certificate: true}
+}
+
+// peerCertificate returns with the leaf of the verified client certificate chain of the connection
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return info.State.VerifiedChains[0][0]
+}
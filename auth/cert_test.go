@@ -0,0 +1,177 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/halimi/todo-list-service/auth"
+)
+
+// clientCert returns with a client certificate of the fields
+func clientCert() *x509.Certificate {
+	return &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "bob"},
+		Issuer:         pkix.Name{CommonName: "Client CA"},
+		URIs:           []*url.URL{{Scheme: "spiffe", Host: "example.com", Path: "/bob"}},
+		EmailAddresses: []string{"bob@example.com"},
+	}
+}
+
+// peerContext returns with a context of a TLS connection with the verified client certificate
+func peerContext(ctx context.Context, cert *x509.Certificate) context.Context {
+	state := tls.ConnectionState{}
+	if cert != nil {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestNewCertificateMapper(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"common-name", "bob"},
+		{"uri", "spiffe://example.com/bob"},
+		{"email", "bob@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			mapper, err := auth.NewCertificateMapper(tt.field)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := mapper(clientCert()); got != tt.want {
+				t.Fatalf("Want: %v, Got: %v\n", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := auth.NewCertificateMapper("serial"); err == nil {
+		t.Fatalf("Want: error, Got: nil\n")
+	}
+}
+
+func TestAuthenticator(t *testing.T) {
+	mapper, err := auth.NewCertificateMapper("common-name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &auth.Authenticator{Tokens: newVerifier(), Certificates: mapper}
+	token := sign(t, jwt.SigningMethodHS256, "", claims("alice", time.Hour), secret)
+	noName := clientCert()
+	noName.Subject = pkix.Name{}
+
+	tests := []struct {
+		name          string
+		authorization string
+		cert          *x509.Certificate
+		code          codes.Code
+		subject       string
+	}{
+		{"Token", "Bearer " + token, nil, codes.OK, "alice"},
+		{"TokenBeforeCertificate", "Bearer " + token, clientCert(), codes.OK, "alice"},
+		{"InvalidToken", "Bearer invalid", clientCert(), codes.Unauthenticated, ""},
+		{"Certificate", "", clientCert(), codes.OK, "bob"},
+		{"NoSubject", "", noName, codes.Unauthenticated, ""},
+		{"Nothing", "", nil, codes.Unauthenticated, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if tt.authorization != "" {
+				md.Set("authorization", tt.authorization)
+			}
+			ctx := peerContext(metadata.NewIncomingContext(context.Background(), md), tt.cert)
+
+			var got *auth.Principal
+			unary := func(ctx context.Context, req interface{}) (interface{}, error) {
+				got, _ = auth.FromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := a.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, unary)
+			if status.Code(err) != tt.code {
+				t.Fatalf("Want: %v, Got: %v\n", tt.code, err)
+			}
+
+			if tt.code == codes.OK && got.Subject != tt.subject {
+				t.Fatalf("Want: %v, Got: %v\n", tt.subject, got)
+			}
+
+			got = nil
+			stream := func(srv interface{}, ss grpc.ServerStream) error {
+				got, _ = auth.FromContext(ss.Context())
+				return nil
+			}
+
+			err = a.StreamServerInterceptor()(nil, &serverStream{ctx: ctx}, &grpc.StreamServerInfo{}, stream)
+			if status.Code(err) != tt.code {
+				t.Fatalf("Want: %v, Got: %v\n", tt.code, err)
+			}
+
+			if tt.code == codes.OK && got.Subject != tt.subject {
+				t.Fatalf("Want: %v, Got: %v\n", tt.subject, got)
+			}
+		})
+	}
+
+	// the issuer of the certificate vouched for the caller, the owner is not the owner of a token of the subject
+	ctx := peerContext(context.Background(), clientCert())
+	_, err = a.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		p, _ := auth.FromContext(ctx)
+		if p.Issuer != "CN=Client CA" || p.Owner() != "cert:CN=Client CA:bob" {
+			t.Fatalf("Want: CN=Client CA cert:CN=Client CA:bob, Got: %v %v\n", p.Issuer, p.Owner())
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCertificateOwner(t *testing.T) {
+	mapper, err := auth.NewCertificateMapper("common-name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &auth.Authenticator{Certificates: mapper}
+
+	// the certificates of the same common name issued by different CAs are different owners
+	other := clientCert()
+	other.Issuer = pkix.Name{CommonName: "Other CA", Organization: []string{"Example"}}
+
+	var owners []string
+	for _, cert := range []*x509.Certificate{clientCert(), other} {
+		_, err := a.UnaryServerInterceptor()(peerContext(context.Background(), cert), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			p, _ := auth.FromContext(ctx)
+			owners = append(owners, p.Owner())
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{"cert:CN=Client CA:bob", "cert:CN=Other CA,O=Example:bob"}
+	if len(owners) != 2 || owners[0] != want[0] || owners[1] != want[1] {
+		t.Fatalf("Want: %v, Got: %v\n", want, owners)
+	}
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTVerifier is checking the JWTs signed with HS256 or RS256 by the keys of the key set,
+// the tokens have to expire and have a subject. The issuer and the audience are checked
+// if they are set.
+type JWTVerifier struct {
+	Keys     *KeySet
+	Issuer   string
+	Audience string
+	// Leeway is the allowed clock skew when the time claims are checked
+	Leeway time.Duration
+}
+
+// Verify returns with the principal of the subject of the token
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.Leeway),
+	}
+	if v.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.Issuer))
+	}
+	if v.Audience != "" {
+		opts = append(opts, jwt.WithAudience(v.Audience))
+	}
+
+	var claims jwt.RegisteredClaims
+	if _, err := jwt.ParseWithClaims(token, &claims, v.Keys.key, opts...); err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	return &Principal{Subject: claims.Subject, Issuer: claims.Issuer}, nil
+}
+
+// KeySet is holding the HS256 secrets and the RS256 public keys by their IDs. The key of a token
+// is selected by its kid header, a token without kid is checked by the key without ID, or by
+// the only key of its algorithm.
+type KeySet struct {
+	secrets map[string][]byte
+	public  map[string]*rsa.PublicKey
+}
+
+// AddSecret is adding an HS256 secret to the set
+func (k *KeySet) AddSecret(kid string, secret []byte) {
+	if k.secrets == nil {
+		k.secrets = make(map[string][]byte)
+	}
+
+	k.secrets[kid] = secret
+}
+
+// AddPublicKey is adding an RS256 public key to the set
+func (k *KeySet) AddPublicKey(kid string, key *rsa.PublicKey) {
+	if k.public == nil {
+		k.public = make(map[string]*rsa.PublicKey)
+	}
+
+	k.public[kid] = key
+}
+
+// Len returns with the number of the keys
+func (k *KeySet) Len() int {
+	return len(k.secrets) + len(k.public)
+}
+
+// jwk is a key of a JSON Web Key Set, only the RSA and the symmetric keys are read
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// LoadJWKS is adding the signing keys of the JSON Web Key Set file to the set, the RSA keys
+// are used for RS256 and the symmetric (oct) keys for HS256
+func (k *KeySet) LoadJWKS(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return fmt.Errorf("could not parse the JWKS %v: %v", path, err)
+	}
+
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		switch {
+		case key.Kty == "RSA" && (key.Alg == "" || key.Alg == jwt.SigningMethodRS256.Alg()):
+			public, err := rsaPublicKey(key)
+			if err != nil {
+				return fmt.Errorf("could not parse the key %q of the JWKS %v: %v", key.Kid, path, err)
+			}
+			k.AddPublicKey(key.Kid, public)
+		case key.Kty == "oct" && (key.Alg == "" || key.Alg == jwt.SigningMethodHS256.Alg()):
+			secret, err := base64.RawURLEncoding.DecodeString(key.K)
+			if err != nil || len(secret) == 0 {
+				return fmt.Errorf("could not parse the key %q of the JWKS %v: invalid secret", key.Kid, path)
+			}
+			k.AddSecret(key.Kid, secret)
+		}
+	}
+
+	return nil
+}
+
+// rsaPublicKey is decoding the modulus and the exponent of the RSA key
+func rsaPublicKey(key jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil || len(n) == 0 {
+		return nil, errors.New("invalid modulus")
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// key returns with the key checking the signature of the token
+func (k *KeySet) key(token *jwt.Token) (interface{}, error) {
+	if k == nil {
+		return nil, errors.New("no keys")
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return lookupKey(k.secrets, kid)
+	case jwt.SigningMethodRS256.Alg():
+		return lookupKey(k.public, kid)
+	}
+
+	return nil, fmt.Errorf("unsupported algorithm: %v", token.Method.Alg())
+}
+
+// lookupKey returns with the key of the ID, or the only key if the ID is empty
+func lookupKey[K any](keys map[string]K, kid string) (K, error) {
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+
+	var zero K
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+
+	if kid == "" {
+		return zero, errors.New("no key for the token")
+	}
+
+	return zero, fmt.Errorf("unknown key: %q", kid)
+}
@@ -8,24 +8,84 @@ import (
 	"log"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/halimi/todo-list-service/tlsconfig"
 	"github.com/halimi/todo-list-service/todolistpb"
+	"github.com/halimi/todo-list-service/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
+// bearerToken is sending the token in the authorization metadata of every request
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
+
 func printTodo(t *todolistpb.Todo) {
 	fmt.Println("Todo:")
 	fmt.Println("  Id:", t.GetId())
 	fmt.Println("  Title:", t.GetTitle())
 	fmt.Println("  Note:", t.GetNote())
 	fmt.Println("  Due date:", ptypes.TimestampString(t.GetDueDate()))
+	fmt.Println("  Status:", t.GetStatus())
+	fmt.Println("  Tags:", t.GetTags())
+	fmt.Println("  Priority:", t.GetPriority())
+	fmt.Println("  Created at:", ptypes.TimestampString(t.GetCreatedAt()))
+	if t.GetCompletedAt() != nil {
+		fmt.Println("  Completed at:", ptypes.TimestampString(t.GetCompletedAt()))
+	}
+}
+
+func printTodoList(l *todolistpb.TodoList) {
+	fmt.Println("TodoList:")
+	fmt.Println("  Id:", l.GetId())
+	fmt.Println("  Name:", l.GetName())
+	fmt.Println("  Description:", l.GetDescription())
+	fmt.Println("  Color:", l.GetColor())
+	fmt.Println("  Created at:", ptypes.TimestampString(l.GetCreatedAt()))
+}
+
+func createTodoList(c todolistpb.TodoListServiceClient) int32 {
+	fmt.Println("Creating TodoList")
+	list := &todolistpb.TodoList{
+		Name:        "First List",
+		Description: "This is a test",
+		Color:       "#00ff00",
+	}
+	res, err := c.CreateTodoList(context.Background(), &todolistpb.CreateTodoListRequest{TodoList: list})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+	resList := res.GetTodoList()
+	printTodoList(resList)
+
+	return resList.GetId()
+}
+
+func deleteTodoList(c todolistpb.TodoListServiceClient, id int32) {
+	fmt.Println("Deleting TodoList")
+
+	_, err := c.DeleteTodoList(context.Background(), &todolistpb.DeleteTodoListRequest{ListId: id})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+
+	fmt.Println("Successfully deleted:", id)
 }
 
-func createTodo(c todolistpb.TodoListServiceClient) int32 {
+func createTodo(c todolistpb.TodoListServiceClient, listID int32) int32 {
 	fmt.Println("Creating Todo")
 	todo := &todolistpb.Todo{
 		Title:   "First Todo",
 		Note:    "This is a test",
 		DueDate: ptypes.TimestampNow(),
+		ListId:  listID,
 	}
 	res, err := c.CreateTodo(context.Background(), &todolistpb.CreateTodoRequest{Todo: todo})
 	if err != nil {
@@ -48,13 +108,14 @@ func readTodo(c todolistpb.TodoListServiceClient, id int32) {
 	printTodo(resTodo)
 }
 
-func updateTodo(c todolistpb.TodoListServiceClient, id int32) {
+func updateTodo(c todolistpb.TodoListServiceClient, id int32, listID int32) {
 	fmt.Println("Updating Todo")
 	todo := &todolistpb.Todo{
 		Id:      id,
 		Title:   "Updated Todo",
 		Note:    "This is an updated test",
 		DueDate: ptypes.TimestampNow(),
+		ListId:  listID,
 	}
 
 	res, err := c.UpdateTodo(context.Background(), &todolistpb.UpdateTodoRequest{Todo: todo})
@@ -65,6 +126,26 @@ func updateTodo(c todolistpb.TodoListServiceClient, id int32) {
 	printTodo(resTodo)
 }
 
+func completeTodo(c todolistpb.TodoListServiceClient, id int32) {
+	fmt.Println("Completing Todo")
+
+	res, err := c.CompleteTodo(context.Background(), &todolistpb.CompleteTodoRequest{TodoId: id})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+	printTodo(res.GetTodo())
+}
+
+func reopenTodo(c todolistpb.TodoListServiceClient, id int32) {
+	fmt.Println("Reopening Todo")
+
+	res, err := c.ReopenTodo(context.Background(), &todolistpb.ReopenTodoRequest{TodoId: id})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+	printTodo(res.GetTodo())
+}
+
 func deleteTodo(c todolistpb.TodoListServiceClient, id int32) {
 	fmt.Println("Deleting Todo")
 
@@ -79,19 +160,34 @@ func deleteTodo(c todolistpb.TodoListServiceClient, id int32) {
 func listTodos(c todolistpb.TodoListServiceClient) {
 	fmt.Println("Listing Todos")
 
-	stream, err := c.ListTodos(context.Background(), &todolistpb.ListTodosRequest{})
-	if err != nil {
-		log.Fatalf("Server error: %v", err)
+	req := &todolistpb.ListTodosRequest{
+		SortBy:   todolistpb.ListTodosRequest_DUE_DATE,
+		PageSize: 10,
 	}
+
 	for {
-		res, err := stream.Recv()
-		if err == io.EOF {
-			break
-		}
+		stream, err := c.ListTodos(context.Background(), req)
 		if err != nil {
-			log.Fatalf("Stream error: %v", err)
+			log.Fatalf("Server error: %v", err)
+		}
+
+		next := ""
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("Stream error: %v", err)
+			}
+			printTodo(res.GetTodo())
+			next = res.GetNextPageToken()
 		}
-		printTodo(res.GetTodo())
+
+		if next == "" {
+			break
+		}
+		req.PageToken = next
 	}
 }
 
@@ -100,13 +196,48 @@ func main() {
 
 	host := flag.String("host", "localhost", "Service host name")
 	port := flag.String("port", "5000", "Service port number")
+	token := flag.String("token", "", "Bearer token sent with the requests if the service requires authentication")
+	useTLS := flag.Bool("tls", false, "Connect to the service with TLS")
+	tlsCAFile := flag.String("tls-ca-file", "", "PEM file of the CAs of the service certificate, the system CAs are used if empty")
+	tlsCertFile := flag.String("tls-cert-file", "", "PEM file of the client certificate sent if the service requires one")
+	tlsKeyFile := flag.String("tls-key-file", "", "PEM file of the private key of the client certificate")
+	tlsServerName := flag.String("tls-server-name", "", "Name of the service certificate, the host is used if empty")
+	traceExporter := flag.String("trace-exporter", "none", "Exporter of the OpenTelemetry traces: none, otlp, stdout or file")
+	traceOTLPEndpoint := flag.String("trace-otlp-endpoint", "", "host:port of the OTLP/HTTP collector, OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4318 if empty")
+	traceOTLPInsecure := flag.Bool("trace-otlp-insecure", false, "Send the traces to the OTLP collector without TLS")
+	traceFile := flag.String("trace-file", "client-traces.jsonl", "File of the file trace exporter")
 
 	flag.Parse()
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName:  "todo-list-client",
+		Exporter:     *traceExporter,
+		OTLPEndpoint: *traceOTLPEndpoint,
+		OTLPInsecure: *traceOTLPInsecure,
+		File:         *traceFile,
+		SampleRatio:  1,
+	})
+	if err != nil {
+		log.Fatalf("Could not set up the tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	connStr := fmt.Sprintf("%v:%v", *host, *port)
-	opts := grpc.WithInsecure()
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if *useTLS {
+		config, err := tlsconfig.Client(*tlsCAFile, *tlsCertFile, *tlsKeyFile, *tlsServerName)
+		if err != nil {
+			log.Fatalf("Could not load the TLS configuration: %v", err)
+		}
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}
+	}
+	if *token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken(*token)))
+	}
+	// the trace context of the calls is sent to the service
+	opts = append(opts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
 
-	cc, err := grpc.Dial(connStr, opts)
+	cc, err := grpc.Dial(connStr, opts...)
 	if err != nil {
 		log.Fatalf("Could not connect: %v", err)
 	}
@@ -114,13 +245,21 @@ func main() {
 
 	c := todolistpb.NewTodoListServiceClient(cc)
 
-	id := createTodo(c)
+	listID := createTodoList(c)
+
+	id := createTodo(c, listID)
 
 	readTodo(c, id)
 
-	updateTodo(c, id)
+	updateTodo(c, id, listID)
+
+	completeTodo(c, id)
+
+	reopenTodo(c, id)
 
 	listTodos(c)
 
 	deleteTodo(c, id)
+
+	deleteTodoList(c, listID)
 }
@@ -0,0 +1,278 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// Broadcaster is a Repository streaming the changes of the todos of the wrapped repository to
+// the watchers of the process. The writes are serialized so the events are in the order of the
+// changes, the last events are kept in memory to resume the watches after a reconnect.
+type Broadcaster struct {
+	Repository
+
+	size  int
+	epoch int64
+
+	writeMu sync.Mutex
+
+	mu     sync.Mutex
+	events []*Event
+	lastID int64
+	wake   notifier
+}
+
+// NewBroadcaster returns with a broadcaster of the repository keeping at least the last size events
+func NewBroadcaster(repo Repository, size int) *Broadcaster {
+	if size < 1 {
+		size = 1
+	}
+
+	return &Broadcaster{
+		Repository: repo,
+		size:       size,
+		// the resume tokens of an other process are expired
+		epoch: time.Now().UnixNano(),
+	}
+}
+
+// Watch is calling fn with the events after the resume token, it returns ErrResumeTokenExpired
+// if the events after the token are not kept anymore
+func (b *Broadcaster) Watch(ctx context.Context, resumeToken string, fn func(*Event) error) error {
+	b.mu.Lock()
+	after := b.lastID
+	b.mu.Unlock()
+
+	if resumeToken != "" {
+		var err error
+		if after, err = b.parseResumeToken(resumeToken); err != nil {
+			return err
+		}
+	}
+
+	for {
+		b.mu.Lock()
+		first := b.lastID - int64(len(b.events)) + 1
+		if after+1 < first || after > b.lastID {
+			b.mu.Unlock()
+			return ErrResumeTokenExpired
+		}
+		pending := b.events[after+1-first:]
+		wait := b.wake.wait()
+		b.mu.Unlock()
+
+		for _, e := range pending {
+			if err := fn(e); err != nil {
+				return err
+			}
+			after++
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wait:
+		}
+	}
+}
+
+// Insert is inserting the todo and publishes its creation
+func (b *Broadcaster) Insert(ctx context.Context, todo *todolistpb.Todo) (int32, error) {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	id, err := b.Repository.Insert(ctx, todo)
+	if err != nil {
+		return id, err
+	}
+
+	b.publishTodo(ctx, todolistpb.WatchTodosResponse_CREATED, id)
+
+	return id, nil
+}
+
+// Update is updating the todo and publishes the change
+func (b *Broadcaster) Update(ctx context.Context, todo *todolistpb.Todo, paths []string, expectedVersion int64) (*todolistpb.Todo, error) {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	t, err := b.Repository.Update(ctx, todo, paths, expectedVersion)
+	if err != nil || len(paths) == 0 {
+		return t, err
+	}
+
+	b.publish(todolistpb.WatchTodosResponse_UPDATED, t)
+
+	return t, nil
+}
+
+// Delete is moving the todo to the trash and publishes its last state
+func (b *Broadcaster) Delete(ctx context.Context, id int32, expectedVersion int64) (int64, error) {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	todo, err := b.Repository.Get(ctx, id)
+	if err != nil {
+		return -1, err
+	}
+
+	count, err := b.Repository.Delete(ctx, id, expectedVersion)
+	if err != nil || count == 0 {
+		return count, err
+	}
+
+	b.publish(todolistpb.WatchTodosResponse_DELETED, todo)
+
+	return count, nil
+}
+
+// Restore is moving the todo back from the trash and publishes its creation
+func (b *Broadcaster) Restore(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	todo, err := b.Repository.Restore(ctx, id)
+	if err != nil {
+		return todo, err
+	}
+
+	b.publish(todolistpb.WatchTodosResponse_CREATED, todo)
+
+	return todo, nil
+}
+
+// Complete is setting the todo to done and publishes the change
+func (b *Broadcaster) Complete(ctx context.Context, id int32, completedAt *timestamp.Timestamp) (*todolistpb.Todo, error) {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	todo, err := b.Repository.Complete(ctx, id, completedAt)
+	if err != nil {
+		return todo, err
+	}
+
+	b.publish(todolistpb.WatchTodosResponse_UPDATED, todo)
+
+	return todo, nil
+}
+
+// Reopen is setting the todo to open and publishes the change
+func (b *Broadcaster) Reopen(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	todo, err := b.Repository.Reopen(ctx, id)
+	if err != nil {
+		return todo, err
+	}
+
+	b.publish(todolistpb.WatchTodosResponse_UPDATED, todo)
+
+	return todo, nil
+}
+
+// DeleteTodoList is deleting the list and publishes the changes of its todos, they are moved
+// to the trash or to the inbox
+func (b *Broadcaster) DeleteTodoList(ctx context.Context, id int32, deleteTodos bool) (int64, error) {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	var todos []*todolistpb.Todo
+	req := &todolistpb.ListTodosRequest{ListId: id, PageSize: maxPageSize}
+	for {
+		page, next, err := b.Repository.List(ctx, req)
+		if err != nil {
+			return -1, err
+		}
+		todos = append(todos, page...)
+
+		if next == "" {
+			break
+		}
+		req.PageToken = next
+	}
+
+	count, err := b.Repository.DeleteTodoList(ctx, id, deleteTodos)
+	if err != nil || count == 0 {
+		return count, err
+	}
+
+	for _, todo := range todos {
+		if deleteTodos {
+			b.publish(todolistpb.WatchTodosResponse_DELETED, todo)
+		} else {
+			b.publishTodo(ctx, todolistpb.WatchTodosResponse_UPDATED, todo.GetId())
+		}
+	}
+
+	return count, nil
+}
+
+// publishTodo is publishing the event with the current state of the todo
+func (b *Broadcaster) publishTodo(ctx context.Context, eventType todolistpb.WatchTodosResponse_EventType, id int32) {
+	// the change is already done, so the event is published even if the request is cancelled
+	todo, err := b.Repository.Get(context.WithoutCancel(ctx), id)
+	if err != nil {
+		log.Printf("Could not get the changed todo %v: %v", id, err)
+		return
+	}
+
+	b.publish(eventType, todo)
+}
+
+// publish is adding the event of the todo to the stream and wakes up the watchers,
+// nothing is published for an empty todo which was not found
+func (b *Broadcaster) publish(eventType todolistpb.WatchTodosResponse_EventType, todo *todolistpb.Todo) {
+	if todo.GetId() == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	b.events = append(b.events, &Event{
+		Type:        eventType,
+		Todo:        cloneTodo(todo),
+		ResumeToken: fmt.Sprintf("%v.%v", b.epoch, b.lastID),
+	})
+
+	// the old events are dropped in batches, so at least the last size events are kept
+	if len(b.events) >= 2*b.size {
+		b.events = append([]*Event(nil), b.events[len(b.events)-b.size:]...)
+	}
+
+	b.wake.notify()
+}
+
+// parseResumeToken returns with the ID of the event of the resume token
+func (b *Broadcaster) parseResumeToken(token string) (int64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return 0, ErrInvalidResumeToken
+	}
+
+	epoch, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidResumeToken
+	}
+
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidResumeToken
+	}
+
+	if epoch != b.epoch {
+		return 0, ErrResumeTokenExpired
+	}
+
+	return id, nil
+}
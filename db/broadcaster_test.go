@@ -0,0 +1,57 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/halimi/todo-list-service/db"
+)
+
+func TestBroadcasterResumeTokenExpired(t *testing.T) {
+	b := db.NewBroadcaster(setupMemory(t), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tokens := make(chan string, 100)
+	go b.Watch(ctx, "", func(e *db.Event) error {
+		tokens <- e.ResumeToken
+		return nil
+	})
+
+	// the todos are inserted until the watch is started
+	var token string
+	for token == "" {
+		if _, err := b.Insert(ctx, getTestTodo(0, "Test Todo")); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case token = <-tokens:
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// only the last event is kept
+	for i := 0; i < 2; i++ {
+		if _, err := b.Insert(ctx, getTestTodo(0, "Test Todo")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	watch := func(b *db.Broadcaster) error {
+		return b.Watch(ctx, token, func(e *db.Event) error {
+			return nil
+		})
+	}
+
+	if err := watch(b); err != db.ErrResumeTokenExpired {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrResumeTokenExpired, err)
+	}
+
+	// the tokens of an other broadcaster, like the one before a restart, are expired too
+	if err := watch(db.NewBroadcaster(setupMemory(t), 100)); err != db.ErrResumeTokenExpired {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrResumeTokenExpired, err)
+	}
+}
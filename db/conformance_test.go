@@ -0,0 +1,152 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/db/dbtest"
+)
+
+func TestPostgresConformance(t *testing.T) {
+	dbtest.RunRepositoryConformance(t, func(t *testing.T) db.Repository {
+		return &db.Postgres{DB: setupDB()}
+	})
+}
+
+func TestSQLiteConformance(t *testing.T) {
+	dbtest.RunRepositoryConformance(t, func(t *testing.T) db.Repository {
+		return setupSQLite()
+	})
+}
+
+func TestMemoryConformance(t *testing.T) {
+	dbtest.RunRepositoryConformance(t, func(t *testing.T) db.Repository {
+		return setupMemory(t)
+	})
+}
+
+func TestPostgresWatcherConformance(t *testing.T) {
+	dbtest.RunWatcherConformance(t, func(t *testing.T) db.Repository {
+		return &db.Postgres{DB: setupDB()}
+	})
+}
+
+func TestBroadcasterConformance(t *testing.T) {
+	factories := map[string]dbtest.Factory{
+		"SQLite": func(t *testing.T) db.Repository {
+			return db.NewBroadcaster(setupSQLite(), 100)
+		},
+		"Memory": func(t *testing.T) db.Repository {
+			return db.NewBroadcaster(setupMemory(t), 100)
+		},
+	}
+
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			dbtest.RunRepositoryConformance(t, factory)
+			dbtest.RunWatcherConformance(t, factory)
+		})
+	}
+}
+
+func TestPostgresOutboxConformance(t *testing.T) {
+	dbtest.RunOutboxConformance(t, func(t *testing.T) db.Repository {
+		return &db.Postgres{DB: setupDB()}
+	})
+}
+
+func TestSQLiteOutboxConformance(t *testing.T) {
+	dbtest.RunOutboxConformance(t, func(t *testing.T) db.Repository {
+		return setupSQLite()
+	})
+}
+
+func TestMemoryOutboxConformance(t *testing.T) {
+	dbtest.RunOutboxConformance(t, func(t *testing.T) db.Repository {
+		return setupMemory(t)
+	})
+}
+
+func TestPostgresWebhookStoreConformance(t *testing.T) {
+	dbtest.RunWebhookStoreConformance(t, func(t *testing.T) db.Repository {
+		return &db.Postgres{DB: setupDB()}
+	})
+}
+
+func TestSQLiteWebhookStoreConformance(t *testing.T) {
+	dbtest.RunWebhookStoreConformance(t, func(t *testing.T) db.Repository {
+		return setupSQLite()
+	})
+}
+
+func TestMemoryWebhookStoreConformance(t *testing.T) {
+	dbtest.RunWebhookStoreConformance(t, func(t *testing.T) db.Repository {
+		return setupMemory(t)
+	})
+}
+
+func TestPostgresHistoryConformance(t *testing.T) {
+	dbtest.RunHistoryConformance(t, func(t *testing.T) db.Repository {
+		return &db.Postgres{DB: setupDB()}
+	})
+}
+
+func TestSQLiteHistoryConformance(t *testing.T) {
+	dbtest.RunHistoryConformance(t, func(t *testing.T) db.Repository {
+		return setupSQLite()
+	})
+}
+
+func TestMemoryHistoryConformance(t *testing.T) {
+	dbtest.RunHistoryConformance(t, func(t *testing.T) db.Repository {
+		return setupMemory(t)
+	})
+}
+
+func TestPostgresMemberStoreConformance(t *testing.T) {
+	dbtest.RunMemberStoreConformance(t, func(t *testing.T) db.Repository {
+		return &db.Postgres{DB: setupDB()}
+	})
+}
+
+func TestSQLiteMemberStoreConformance(t *testing.T) {
+	dbtest.RunMemberStoreConformance(t, func(t *testing.T) db.Repository {
+		return setupSQLite()
+	})
+}
+
+func TestMemoryMemberStoreConformance(t *testing.T) {
+	dbtest.RunMemberStoreConformance(t, func(t *testing.T) db.Repository {
+		return setupMemory(t)
+	})
+}
+
+func TestPostgresTenantConformance(t *testing.T) {
+	dbtest.RunTenantConformance(t, func(t *testing.T) db.Repository {
+		return &db.Postgres{DB: setupDB()}
+	})
+}
+
+func TestPostgresRowLevelSecurityConformance(t *testing.T) {
+	dbtest.RunTenantConformance(t, func(t *testing.T) db.Repository {
+		conn := setupDB()
+		if err := db.EnforceRowLevelSecurity(context.Background(), conn); err != nil {
+			t.Fatal(err)
+		}
+
+		return &db.Postgres{DB: conn, RowLevelSecurity: true}
+	})
+}
+
+func TestSQLiteTenantConformance(t *testing.T) {
+	dbtest.RunTenantConformance(t, func(t *testing.T) db.Repository {
+		return setupSQLite()
+	})
+}
+
+func TestMemoryTenantConformance(t *testing.T) {
+	dbtest.RunTenantConformance(t, func(t *testing.T) db.Repository {
+		return setupMemory(t)
+	})
+}
@@ -0,0 +1,956 @@
+// Package dbtest is checking that the implementations of db.Repository behave the same way
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// Factory returns with a new empty repository, it is closed at the end of the test
+type Factory func(t *testing.T) db.Repository
+
+// RunRepositoryConformance is running the conformance tests on the repositories created by the factory
+func RunRepositoryConformance(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T, db.Repository)
+	}{
+		{"InsertGet", testInsertGet},
+		{"GetNotFound", testGetNotFound},
+		{"Timestamps", testTimestamps},
+		{"Unicode", testUnicode},
+		{"LargeNote", testLargeNote},
+		{"Update", testUpdate},
+		{"UpdateMask", testUpdateMask},
+		{"UpdateStatus", testUpdateStatus},
+		{"UpdateVersion", testUpdateVersion},
+		{"UpdateNotFound", testUpdateNotFound},
+		{"CompleteReopen", testCompleteReopen},
+		{"Delete", testDelete},
+		{"DeleteVersion", testDeleteVersion},
+		{"Trash", testTrash},
+		{"Purge", testPurge},
+		{"ListFilter", testListFilter},
+		{"ListSort", testListSort},
+		{"ListInvalidPageToken", testListInvalidPageToken},
+		{"CountTodos", testCountTodos},
+		{"TodoList", testTodoList},
+		{"DeleteTodoList", testDeleteTodoList},
+		{"ConcurrentWriters", testConcurrentWriters},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo := factory(t)
+			defer repo.Close()
+
+			tt.fn(t, repo)
+		})
+	}
+}
+
+// date returns with the timestamp of the date in UTC
+func date(t *testing.T, year int, month time.Month, day int) *timestamp.Timestamp {
+	ts, err := ptypes.TimestampProto(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ts
+}
+
+func getTestTodo(t *testing.T, id int32, title string) *todolistpb.Todo {
+	dd := date(t, 2000, 1, 1)
+
+	return &todolistpb.Todo{
+		Id:        id,
+		Title:     title,
+		Note:      "This is a test",
+		DueDate:   dd,
+		CreatedAt: dd,
+		Version:   1,
+	}
+}
+
+func getTestTodoList(t *testing.T, id int32, name string) *todolistpb.TodoList {
+	return &todolistpb.TodoList{
+		Id:          id,
+		Name:        name,
+		Description: "This is a test",
+		Color:       "#ffffff",
+		CreatedAt:   date(t, 2000, 1, 1),
+	}
+}
+
+func insert(t *testing.T, repo db.Repository, todo *todolistpb.Todo) int32 {
+	id, err := repo.Insert(context.Background(), todo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return id
+}
+
+func get(t *testing.T, repo db.Repository, id int32) *todolistpb.Todo {
+	todo, err := repo.Get(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return todo
+}
+
+func checkTodo(t *testing.T, want, got *todolistpb.Todo) {
+	t.Helper()
+
+	if !proto.Equal(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+}
+
+func testInsertGet(t *testing.T, repo db.Repository) {
+	id1 := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+	id2 := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	if id1 <= 0 || id2 <= 0 || id1 == id2 {
+		t.Fatalf("Want: different positive IDs, Got: %v, %v\n", id1, id2)
+	}
+
+	checkTodo(t, getTestTodo(t, id1, "Test Todo"), get(t, repo, id1))
+	checkTodo(t, getTestTodo(t, id2, "Test Todo"), get(t, repo, id2))
+
+	// the creation time is set if it is missing
+	todo := getTestTodo(t, 0, "Test Todo")
+	todo.CreatedAt = nil
+
+	before := time.Now().Add(-time.Second)
+	got := get(t, repo, insert(t, repo, todo))
+	after := time.Now().Add(time.Second)
+
+	createdAt, err := ptypes.Timestamp(got.GetCreatedAt())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if createdAt.Before(before) || createdAt.After(after) {
+		t.Fatalf("Want: between %v and %v, Got: %v\n", before, after, createdAt)
+	}
+}
+
+func testGetNotFound(t *testing.T, repo db.Repository) {
+	got := get(t, repo, 42)
+
+	if got.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v\n", got)
+	}
+}
+
+func testTimestamps(t *testing.T, repo db.Repository) {
+	// the databases are storing the timestamps with microsecond precision
+	for _, ts := range []time.Time{
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1969, 7, 20, 20, 17, 40, 0, time.UTC),
+		time.Date(2038, 1, 19, 3, 14, 8, 123456000, time.UTC),
+		time.Date(2999, 12, 31, 23, 59, 59, 999999000, time.FixedZone("CET", 3600)),
+	} {
+		pts, err := ptypes.TimestampProto(ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := getTestTodo(t, 0, "Test Todo")
+		want.DueDate = pts
+		want.CreatedAt = pts
+		want.Status = todolistpb.Status_DONE
+		want.CompletedAt = pts
+		want.Id = insert(t, repo, want)
+
+		checkTodo(t, want, get(t, repo, want.GetId()))
+	}
+}
+
+func testUnicode(t *testing.T, repo db.Repository) {
+	want := getTestTodo(t, 0, "Kaffee für das Café ☕ 日本語 🚀")
+	want.Note = "Ünïcödé nöte\nwith 'quotes', \"double quotes\", \\backslashes\\ and emoji 👩‍💻"
+	want.Tags = []string{"küche", "日本", "🏠"}
+	want.Id = insert(t, repo, want)
+	insert(t, repo, getTestTodo(t, 0, "Cafe without accent"))
+
+	checkTodo(t, want, get(t, repo, want.GetId()))
+
+	got, _, err := repo.List(context.Background(), &todolistpb.ListTodosRequest{
+		TitleContains: "CAFÉ",
+		Tags:          []string{"🏠"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+	checkTodo(t, want, got[0])
+}
+
+func testLargeNote(t *testing.T, repo db.Repository) {
+	want := getTestTodo(t, 0, "Test Todo")
+	want.Note = strings.Repeat("This is a large note. ", 64*1024)
+	want.Id = insert(t, repo, want)
+
+	checkTodo(t, want, get(t, repo, want.GetId()))
+}
+
+func testUpdate(t *testing.T, repo db.Repository) {
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	todo := getTestTodo(t, id, "Update Test Todo")
+	todo.Note = "Updated"
+	todo.DueDate = date(t, 2001, 2, 3)
+	todo.Tags = []string{"home"}
+	todo.Priority = 3
+
+	got, err := repo.Update(context.Background(), todo, db.UpdatePaths, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := proto.Clone(todo).(*todolistpb.Todo)
+	want.Version = 2
+
+	checkTodo(t, want, got)
+	checkTodo(t, want, get(t, repo, id))
+}
+
+func testUpdateMask(t *testing.T, repo db.Repository) {
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	// only the title is updated, the rest of the fields are kept
+	got, err := repo.Update(context.Background(), &todolistpb.Todo{Id: id, Title: "Renamed Test Todo"}, []string{"title"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(t, id, "Renamed Test Todo")
+	want.Version = 2
+
+	checkTodo(t, want, got)
+
+	// no paths are not changing anything
+	got, err = repo.Update(context.Background(), &todolistpb.Todo{Id: id}, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkTodo(t, want, got)
+}
+
+func testUpdateStatus(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	completedAt := date(t, 2000, 1, 2)
+	todo := &todolistpb.Todo{Id: id, Status: todolistpb.Status_DONE, CompletedAt: completedAt}
+
+	if _, err := repo.Update(ctx, todo, []string{"status"}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// updating to done again keeps the first completion time
+	todo.CompletedAt = date(t, 2000, 1, 3)
+	got, err := repo.Update(ctx, todo, []string{"status"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(t, id, "Test Todo")
+	want.Status = todolistpb.Status_DONE
+	want.CompletedAt = completedAt
+	want.Version = 3
+
+	checkTodo(t, want, got)
+
+	got, err = repo.Update(ctx, &todolistpb.Todo{Id: id, Status: todolistpb.Status_IN_PROGRESS}, []string{"status"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want.Status = todolistpb.Status_IN_PROGRESS
+	want.CompletedAt = nil
+	want.Version = 4
+
+	checkTodo(t, want, got)
+}
+
+func testUpdateVersion(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	todo := getTestTodo(t, id, "Update Test Todo")
+
+	if _, err := repo.Update(ctx, todo, db.UpdatePaths, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// the version moved to 2 with the first update
+	if _, err := repo.Update(ctx, todo, db.UpdatePaths, 1); err != db.ErrVersionMismatch {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrVersionMismatch, err)
+	}
+
+	got, err := repo.Update(ctx, todo, db.UpdatePaths, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(t, id, "Update Test Todo")
+	want.Version = 3
+
+	checkTodo(t, want, got)
+}
+
+func testUpdateNotFound(t *testing.T, repo db.Repository) {
+	for _, version := range []int64{0, 1} {
+		got, err := repo.Update(context.Background(), getTestTodo(t, 42, "Test Todo"), db.UpdatePaths, version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.GetId() != 0 {
+			t.Fatalf("Want: empty todo, Got: %v\n", got)
+		}
+	}
+}
+
+func testCompleteReopen(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	completedAt := date(t, 2000, 1, 2)
+	if _, err := repo.Complete(ctx, id, completedAt); err != nil {
+		t.Fatal(err)
+	}
+
+	// completing again keeps the first completion time
+	got, err := repo.Complete(ctx, id, date(t, 2000, 1, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(t, id, "Test Todo")
+	want.Status = todolistpb.Status_DONE
+	want.CompletedAt = completedAt
+	want.Version = 3
+
+	checkTodo(t, want, got)
+
+	got, err = repo.Reopen(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = getTestTodo(t, id, "Test Todo")
+	want.Version = 4
+
+	checkTodo(t, want, got)
+
+	got, err = repo.Complete(ctx, 42, completedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v\n", got)
+	}
+
+	got, err = repo.Reopen(ctx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v\n", got)
+	}
+}
+
+func testDelete(t *testing.T, repo db.Repository) {
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	// deleting again and deleting a missing todo are not failing
+	for _, want := range []int64{1, 0} {
+		got, err := repo.Delete(context.Background(), id, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Fatalf("Want: %v, Got: %v\n", want, got)
+		}
+	}
+
+	if got := get(t, repo, id); got.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v\n", got)
+	}
+}
+
+func testDeleteVersion(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	if _, err := repo.Delete(ctx, id, 2); err != db.ErrVersionMismatch {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrVersionMismatch, err)
+	}
+
+	got, err := repo.Delete(ctx, id, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != 1 {
+		t.Fatalf("Want: 1, Got: %v\n", got)
+	}
+
+	// the missing todo is not a version mismatch
+	if _, err := repo.Delete(ctx, id, 1); err != nil {
+		t.Fatalf("Want: nil, Got: %v\n", err)
+	}
+}
+
+func listTrash(t *testing.T, repo db.Repository) []*todolistpb.Todo {
+	todos, err := repo.ListTrash(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return todos
+}
+
+func testTrash(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+	id1 := insert(t, repo, getTestTodo(t, 0, "Test Todo 1"))
+	id2 := insert(t, repo, getTestTodo(t, 0, "Test Todo 2"))
+
+	for _, id := range []int32{id1, id2} {
+		if _, err := repo.Delete(ctx, id, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the todos of the trash are not found by the other calls
+	if got := get(t, repo, id1); got.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v\n", got)
+	}
+
+	if got := listIDs(t, repo, &todolistpb.ListTodosRequest{}); len(got) != 0 {
+		t.Fatalf("Want: no todos, Got: %v\n", got)
+	}
+
+	updated, err := repo.Update(ctx, &todolistpb.Todo{Id: id1, Title: "Renamed Todo"}, []string{"title"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	completed, err := repo.Complete(ctx, id1, date(t, 2000, 1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := repo.Reopen(ctx, id1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.GetId() != 0 || completed.GetId() != 0 || reopened.GetId() != 0 {
+		t.Fatalf("Want: empty todos, Got: %v %v %v\n", updated, completed, reopened)
+	}
+
+	// the last deleted is the first
+	trash := listTrash(t, repo)
+	if len(trash) != 2 || trash[0].GetId() != id2 || trash[1].GetId() != id1 {
+		t.Fatalf("Want: %v %v, Got: %v\n", id2, id1, trash)
+	}
+
+	for _, todo := range trash {
+		if todo.GetDeletedAt() == nil || todo.GetVersion() != 1 || todo.GetTitle() == "Renamed Todo" {
+			t.Fatalf("Want: unchanged todo in the trash, Got: %v\n", todo)
+		}
+	}
+
+	trashed, err := repo.GetTrashed(ctx, id1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkTodo(t, trash[1], trashed)
+
+	restored, err := repo.Restore(ctx, id1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkTodo(t, getTestTodo(t, id1, "Test Todo 1"), restored)
+	checkTodo(t, restored, get(t, repo, id1))
+
+	// the restored todo and the live todos are not in the trash
+	for _, id := range []int32{id1, 100} {
+		got, err := repo.Restore(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		trashed, err := repo.GetTrashed(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.GetId() != 0 || trashed.GetId() != 0 {
+			t.Fatalf("Want: empty todos, Got: %v %v\n", got, trashed)
+		}
+	}
+
+	if trash := listTrash(t, repo); len(trash) != 1 || trash[0].GetId() != id2 {
+		t.Fatalf("Want: %v, Got: %v\n", id2, trash)
+	}
+}
+
+func testPurge(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+	live := insert(t, repo, getTestTodo(t, 0, "Live Todo"))
+
+	for i := 0; i < 2; i++ {
+		id := insert(t, repo, getTestTodo(t, 0, "Deleted Todo"))
+		if _, err := repo.Delete(ctx, id, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the todos deleted after the time are kept
+	count, err := repo.Purge(ctx, date(t, 2000, 1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 || len(listTrash(t, repo)) != 2 {
+		t.Fatalf("Want: 0 purged, Got: %v\n", count)
+	}
+
+	later, err := ptypes.TimestampProto(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = repo.Purge(ctx, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 2 || len(listTrash(t, repo)) != 0 {
+		t.Fatalf("Want: 2 purged, Got: %v\n", count)
+	}
+
+	// the live todos are never purged
+	count, err = repo.Purge(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 || get(t, repo, live).GetId() != live {
+		t.Fatalf("Want: 0 purged, Got: %v\n", count)
+	}
+
+	// the purged todos can not be restored
+	if _, err := repo.Delete(ctx, live, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if count, err := repo.Purge(ctx, nil); err != nil || count != 1 {
+		t.Fatalf("Want: 1 purged, Got: %v %v\n", count, err)
+	}
+
+	restored, err := repo.Restore(ctx, live)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if restored.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v\n", restored)
+	}
+}
+
+// listIDs is reading all the pages of the request and returns with the IDs of the todos
+func listIDs(t *testing.T, repo db.Repository, req *todolistpb.ListTodosRequest) []int32 {
+	req = proto.Clone(req).(*todolistpb.ListTodosRequest)
+
+	var ids []int32
+	for {
+		todos, next, err := repo.List(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(todos) > db.PageSize(req) {
+			t.Fatalf("Want: at most %v todos, Got: %v\n", db.PageSize(req), len(todos))
+		}
+
+		for _, todo := range todos {
+			ids = append(ids, todo.GetId())
+		}
+
+		if next == "" {
+			return ids
+		}
+		req.PageToken = next
+	}
+}
+
+func checkIDs(t *testing.T, name string, want, got []int32) {
+	t.Helper()
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("%v: Want: %v, Got: %v\n", name, want, got)
+	}
+}
+
+func testListFilter(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+
+	listID, err := repo.InsertTodoList(ctx, getTestTodoList(t, 0, "Test List"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todo := getTestTodo(t, 0, "Buy milk")
+	todo.Tags = []string{"home", "shopping"}
+	todo.DueDate = date(t, 2000, 1, 1)
+	id1 := insert(t, repo, todo)
+
+	todo = getTestTodo(t, 0, "Buy a car")
+	todo.Tags = []string{"shopping"}
+	todo.DueDate = date(t, 2000, 1, 2)
+	todo.ListId = listID
+	id2 := insert(t, repo, todo)
+
+	todo = getTestTodo(t, 0, "Fix the MILK bottle")
+	todo.Status = todolistpb.Status_DONE
+	todo.CompletedAt = date(t, 2000, 1, 1)
+	todo.DueDate = date(t, 2000, 1, 3)
+	id3 := insert(t, repo, todo)
+
+	tests := map[string]struct {
+		req  *todolistpb.ListTodosRequest
+		want []int32
+	}{
+		"all":            {&todolistpb.ListTodosRequest{}, []int32{id1, id2, id3}},
+		"status":         {&todolistpb.ListTodosRequest{Status: []todolistpb.Status{todolistpb.Status_DONE}}, []int32{id3}},
+		"statuses":       {&todolistpb.ListTodosRequest{Status: []todolistpb.Status{todolistpb.Status_OPEN, todolistpb.Status_DONE}}, []int32{id1, id2, id3}},
+		"due before":     {&todolistpb.ListTodosRequest{DueBefore: date(t, 2000, 1, 2)}, []int32{id1}},
+		"due after":      {&todolistpb.ListTodosRequest{DueAfter: date(t, 2000, 1, 2)}, []int32{id3}},
+		"title contains": {&todolistpb.ListTodosRequest{TitleContains: "milk"}, []int32{id1, id3}},
+		"tags":           {&todolistpb.ListTodosRequest{Tags: []string{"shopping", "home"}}, []int32{id1}},
+		"unknown tag":    {&todolistpb.ListTodosRequest{Tags: []string{"work"}}, nil},
+		"list":           {&todolistpb.ListTodosRequest{ListId: listID}, []int32{id2}},
+		"inbox":          {&todolistpb.ListTodosRequest{Inbox: true}, []int32{id1, id3}},
+		"combined":       {&todolistpb.ListTodosRequest{TitleContains: "BUY", Tags: []string{"shopping"}, Inbox: true}, []int32{id1}},
+	}
+
+	for name, tt := range tests {
+		checkIDs(t, name, tt.want, listIDs(t, repo, tt.req))
+	}
+}
+
+func testListSort(t *testing.T, repo db.Repository) {
+	todos := []struct {
+		title     string
+		due       int
+		created   int
+		priority  int32
+		completed bool
+	}{
+		{"b", 3, 2, 1, false},
+		{"a", 1, 1, 2, false},
+		{"c", 2, 3, 0, true},
+		{"a", 1, 3, 1, false},
+		{"B", 2, 1, 2, false},
+	}
+
+	var ids []int32
+	for _, tt := range todos {
+		todo := getTestTodo(t, 0, tt.title)
+		todo.DueDate = date(t, 2000, 1, tt.due)
+		todo.CreatedAt = date(t, 2000, 1, tt.created)
+		todo.Priority = tt.priority
+		ids = append(ids, insert(t, repo, todo))
+	}
+
+	// the expected order as indexes of the todos, the ID breaks the ties
+	tests := []struct {
+		sortBy todolistpb.ListTodosRequest_SortBy
+		want   []int
+	}{
+		{todolistpb.ListTodosRequest_ID, []int{0, 1, 2, 3, 4}},
+		{todolistpb.ListTodosRequest_DUE_DATE, []int{1, 3, 2, 4, 0}},
+		{todolistpb.ListTodosRequest_CREATED_AT, []int{1, 4, 0, 2, 3}},
+		{todolistpb.ListTodosRequest_TITLE, []int{4, 1, 3, 0, 2}},
+		{todolistpb.ListTodosRequest_PRIORITY, []int{2, 0, 3, 1, 4}},
+	}
+
+	for _, tt := range tests {
+		var want []int32
+		for _, i := range tt.want {
+			want = append(want, ids[i])
+		}
+
+		for _, pageSize := range []int32{0, 1, 2} {
+			req := &todolistpb.ListTodosRequest{SortBy: tt.sortBy, PageSize: pageSize}
+			checkIDs(t, fmt.Sprintf("%v page size %v", tt.sortBy, pageSize), want, listIDs(t, repo, req))
+
+			var reversed []int32
+			for i := len(want) - 1; i >= 0; i-- {
+				reversed = append(reversed, want[i])
+			}
+
+			req.Descending = true
+			checkIDs(t, fmt.Sprintf("%v descending page size %v", tt.sortBy, pageSize), reversed, listIDs(t, repo, req))
+		}
+	}
+}
+
+func testListInvalidPageToken(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+	insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+	insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	req := &todolistpb.ListTodosRequest{PageSize: 1}
+	_, next, err := repo.List(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the token belongs to a different sort order
+	req.PageToken = next
+	req.SortBy = todolistpb.ListTodosRequest_TITLE
+	if _, _, err := repo.List(ctx, req); err != db.ErrInvalidPageToken {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrInvalidPageToken, err)
+	}
+
+	req.PageToken = "invalid"
+	if _, _, err := repo.List(ctx, req); err != db.ErrInvalidPageToken {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrInvalidPageToken, err)
+	}
+}
+
+func testCountTodos(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+
+	var ids []int32
+	for day := 1; day <= 4; day++ {
+		todo := getTestTodo(t, 0, "Test Todo")
+		todo.DueDate = date(t, 2000, 1, day)
+		ids = append(ids, insert(t, repo, todo))
+	}
+
+	// the done and the deleted todos are not counted
+	if _, err := repo.Complete(ctx, ids[0], date(t, 2000, 1, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Delete(ctx, ids[3], 0); err != nil {
+		t.Fatal(err)
+	}
+
+	open, overdue, err := repo.CountTodos(ctx, date(t, 2000, 1, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if open != 2 || overdue != 1 {
+		t.Fatalf("Want: 2 1, Got: %v %v\n", open, overdue)
+	}
+}
+
+func testTodoList(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+
+	id, err := repo.InsertTodoList(ctx, getTestTodoList(t, 0, "Test List"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := repo.GetTodoList(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodoList(t, id, "Test List")
+	if !proto.Equal(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	want = getTestTodoList(t, id, "Update Test List ✓")
+	got, err = repo.UpdateTodoList(ctx, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	id2, err := repo.InsertTodoList(ctx, getTestTodoList(t, 0, "Test List"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lists, err := repo.ListTodoLists(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lists) != 2 || !proto.Equal(lists[0], want) || lists[1].GetId() != id2 {
+		t.Fatalf("Want: %v and %v, Got: %v\n", want, id2, lists)
+	}
+
+	got, err = repo.GetTodoList(ctx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.GetId() != 0 {
+		t.Fatalf("Want: empty list, Got: %v\n", got)
+	}
+
+	got, err = repo.UpdateTodoList(ctx, getTestTodoList(t, 42, "Test List"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.GetId() != 0 {
+		t.Fatalf("Want: empty list, Got: %v\n", got)
+	}
+}
+
+func testDeleteTodoList(t *testing.T, repo db.Repository) {
+	ctx := context.Background()
+
+	for _, deleteTodos := range []bool{false, true} {
+		listID, err := repo.InsertTodoList(ctx, getTestTodoList(t, 0, "Test List"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		todo := getTestTodo(t, 0, "Test Todo")
+		todo.ListId = listID
+		id := insert(t, repo, todo)
+
+		count, err := repo.DeleteTodoList(ctx, listID, deleteTodos)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 1 {
+			t.Fatalf("Want: 1, Got: %v\n", count)
+		}
+
+		// the todo is moved to the trash together with the list or to the inbox
+		want := getTestTodo(t, id, "Test Todo")
+		if deleteTodos {
+			want = &todolistpb.Todo{}
+		}
+
+		checkTodo(t, want, get(t, repo, id))
+
+		if deleteTodos {
+			restored, err := repo.Restore(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkTodo(t, getTestTodo(t, id, "Test Todo"), restored)
+		}
+
+		count, err = repo.DeleteTodoList(ctx, listID, deleteTodos)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 0 {
+			t.Fatalf("Want: 0, Got: %v\n", count)
+		}
+	}
+}
+
+func testConcurrentWriters(t *testing.T, repo db.Repository) {
+	const writers = 8
+	const inserts = 10
+
+	ctx := context.Background()
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*(inserts+1))
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < inserts; i++ {
+				if _, err := repo.Insert(ctx, getTestTodo(t, 0, fmt.Sprintf("Writer %v todo %v", w, i))); err != nil {
+					errs <- err
+					return
+				}
+			}
+
+			// read-modify-write the shared todo until the version matches
+			for {
+				todo, err := repo.Get(ctx, id)
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				todo.Priority++
+				_, err = repo.Update(ctx, todo, []string{"priority"}, todo.GetVersion())
+				if err == db.ErrVersionMismatch {
+					continue
+				}
+				if err != nil {
+					errs <- err
+				}
+				return
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	ids := listIDs(t, repo, &todolistpb.ListTodosRequest{})
+	if len(ids) != writers*inserts+1 {
+		t.Fatalf("Want: %v todos, Got: %v\n", writers*inserts+1, len(ids))
+	}
+
+	seen := make(map[int32]bool)
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("Want: unique IDs, Got: %v twice\n", id)
+		}
+		seen[id] = true
+	}
+
+	// no update was lost
+	got := get(t, repo, id)
+	if got.GetPriority() != writers || got.GetVersion() != writers+1 {
+		t.Fatalf("Want: priority %v and version %v, Got: %v\n", writers, writers+1, got)
+	}
+}
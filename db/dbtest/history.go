@@ -0,0 +1,249 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// RunHistoryConformance is running the conformance tests of db.History on the repositories
+// created by the factory
+func RunHistoryConformance(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T, db.Repository, db.History)
+	}{
+		{"Revisions", testHistoryRevisions},
+		{"Pages", testHistoryPages},
+		{"InvalidPageToken", testHistoryInvalidPageToken},
+		{"Purge", testHistoryPurge},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo := factory(t)
+			defer repo.Close()
+
+			history, ok := repo.(db.History)
+			if !ok {
+				t.Fatalf("Want: db.History, Got: %T\n", repo)
+			}
+
+			tt.fn(t, repo, history)
+		})
+	}
+}
+
+// listHistory returns with the revisions of the first page of the todo
+func listHistory(t *testing.T, history db.History, todoID int32) []*todolistpb.TodoRevision {
+	revisions, _, err := history.ListTodoHistory(context.Background(), &todolistpb.GetTodoHistoryRequest{TodoId: todoID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return revisions
+}
+
+func testHistoryRevisions(t *testing.T, repo db.Repository, history db.History) {
+	ctx := db.WithAudit(context.Background(), db.Audit{Actor: "alice", RequestID: "request-1"})
+
+	id, err := repo.Insert(ctx, getTestTodo(t, 0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update := &todolistpb.Todo{Id: id, DueDate: date(t, 2001, 1, 1)}
+	updated, err := repo.Update(db.WithAudit(ctx, db.Audit{Actor: "bob", RequestID: "request-2"}), update, []string{"due_date"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Complete(ctx, id, date(t, 2000, 6, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Delete(ctx, id, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Restore(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+
+	// the changes without an audit are recorded too
+	if _, err := repo.Reopen(context.Background(), id); err != nil {
+		t.Fatal(err)
+	}
+
+	got := listHistory(t, history, id)
+	want := []struct {
+		action    todolistpb.TodoRevision_Action
+		actor     string
+		requestID string
+	}{
+		{todolistpb.TodoRevision_UPDATED, "", ""},
+		{todolistpb.TodoRevision_RESTORED, "alice", "request-1"},
+		{todolistpb.TodoRevision_DELETED, "alice", "request-1"},
+		{todolistpb.TodoRevision_UPDATED, "alice", "request-1"},
+		{todolistpb.TodoRevision_UPDATED, "bob", "request-2"},
+		{todolistpb.TodoRevision_CREATED, "alice", "request-1"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Want: %v revisions, Got: %v\n", len(want), got)
+	}
+
+	for i, w := range want {
+		r := got[i]
+		if r.GetAction() != w.action || r.GetActor() != w.actor || r.GetRequestId() != w.requestID || r.GetTodoId() != id || r.GetChangedAt() == nil {
+			t.Fatalf("Want: %v, Got: %v\n", w, r)
+		}
+
+		if i > 0 && r.GetId() >= got[i-1].GetId() {
+			t.Fatalf("Want: newest first, Got: %v\n", got)
+		}
+
+		// every revision starts from the state of the previous one
+		if i < len(want)-1 && !proto.Equal(r.GetBefore(), got[i+1].GetAfter()) {
+			t.Fatalf("Want: %v, Got: %v\n", got[i+1].GetAfter(), r.GetBefore())
+		}
+	}
+
+	created := got[len(got)-1]
+	if created.GetBefore() != nil {
+		t.Fatalf("Want: nil, Got: %v\n", created.GetBefore())
+	}
+	checkTodo(t, getTestTodo(t, id, "Test Todo"), created.GetAfter())
+	checkTodo(t, updated, got[4].GetAfter())
+
+	if got[2].GetAfter().GetDeletedAt() == nil || got[1].GetAfter().GetDeletedAt() != nil {
+		t.Fatalf("Want: deleted and restored todo, Got: %v %v\n", got[2].GetAfter(), got[1].GetAfter())
+	}
+
+	revision, err := history.GetTodoRevision(context.Background(), got[4].GetId())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(revision, got[4]) {
+		t.Fatalf("Want: %v, Got: %v\n", got[4], revision)
+	}
+
+	revision, err = history.GetTodoRevision(context.Background(), 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if revision.GetId() != 0 {
+		t.Fatalf("Want: empty revision, Got: %v\n", revision)
+	}
+}
+
+func testHistoryPages(t *testing.T, repo db.Repository, history db.History) {
+	ctx := context.Background()
+
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+	other := insert(t, repo, getTestTodo(t, 0, "Other Todo"))
+	for _, title := range []string{"First", "Second"} {
+		for _, todoID := range []int32{id, other} {
+			if _, err := repo.Update(ctx, &todolistpb.Todo{Id: todoID, Title: title}, []string{"title"}, 0); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	req := &todolistpb.GetTodoHistoryRequest{TodoId: id, PageSize: 2}
+	var titles []string
+	for page := 0; ; page++ {
+		revisions, token, err := history.ListTodoHistory(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range revisions {
+			titles = append(titles, r.GetAfter().GetTitle())
+		}
+
+		if token == "" {
+			if page != 1 {
+				t.Fatalf("Want: 2 pages, Got: %v\n", page+1)
+			}
+			break
+		}
+
+		req.PageToken = token
+	}
+
+	want := []string{"Second", "First", "Test Todo"}
+	if len(titles) != len(want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, titles)
+	}
+
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("Want: %v, Got: %v\n", want, titles)
+		}
+	}
+}
+
+func testHistoryInvalidPageToken(t *testing.T, repo db.Repository, history db.History) {
+	ctx := context.Background()
+
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+	other := insert(t, repo, getTestTodo(t, 0, "Other Todo"))
+	for _, todoID := range []int32{id, other} {
+		if _, err := repo.Update(ctx, &todolistpb.Todo{Id: todoID, Title: "Updated"}, []string{"title"}, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, token, err := history.ListTodoHistory(ctx, &todolistpb.GetTodoHistoryRequest{TodoId: id, PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the token of a todo can not be used for an other todo
+	tokens := []string{"invalid", token}
+	for _, token := range tokens {
+		_, _, err := history.ListTodoHistory(ctx, &todolistpb.GetTodoHistoryRequest{TodoId: other, PageToken: token})
+		if err != db.ErrInvalidPageToken {
+			t.Fatalf("Want: %v, Got: %v\n", db.ErrInvalidPageToken, err)
+		}
+	}
+}
+
+func testHistoryPurge(t *testing.T, repo db.Repository, history db.History) {
+	ctx := context.Background()
+
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+	if _, err := repo.Delete(ctx, id, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	revisions := listHistory(t, history, id)
+	if len(revisions) != 2 {
+		t.Fatalf("Want: 2 revisions, Got: %v\n", revisions)
+	}
+
+	if _, err := repo.Purge(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the history is deleted with the todo
+	if got := listHistory(t, history, id); len(got) != 0 {
+		t.Fatalf("Want: no revisions, Got: %v\n", got)
+	}
+
+	revision, err := history.GetTodoRevision(ctx, revisions[0].GetId())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if revision.GetId() != 0 {
+		t.Fatalf("Want: empty revision, Got: %v\n", revision)
+	}
+}
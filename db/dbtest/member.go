@@ -0,0 +1,211 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// RunMemberStoreConformance is running the conformance tests of db.MemberStore on the repositories
+// created by the factory
+func RunMemberStoreConformance(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T, db.Repository, db.MemberStore)
+	}{
+		{"ListMember", testListMember},
+		{"ListMemberNotFound", testListMemberNotFound},
+		{"ListMemberships", testListMemberships},
+		{"DeleteTodoListMembers", testDeleteTodoListMembers},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo := factory(t)
+			defer repo.Close()
+
+			store, ok := repo.(db.MemberStore)
+			if !ok {
+				t.Fatalf("Want: db.MemberStore, Got: %T\n", repo)
+			}
+
+			tt.fn(t, repo, store)
+		})
+	}
+}
+
+func getTestListMember(t *testing.T, listID int32, subject string, role todolistpb.ListMember_Role) *todolistpb.ListMember {
+	return &todolistpb.ListMember{
+		ListId:    listID,
+		Subject:   subject,
+		Role:      role,
+		CreatedAt: date(t, 2000, 1, 1),
+	}
+}
+
+// checkListMembers is comparing the members
+func checkListMembers(t *testing.T, want, got []*todolistpb.ListMember) {
+	t.Helper()
+
+	if len(want) != len(got) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	for i := range want {
+		if !proto.Equal(want[i], got[i]) {
+			t.Fatalf("Want: %v, Got: %v\n", want, got)
+		}
+	}
+}
+
+// insertTodoList returns with the ID of the inserted list
+func insertTodoList(t *testing.T, repo db.Repository, name string) int32 {
+	id, err := repo.InsertTodoList(context.Background(), getTestTodoList(t, 0, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return id
+}
+
+// putListMember returns with the stored member
+func putListMember(t *testing.T, store db.MemberStore, member *todolistpb.ListMember) *todolistpb.ListMember {
+	m, err := store.PutListMember(context.Background(), member)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func testListMember(t *testing.T, repo db.Repository, store db.MemberStore) {
+	ctx := context.Background()
+	listID := insertTodoList(t, repo, "Shared List")
+
+	bob := getTestListMember(t, listID, "bob", todolistpb.ListMember_VIEWER)
+	checkListMembers(t, []*todolistpb.ListMember{bob}, []*todolistpb.ListMember{putListMember(t, store, bob)})
+
+	alice := getTestListMember(t, listID, "alice", todolistpb.ListMember_EDITOR)
+	putListMember(t, store, alice)
+
+	got, err := store.GetListMember(ctx, listID, "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkListMembers(t, []*todolistpb.ListMember{bob}, []*todolistpb.ListMember{got})
+
+	// the role is changed, the creation time is kept
+	owner := getTestListMember(t, listID, "bob", todolistpb.ListMember_OWNER)
+	owner.CreatedAt = date(t, 2001, 1, 1)
+	got = putListMember(t, store, owner)
+
+	want := getTestListMember(t, listID, "bob", todolistpb.ListMember_OWNER)
+	checkListMembers(t, []*todolistpb.ListMember{want}, []*todolistpb.ListMember{got})
+
+	members, err := store.ListListMembers(ctx, listID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkListMembers(t, []*todolistpb.ListMember{alice, want}, members)
+
+	count, err := store.DeleteListMember(ctx, listID, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Fatalf("Want: 1, Got: %v\n", count)
+	}
+
+	members, err = store.ListListMembers(ctx, listID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkListMembers(t, []*todolistpb.ListMember{want}, members)
+}
+
+func testListMemberNotFound(t *testing.T, repo db.Repository, store db.MemberStore) {
+	ctx := context.Background()
+	listID := insertTodoList(t, repo, "Shared List")
+
+	got, err := store.GetListMember(ctx, listID, "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.GetListId() != 0 {
+		t.Fatalf("Want: empty member, Got: %v\n", got)
+	}
+
+	count, err := store.DeleteListMember(ctx, listID, "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 {
+		t.Fatalf("Want: 0, Got: %v\n", count)
+	}
+
+	members, err := store.ListListMembers(ctx, listID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(members) != 0 {
+		t.Fatalf("Want: no members, Got: %v\n", members)
+	}
+
+	// a list which does not exist can not be shared
+	if _, err := store.PutListMember(ctx, getTestListMember(t, listID+1, "bob", todolistpb.ListMember_VIEWER)); err == nil {
+		t.Fatalf("Want: error, Got: nil\n")
+	}
+}
+
+func testListMemberships(t *testing.T, repo db.Repository, store db.MemberStore) {
+	ctx := context.Background()
+	listID1 := insertTodoList(t, repo, "Shared List 1")
+	listID2 := insertTodoList(t, repo, "Shared List 2")
+
+	member2 := putListMember(t, store, getTestListMember(t, listID2, "bob", todolistpb.ListMember_EDITOR))
+	member1 := putListMember(t, store, getTestListMember(t, listID1, "bob", todolistpb.ListMember_VIEWER))
+	putListMember(t, store, getTestListMember(t, listID1, "alice", todolistpb.ListMember_VIEWER))
+
+	members, err := store.ListMemberships(ctx, "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkListMembers(t, []*todolistpb.ListMember{member1, member2}, members)
+
+	members, err = store.ListMemberships(ctx, "carol")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(members) != 0 {
+		t.Fatalf("Want: no members, Got: %v\n", members)
+	}
+}
+
+func testDeleteTodoListMembers(t *testing.T, repo db.Repository, store db.MemberStore) {
+	ctx := context.Background()
+	listID := insertTodoList(t, repo, "Shared List")
+	putListMember(t, store, getTestListMember(t, listID, "bob", todolistpb.ListMember_EDITOR))
+
+	if _, err := repo.DeleteTodoList(ctx, listID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// the members are deleted together with the list
+	members, err := store.ListMemberships(ctx, "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(members) != 0 {
+		t.Fatalf("Want: no members, Got: %v\n", members)
+	}
+}
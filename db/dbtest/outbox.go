@@ -0,0 +1,211 @@
+package dbtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// RunOutboxConformance is running the conformance tests of db.Outbox on the repositories
+// created by the factory
+func RunOutboxConformance(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T, db.Repository, db.Outbox)
+	}{
+		{"Events", testOutboxEvents},
+		{"Trash", testOutboxTrash},
+		{"Limit", testOutboxLimit},
+		{"Failure", testOutboxFailure},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo := factory(t)
+			defer repo.Close()
+
+			outbox, ok := repo.(db.Outbox)
+			if !ok {
+				t.Fatalf("Want: db.Outbox, Got: %T\n", repo)
+			}
+
+			tt.fn(t, repo, outbox)
+		})
+	}
+}
+
+// processOutbox returns with the processed events of the outbox
+func processOutbox(t *testing.T, outbox db.Outbox, limit int) []*db.OutboxEvent {
+	var events []*db.OutboxEvent
+	count, err := outbox.ProcessOutbox(context.Background(), limit, func(e *db.OutboxEvent) error {
+		events = append(events, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != len(events) {
+		t.Fatalf("Want: %v, Got: %v\n", len(events), count)
+	}
+
+	return events
+}
+
+func testOutboxEvents(t *testing.T, repo db.Repository, outbox db.Outbox) {
+	ctx := context.Background()
+
+	id := insert(t, repo, getTestTodo(t, 0, "Test Todo"))
+
+	if _, err := repo.Complete(ctx, id, date(t, 2000, 1, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Delete(ctx, id, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	events := processOutbox(t, outbox, 10)
+
+	want := []todolistpb.WatchTodosResponse_EventType{
+		todolistpb.WatchTodosResponse_CREATED,
+		todolistpb.WatchTodosResponse_UPDATED,
+		todolistpb.WatchTodosResponse_DELETED,
+	}
+
+	if len(events) != len(want) {
+		t.Fatalf("Want: %v events, Got: %v\n", len(want), events)
+	}
+
+	for i, e := range events {
+		if e.Type != want[i] || e.Todo.GetId() != id || e.OccurredAt.IsZero() {
+			t.Fatalf("Want: %v %v, Got: %v\n", want[i], id, e)
+		}
+
+		if i > 0 && e.ID <= events[i-1].ID {
+			t.Fatalf("Want: increasing IDs, Got: %v %v\n", events[i-1].ID, e.ID)
+		}
+	}
+
+	want1 := getTestTodo(t, id, "Test Todo")
+	checkTodo(t, want1, events[0].Todo)
+
+	if events[1].Todo.GetStatus() != todolistpb.Status_DONE || events[1].Todo.GetVersion() != 2 {
+		t.Fatalf("Want: %v version 2, Got: %v\n", todolistpb.Status_DONE, events[1].Todo)
+	}
+
+	// the processed events are removed
+	if events := processOutbox(t, outbox, 10); len(events) != 0 {
+		t.Fatalf("Want: empty outbox, Got: %v\n", events)
+	}
+}
+
+func testOutboxTrash(t *testing.T, repo db.Repository, outbox db.Outbox) {
+	ctx := context.Background()
+
+	listID, err := repo.InsertTodoList(ctx, getTestTodoList(t, 0, "Test List"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todo := getTestTodo(t, 0, "Test Todo")
+	todo.ListId = listID
+	moved := insert(t, repo, todo)
+	trashed := insert(t, repo, todo)
+
+	if _, err := repo.Delete(ctx, trashed, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Restore(ctx, trashed); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Delete(ctx, trashed, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// the todos of the trash are moved to the inbox and purged without events
+	if _, err := repo.DeleteTodoList(ctx, listID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Purge(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	events := processOutbox(t, outbox, 10)
+
+	want := []struct {
+		eventType todolistpb.WatchTodosResponse_EventType
+		id        int32
+		deleted   bool
+	}{
+		{todolistpb.WatchTodosResponse_CREATED, moved, false},
+		{todolistpb.WatchTodosResponse_CREATED, trashed, false},
+		{todolistpb.WatchTodosResponse_DELETED, trashed, true},
+		{todolistpb.WatchTodosResponse_CREATED, trashed, false},
+		{todolistpb.WatchTodosResponse_DELETED, trashed, true},
+		{todolistpb.WatchTodosResponse_UPDATED, moved, false},
+	}
+
+	if len(events) != len(want) {
+		t.Fatalf("Want: %v events, Got: %v\n", len(want), events)
+	}
+
+	for i, w := range want {
+		if events[i].Type != w.eventType || events[i].Todo.GetId() != w.id || (events[i].Todo.GetDeletedAt() != nil) != w.deleted {
+			t.Fatalf("Want: %v, Got: %v\n", w, events[i])
+		}
+	}
+}
+
+func testOutboxLimit(t *testing.T, repo db.Repository, outbox db.Outbox) {
+	var ids []int32
+	for i := 0; i < 3; i++ {
+		ids = append(ids, insert(t, repo, getTestTodo(t, 0, "Test Todo")))
+	}
+
+	for _, want := range [][]int32{ids[:2], ids[2:]} {
+		events := processOutbox(t, outbox, 2)
+
+		if len(events) != len(want) {
+			t.Fatalf("Want: %v, Got: %v\n", want, events)
+		}
+
+		for i, e := range events {
+			if e.Todo.GetId() != want[i] {
+				t.Fatalf("Want: %v, Got: %v\n", want, events)
+			}
+		}
+	}
+}
+
+func testOutboxFailure(t *testing.T, repo db.Repository, outbox db.Outbox) {
+	var ids []int32
+	for i := 0; i < 3; i++ {
+		ids = append(ids, insert(t, repo, getTestTodo(t, 0, "Test Todo")))
+	}
+
+	// the processing stops at the failed event, only the events before it are removed
+	errPublish := errors.New("publish failed")
+	count, err := outbox.ProcessOutbox(context.Background(), 10, func(e *db.OutboxEvent) error {
+		if e.Todo.GetId() == ids[1] {
+			return errPublish
+		}
+		return nil
+	})
+
+	if count != 1 || err != errPublish {
+		t.Fatalf("Want: 1 %v, Got: %v %v\n", errPublish, count, err)
+	}
+
+	events := processOutbox(t, outbox, 10)
+	if len(events) != 2 || events[0].Todo.GetId() != ids[1] || events[1].Todo.GetId() != ids[2] {
+		t.Fatalf("Want: %v, Got: %v\n", ids[1:], events)
+	}
+}
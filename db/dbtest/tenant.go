@@ -0,0 +1,303 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// RunTenantConformance is running the conformance tests of the owners of the todos and the lists
+// on the repositories created by the factory
+func RunTenantConformance(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T, db.Repository)
+	}{
+		{"Owner", testTenantOwner},
+		{"Todos", testTenantTodos},
+		{"Trash", testTenantTrash},
+		{"TodoLists", testTenantTodoLists},
+		{"History", testTenantHistory},
+		{"AllTenants", testAllTenants},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo := factory(t)
+			defer repo.Close()
+
+			tt.fn(t, repo)
+		})
+	}
+}
+
+var (
+	alice = db.WithTenant(context.Background(), "alice")
+	bob   = db.WithTenant(context.Background(), "bob")
+)
+
+func testTenantOwner(t *testing.T, repo db.Repository) {
+	id, err := repo.Insert(alice, getTestTodo(t, 0, "Alice Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todo, err := repo.Get(alice, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(t, id, "Alice Todo")
+	want.Owner = "alice"
+	checkTodo(t, want, todo)
+
+	// the todos created without an owner are owned by the empty owner
+	id = insert(t, repo, getTestTodo(t, 0, "Todo"))
+	checkTodo(t, getTestTodo(t, id, "Todo"), get(t, repo, id))
+}
+
+func testTenantTodos(t *testing.T, repo db.Repository) {
+	id, err := repo.Insert(alice, getTestTodo(t, 0, "Alice Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if todo, err := repo.Get(bob, id); err != nil || todo.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v %v\n", todo, err)
+	}
+
+	update := &todolistpb.Todo{Id: id, Title: "Bob Todo"}
+	if todo, err := repo.Update(bob, update, []string{"title"}, 0); err != nil || todo.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v %v\n", todo, err)
+	}
+
+	// the version of the todo of an other owner is not checked
+	if todo, err := repo.Update(bob, update, []string{"title"}, 5); err != nil || todo.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v %v\n", todo, err)
+	}
+
+	if todo, err := repo.Complete(bob, id, date(t, 2000, 6, 1)); err != nil || todo.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v %v\n", todo, err)
+	}
+
+	if todo, err := repo.Reopen(bob, id); err != nil || todo.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v %v\n", todo, err)
+	}
+
+	if count, err := repo.Delete(bob, id, 5); err != nil || count != 0 {
+		t.Fatalf("Want: 0, Got: %v %v\n", count, err)
+	}
+
+	todos, _, err := repo.List(bob, &todolistpb.ListTodosRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(todos) != 0 {
+		t.Fatalf("Want: no todos, Got: %v\n", todos)
+	}
+
+	// the todo of alice is not changed
+	todo, err := repo.Get(alice, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(t, id, "Alice Todo")
+	want.Owner = "alice"
+	checkTodo(t, want, todo)
+
+	todos, _, err = repo.List(alice, &todolistpb.ListTodosRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(todos) != 1 || todos[0].GetId() != id {
+		t.Fatalf("Want: %v, Got: %v\n", id, todos)
+	}
+}
+
+func testTenantTrash(t *testing.T, repo db.Repository) {
+	id, err := repo.Insert(alice, getTestTodo(t, 0, "Alice Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Delete(alice, id, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	trash, err := repo.ListTrash(bob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(trash) != 0 {
+		t.Fatalf("Want: empty trash, Got: %v\n", trash)
+	}
+
+	if todo, err := repo.Restore(bob, id); err != nil || todo.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v %v\n", todo, err)
+	}
+
+	if todo, err := repo.GetTrashed(bob, id); err != nil || todo.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v %v\n", todo, err)
+	}
+
+	if count, err := repo.Purge(bob, nil); err != nil || count != 0 {
+		t.Fatalf("Want: 0, Got: %v %v\n", count, err)
+	}
+
+	trash, err = repo.ListTrash(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(trash) != 1 || trash[0].GetId() != id {
+		t.Fatalf("Want: %v, Got: %v\n", id, trash)
+	}
+
+	if count, err := repo.Purge(alice, nil); err != nil || count != 1 {
+		t.Fatalf("Want: 1, Got: %v %v\n", count, err)
+	}
+}
+
+func testTenantTodoLists(t *testing.T, repo db.Repository) {
+	listID, err := repo.InsertTodoList(alice, getTestTodoList(t, 0, "Alice List"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todo := getTestTodo(t, 0, "Alice Todo")
+	todo.ListId = listID
+	id, err := repo.Insert(alice, todo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := repo.GetTodoList(alice, listID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if list.GetId() != listID || list.GetOwner() != "alice" {
+		t.Fatalf("Want: %v alice, Got: %v\n", listID, list)
+	}
+
+	if list, err := repo.GetTodoList(bob, listID); err != nil || list.GetId() != 0 {
+		t.Fatalf("Want: empty list, Got: %v %v\n", list, err)
+	}
+
+	if list, err := repo.UpdateTodoList(bob, getTestTodoList(t, listID, "Bob List")); err != nil || list.GetId() != 0 {
+		t.Fatalf("Want: empty list, Got: %v %v\n", list, err)
+	}
+
+	lists, err := repo.ListTodoLists(bob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lists) != 0 {
+		t.Fatalf("Want: no lists, Got: %v\n", lists)
+	}
+
+	if count, err := repo.DeleteTodoList(bob, listID, true); err != nil || count != 0 {
+		t.Fatalf("Want: 0, Got: %v %v\n", count, err)
+	}
+
+	// the list and its todo are not changed
+	lists, err = repo.ListTodoLists(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lists) != 1 || lists[0].GetName() != "Alice List" {
+		t.Fatalf("Want: Alice List, Got: %v\n", lists)
+	}
+
+	todo, err = repo.Get(alice, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if todo.GetListId() != listID {
+		t.Fatalf("Want: %v, Got: %v\n", listID, todo)
+	}
+}
+
+func testTenantHistory(t *testing.T, repo db.Repository) {
+	history, ok := repo.(db.History)
+	if !ok {
+		t.Skipf("%T is not implementing db.History", repo)
+	}
+
+	id, err := repo.Insert(alice, getTestTodo(t, 0, "Alice Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, _, err := history.ListTodoHistory(alice, &todolistpb.GetTodoHistoryRequest{TodoId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(revisions) != 1 || revisions[0].GetAfter().GetOwner() != "alice" {
+		t.Fatalf("Want: 1 revision of alice, Got: %v\n", revisions)
+	}
+
+	other, _, err := history.ListTodoHistory(bob, &todolistpb.GetTodoHistoryRequest{TodoId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(other) != 0 {
+		t.Fatalf("Want: no revisions, Got: %v\n", other)
+	}
+
+	revision, err := history.GetTodoRevision(bob, revisions[0].GetId())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if revision.GetId() != 0 {
+		t.Fatalf("Want: empty revision, Got: %v\n", revision)
+	}
+}
+
+func testAllTenants(t *testing.T, repo db.Repository) {
+	all := db.WithAllTenants(context.Background())
+
+	aliceID, err := repo.Insert(alice, getTestTodo(t, 0, "Alice Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bobID, err := repo.Insert(bob, getTestTodo(t, 0, "Bob Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todos, _, err := repo.List(all, &todolistpb.ListTodosRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(todos) != 2 || todos[0].GetId() != aliceID || todos[1].GetId() != bobID {
+		t.Fatalf("Want: %v %v, Got: %v\n", aliceID, bobID, todos)
+	}
+
+	if _, err := repo.Delete(alice, aliceID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Delete(bob, bobID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if count, err := repo.Purge(all, nil); err != nil || count != 2 {
+		t.Fatalf("Want: 2, Got: %v %v\n", count, err)
+	}
+}
@@ -0,0 +1,237 @@
+package dbtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// startTitle is the title of the todos inserted to start the watch
+const startTitle = "Watch start"
+
+// errStop is stopping the watch when the events are received
+var errStop = errors.New("stop")
+
+// RunWatcherConformance is running the conformance tests of db.Watcher on the repositories
+// created by the factory
+func RunWatcherConformance(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T, db.Repository, db.Watcher)
+	}{
+		{"Events", testWatchEvents},
+		{"Restore", testWatchRestore},
+		{"Resume", testWatchResume},
+		{"InvalidResumeToken", testWatchInvalidResumeToken},
+		{"Cancel", testWatchCancel},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo := factory(t)
+			defer repo.Close()
+
+			w, ok := repo.(db.Watcher)
+			if !ok {
+				t.Fatalf("Want: db.Watcher, Got: %T\n", repo)
+			}
+
+			tt.fn(t, repo, w)
+		})
+	}
+}
+
+// watchStart is watching the changes from now and returns with the resume token of the first
+// received event, the todos inserted until the watch is started are ignored by collectEvents
+func watchStart(t *testing.T, repo db.Repository, w db.Watcher) string {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tokens := make(chan string, 1)
+	go w.Watch(ctx, "", func(e *db.Event) error {
+		tokens <- e.ResumeToken
+		return errStop
+	})
+
+	for {
+		insert(t, repo, getTestTodo(t, 0, startTitle))
+
+		select {
+		case token := <-tokens:
+			return token
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// collectEvents is watching the events after the resume token until n events are received
+func collectEvents(t *testing.T, w db.Watcher, token string, n int) []*db.Event {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var events []*db.Event
+	err := w.Watch(ctx, token, func(e *db.Event) error {
+		if e.Todo.GetTitle() == startTitle {
+			return nil
+		}
+
+		events = append(events, e)
+		if len(events) == n {
+			return errStop
+		}
+
+		return nil
+	})
+	if err != errStop {
+		t.Fatalf("Want: %v events, Got: %v %v\n", n, events, err)
+	}
+
+	return events
+}
+
+// checkEvent is checking the type and the todo of the event
+func checkEvent(t *testing.T, e *db.Event, eventType todolistpb.WatchTodosResponse_EventType, id int32, version int64) {
+	if e.Type != eventType || e.Todo.GetId() != id || e.Todo.GetVersion() != version || e.ResumeToken == "" {
+		t.Fatalf("Want: %v %v version %v, Got: %v %v\n", eventType, id, version, e.Type, e.Todo)
+	}
+}
+
+func testWatchEvents(t *testing.T, repo db.Repository, w db.Watcher) {
+	ctx := context.Background()
+	token := watchStart(t, repo, w)
+
+	id := insert(t, repo, getTestTodo(t, 0, "Watched Todo"))
+
+	if _, err := repo.Update(ctx, &todolistpb.Todo{Id: id, Title: "Renamed Todo"}, []string{"title"}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Complete(ctx, id, date(t, 2000, 1, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Reopen(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Delete(ctx, id, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// the todos of a deleted list are moved to the inbox or deleted
+	for _, deleteTodos := range []bool{false, true} {
+		listID, err := repo.InsertTodoList(ctx, getTestTodoList(t, 0, "Watched List"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		todo := getTestTodo(t, 0, "Listed Todo")
+		todo.ListId = listID
+		insert(t, repo, todo)
+
+		if _, err := repo.DeleteTodoList(ctx, listID, deleteTodos); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events := collectEvents(t, w, token, 9)
+
+	checkEvent(t, events[0], todolistpb.WatchTodosResponse_CREATED, id, 1)
+	checkEvent(t, events[1], todolistpb.WatchTodosResponse_UPDATED, id, 2)
+	checkEvent(t, events[2], todolistpb.WatchTodosResponse_UPDATED, id, 3)
+	checkEvent(t, events[3], todolistpb.WatchTodosResponse_UPDATED, id, 4)
+	checkEvent(t, events[4], todolistpb.WatchTodosResponse_DELETED, id, 4)
+
+	if events[0].Todo.GetTitle() != "Watched Todo" || events[1].Todo.GetTitle() != "Renamed Todo" {
+		t.Fatalf("Want: the todo after the change, Got: %v %v\n", events[0].Todo, events[1].Todo)
+	}
+
+	if events[2].Todo.GetStatus() != todolistpb.Status_DONE || events[3].Todo.GetStatus() != todolistpb.Status_OPEN {
+		t.Fatalf("Want: %v %v, Got: %v %v\n", todolistpb.Status_DONE, todolistpb.Status_OPEN, events[2].Todo, events[3].Todo)
+	}
+
+	moved, deleted := events[5].Todo.GetId(), events[7].Todo.GetId()
+	checkEvent(t, events[5], todolistpb.WatchTodosResponse_CREATED, moved, 1)
+	checkEvent(t, events[6], todolistpb.WatchTodosResponse_UPDATED, moved, 1)
+
+	if events[5].Todo.GetListId() == 0 || events[6].Todo.GetListId() != 0 {
+		t.Fatalf("Want: todo moved to the inbox, Got: %v %v\n", events[5].Todo, events[6].Todo)
+	}
+
+	checkEvent(t, events[7], todolistpb.WatchTodosResponse_CREATED, deleted, 1)
+	checkEvent(t, events[8], todolistpb.WatchTodosResponse_DELETED, deleted, 1)
+}
+
+func testWatchRestore(t *testing.T, repo db.Repository, w db.Watcher) {
+	ctx := context.Background()
+	token := watchStart(t, repo, w)
+
+	id := insert(t, repo, getTestTodo(t, 0, "Watched Todo"))
+
+	if _, err := repo.Delete(ctx, id, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// the restored todo is created again
+	if _, err := repo.Restore(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+
+	events := collectEvents(t, w, token, 3)
+
+	checkEvent(t, events[0], todolistpb.WatchTodosResponse_CREATED, id, 1)
+	checkEvent(t, events[1], todolistpb.WatchTodosResponse_DELETED, id, 1)
+	checkEvent(t, events[2], todolistpb.WatchTodosResponse_CREATED, id, 1)
+}
+
+func testWatchResume(t *testing.T, repo db.Repository, w db.Watcher) {
+	token := watchStart(t, repo, w)
+
+	var ids []int32
+	for i := 0; i < 3; i++ {
+		ids = append(ids, insert(t, repo, getTestTodo(t, 0, "Watched Todo")))
+	}
+
+	events := collectEvents(t, w, token, 3)
+
+	// the watch is resumed after the first event without missing the changes made in between
+	ids = append(ids, insert(t, repo, getTestTodo(t, 0, "Watched Todo")))
+
+	resumed := collectEvents(t, w, events[0].ResumeToken, 3)
+
+	checkEvent(t, resumed[0], todolistpb.WatchTodosResponse_CREATED, ids[1], 1)
+	checkEvent(t, resumed[1], todolistpb.WatchTodosResponse_CREATED, ids[2], 1)
+	checkEvent(t, resumed[2], todolistpb.WatchTodosResponse_CREATED, ids[3], 1)
+
+	if resumed[0].ResumeToken != events[1].ResumeToken {
+		t.Fatalf("Want: %v, Got: %v\n", events[1].ResumeToken, resumed[0].ResumeToken)
+	}
+}
+
+func testWatchInvalidResumeToken(t *testing.T, repo db.Repository, w db.Watcher) {
+	err := w.Watch(context.Background(), "invalid", func(e *db.Event) error {
+		return nil
+	})
+
+	if err != db.ErrInvalidResumeToken {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrInvalidResumeToken, err)
+	}
+}
+
+func testWatchCancel(t *testing.T, repo db.Repository, w db.Watcher) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := w.Watch(ctx, "", func(e *db.Event) error {
+		return nil
+	})
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Want: %v, Got: %v\n", context.DeadlineExceeded, err)
+	}
+}
@@ -0,0 +1,416 @@
+package dbtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// RunWebhookStoreConformance is running the conformance tests of db.WebhookStore on the repositories
+// created by the factory
+func RunWebhookStoreConformance(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T, db.WebhookStore)
+	}{
+		{"Webhook", testWebhook},
+		{"WebhookNotFound", testWebhookNotFound},
+		{"WebhookResult", testWebhookResult},
+		{"WebhookDeliveries", testWebhookDeliveries},
+		{"WebhookTenant", testWebhookTenant},
+		{"WebhookQueue", testWebhookQueue},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo := factory(t)
+			defer repo.Close()
+
+			store, ok := repo.(db.WebhookStore)
+			if !ok {
+				t.Fatalf("Want: db.WebhookStore, Got: %T\n", repo)
+			}
+
+			tt.fn(t, store)
+		})
+	}
+}
+
+func getTestWebhook(t *testing.T, id int32, url string) *todolistpb.Webhook {
+	return &todolistpb.Webhook{
+		Id:         id,
+		Url:        url,
+		EventTypes: []string{"todo.created", "todo.due"},
+		Secret:     "secret",
+		CreatedAt:  date(t, 2000, 1, 1),
+	}
+}
+
+// checkWebhook is comparing the webhooks
+func checkWebhook(t *testing.T, want, got *todolistpb.Webhook) {
+	t.Helper()
+
+	if !proto.Equal(want, got) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+}
+
+// insertWebhook returns with the ID of the inserted webhook
+func insertWebhook(t *testing.T, store db.WebhookStore, webhook *todolistpb.Webhook) int32 {
+	id, err := store.InsertWebhook(context.Background(), webhook)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return id
+}
+
+func testWebhook(t *testing.T, store db.WebhookStore) {
+	ctx := context.Background()
+
+	id1 := insertWebhook(t, store, getTestWebhook(t, 0, "http://example.com/1"))
+	id2 := insertWebhook(t, store, &todolistpb.Webhook{Url: "http://example.com/2", Secret: "secret", CreatedAt: date(t, 2000, 1, 1)})
+
+	w, err := store.GetWebhook(ctx, id1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkWebhook(t, getTestWebhook(t, id1, "http://example.com/1"), w)
+
+	// the secret is kept if it is empty and the failure count is reset
+	if _, err := store.RecordWebhookResult(ctx, id1, false, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	update := &todolistpb.Webhook{Id: id1, Url: "http://example.com/3", Disabled: true}
+	w, err = store.UpdateWebhook(ctx, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &todolistpb.Webhook{Id: id1, Url: "http://example.com/3", Secret: "secret", Disabled: true, CreatedAt: date(t, 2000, 1, 1)}
+	checkWebhook(t, want, w)
+
+	update.Secret = "new secret"
+	if w, err = store.UpdateWebhook(ctx, update); err != nil {
+		t.Fatal(err)
+	}
+	if w.GetSecret() != "new secret" {
+		t.Fatalf("Want: %v, Got: %v\n", "new secret", w.GetSecret())
+	}
+
+	webhooks, err := store.ListWebhooks(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(webhooks) != 2 || webhooks[0].GetId() != id1 || webhooks[1].GetId() != id2 {
+		t.Fatalf("Want: %v %v, Got: %v\n", id1, id2, webhooks)
+	}
+
+	count, err := store.DeleteWebhook(ctx, id1)
+	if err != nil || count != 1 {
+		t.Fatalf("Want: 1, Got: %v %v\n", count, err)
+	}
+
+	if w, err = store.GetWebhook(ctx, id1); err != nil || w.GetId() != 0 {
+		t.Fatalf("Want: empty webhook, Got: %v %v\n", w, err)
+	}
+}
+
+func testWebhookNotFound(t *testing.T, store db.WebhookStore) {
+	ctx := context.Background()
+
+	w, err := store.GetWebhook(ctx, 100)
+	if err != nil || w.GetId() != 0 {
+		t.Fatalf("Want: empty webhook, Got: %v %v\n", w, err)
+	}
+
+	w, err = store.UpdateWebhook(ctx, getTestWebhook(t, 100, "http://example.com"))
+	if err != nil || w.GetId() != 0 {
+		t.Fatalf("Want: empty webhook, Got: %v %v\n", w, err)
+	}
+
+	w, err = store.RecordWebhookResult(ctx, 100, false, 1)
+	if err != nil || w.GetId() != 0 {
+		t.Fatalf("Want: empty webhook, Got: %v %v\n", w, err)
+	}
+
+	count, err := store.DeleteWebhook(ctx, 100)
+	if err != nil || count != 0 {
+		t.Fatalf("Want: 0, Got: %v %v\n", count, err)
+	}
+}
+
+func testWebhookResult(t *testing.T, store db.WebhookStore) {
+	ctx := context.Background()
+
+	id := insertWebhook(t, store, getTestWebhook(t, 0, "http://example.com"))
+
+	tests := []struct {
+		success  bool
+		failures int32
+		disabled bool
+	}{
+		{false, 1, false},
+		{false, 2, false},
+		{true, 0, false},
+		{false, 1, false},
+		{false, 2, false},
+		{false, 3, true},
+		// a disabled webhook is not enabled by a success
+		{true, 0, true},
+	}
+
+	for _, tt := range tests {
+		w, err := store.RecordWebhookResult(ctx, id, tt.success, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if w.GetFailureCount() != tt.failures || w.GetDisabled() != tt.disabled {
+			t.Fatalf("Want: %v %v, Got: %v\n", tt.failures, tt.disabled, w)
+		}
+	}
+
+	// the webhook is enabled again by the update
+	w, err := store.UpdateWebhook(ctx, &todolistpb.Webhook{Id: id, Url: "http://example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w.GetFailureCount() != 0 || w.GetDisabled() {
+		t.Fatalf("Want: enabled webhook, Got: %v\n", w)
+	}
+}
+
+func testWebhookDeliveries(t *testing.T, store db.WebhookStore) {
+	ctx := context.Background()
+
+	id1 := insertWebhook(t, store, getTestWebhook(t, 0, "http://example.com/1"))
+	id2 := insertWebhook(t, store, getTestWebhook(t, 0, "http://example.com/2"))
+
+	var want []*todolistpb.WebhookDelivery
+	for i := int32(1); i <= 3; i++ {
+		for _, webhookID := range []int32{id1, id2} {
+			d := &todolistpb.WebhookDelivery{
+				WebhookId:   webhookID,
+				EventId:     7,
+				EventType:   "todo.created",
+				Attempt:     i,
+				StatusCode:  500,
+				Error:       "500 Internal Server Error",
+				Success:     i == 3,
+				AttemptedAt: date(t, 2000, 1, int(i)),
+			}
+
+			id, err := store.InsertWebhookDelivery(ctx, d)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if webhookID == id1 {
+				d.Id = id
+				want = append([]*todolistpb.WebhookDelivery{d}, want...)
+			}
+		}
+	}
+
+	deliveries, err := store.ListWebhookDeliveries(ctx, id1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(deliveries) != 2 {
+		t.Fatalf("Want: %v, Got: %v\n", want[:2], deliveries)
+	}
+
+	for i, d := range deliveries {
+		if !proto.Equal(want[i], d) {
+			t.Fatalf("Want: %v, Got: %v\n", want[i], d)
+		}
+	}
+
+	// the deliveries are deleted together with the webhook
+	if _, err := store.DeleteWebhook(ctx, id1); err != nil {
+		t.Fatal(err)
+	}
+
+	if deliveries, err = store.ListWebhookDeliveries(ctx, id1, 10); err != nil || len(deliveries) != 0 {
+		t.Fatalf("Want: no deliveries, Got: %v %v\n", deliveries, err)
+	}
+
+	if deliveries, err = store.ListWebhookDeliveries(ctx, id2, 10); err != nil || len(deliveries) != 3 {
+		t.Fatalf("Want: 3 deliveries, Got: %v %v\n", deliveries, err)
+	}
+}
+
+func testWebhookTenant(t *testing.T, store db.WebhookStore) {
+	id, err := store.InsertWebhook(alice, getTestWebhook(t, 0, "http://example.com/alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestWebhook(t, id, "http://example.com/alice")
+	want.Owner = "alice"
+
+	w, err := store.GetWebhook(alice, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkWebhook(t, want, w)
+
+	// the webhooks of the other owners are not found
+	if w, err := store.GetWebhook(bob, id); err != nil || w.GetId() != 0 {
+		t.Fatalf("Want: empty webhook, Got: %v %v\n", w, err)
+	}
+
+	if w, err := store.UpdateWebhook(bob, &todolistpb.Webhook{Id: id, Url: "http://example.com/bob"}); err != nil || w.GetId() != 0 {
+		t.Fatalf("Want: empty webhook, Got: %v %v\n", w, err)
+	}
+
+	if w, err := store.RecordWebhookResult(bob, id, false, 1); err != nil || w.GetId() != 0 {
+		t.Fatalf("Want: empty webhook, Got: %v %v\n", w, err)
+	}
+
+	if webhooks, err := store.ListWebhooks(bob); err != nil || len(webhooks) != 0 {
+		t.Fatalf("Want: no webhooks, Got: %v %v\n", webhooks, err)
+	}
+
+	delivery := &todolistpb.WebhookDelivery{WebhookId: id, EventId: 7, EventType: "todo.created", Attempt: 1, AttemptedAt: date(t, 2000, 1, 1)}
+	if deliveryID, err := store.InsertWebhookDelivery(bob, delivery); err != nil || deliveryID != 0 {
+		t.Fatalf("Want: 0, Got: %v %v\n", deliveryID, err)
+	}
+
+	// the dispatcher is delivering the events to the webhooks of every owner
+	all := db.WithAllTenants(context.Background())
+	if _, err := store.InsertWebhookDelivery(all, delivery); err != nil {
+		t.Fatal(err)
+	}
+
+	if deliveries, err := store.ListWebhookDeliveries(bob, id, 10); err != nil || len(deliveries) != 0 {
+		t.Fatalf("Want: no deliveries, Got: %v %v\n", deliveries, err)
+	}
+
+	if deliveries, err := store.ListWebhookDeliveries(alice, id, 10); err != nil || len(deliveries) != 1 {
+		t.Fatalf("Want: 1 delivery, Got: %v %v\n", deliveries, err)
+	}
+
+	if webhooks, err := store.ListWebhooks(all); err != nil || len(webhooks) != 1 {
+		t.Fatalf("Want: 1 webhook, Got: %v %v\n", webhooks, err)
+	}
+
+	if count, err := store.DeleteWebhook(bob, id); err != nil || count != 0 {
+		t.Fatalf("Want: 0, Got: %v %v\n", count, err)
+	}
+
+	if count, err := store.DeleteWebhook(alice, id); err != nil || count != 1 {
+		t.Fatalf("Want: 1, Got: %v %v\n", count, err)
+	}
+}
+
+// claim returns with the event IDs of the claimed deliveries by their webhooks
+func claim(t *testing.T, store db.WebhookStore, limit int) map[int32]*db.QueuedDelivery {
+	t.Helper()
+
+	deliveries, err := store.ClaimWebhookDeliveries(context.Background(), limit, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claimed := make(map[int32]*db.QueuedDelivery)
+	for _, d := range deliveries {
+		if claimed[d.WebhookID] != nil {
+			t.Fatalf("Want: 1 delivery of the webhook %v, Got: %v\n", d.WebhookID, deliveries)
+		}
+		claimed[d.WebhookID] = d
+	}
+
+	return claimed
+}
+
+func testWebhookQueue(t *testing.T, store db.WebhookStore) {
+	ctx := context.Background()
+
+	id1 := insertWebhook(t, store, getTestWebhook(t, 0, "http://example.com/1"))
+	id2 := insertWebhook(t, store, getTestWebhook(t, 0, "http://example.com/2"))
+
+	tests := []struct {
+		webhookID int32
+		eventID   int64
+		queued    bool
+	}{
+		{id1, 1, true},
+		{id1, 2, true},
+		{id2, 1, true},
+		// an event is queued once to a webhook, the events without an ID every time
+		{id1, 1, false},
+		{id2, 0, true},
+		{id2, 0, true},
+		{100, 3, false},
+	}
+
+	for _, tt := range tests {
+		d := &db.QueuedDelivery{WebhookID: tt.webhookID, EventID: tt.eventID, EventType: "todo.created", Body: []byte(`{"id":1}`)}
+		id, err := store.EnqueueWebhookDelivery(ctx, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if (id != 0) != tt.queued {
+			t.Fatalf("Want: queued %v, Got: %v\n", tt.queued, id)
+		}
+	}
+
+	// the oldest delivery of every webhook is claimed
+	claimed := claim(t, store, 10)
+	if len(claimed) != 2 || claimed[id1].EventID != 1 || claimed[id2].EventID != 1 {
+		t.Fatalf("Want: event 1 of both webhooks, Got: %v\n", claimed)
+	}
+
+	head := claimed[id1]
+	if string(head.Body) != `{"id":1}` || head.EventType != "todo.created" || head.Attempt != 0 {
+		t.Fatalf("Want: queued delivery, Got: %v\n", head)
+	}
+
+	// the claimed deliveries are leased, the next deliveries of their webhooks wait for them
+	if claimed := claim(t, store, 10); len(claimed) != 0 {
+		t.Fatalf("Want: no deliveries, Got: %v\n", claimed)
+	}
+
+	if err := store.RetryWebhookDelivery(ctx, head.ID, 1, time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	claimed = claim(t, store, 10)
+	if len(claimed) != 1 || claimed[id1].ID != head.ID || claimed[id1].Attempt != 1 {
+		t.Fatalf("Want: the retried delivery, Got: %v\n", claimed)
+	}
+
+	if err := store.DequeueWebhookDelivery(ctx, head.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	claimed = claim(t, store, 10)
+	if len(claimed) != 1 || claimed[id1].EventID != 2 {
+		t.Fatalf("Want: event 2 of the webhook %v, Got: %v\n", id1, claimed)
+	}
+
+	// the queue is deleted together with the webhook
+	if _, err := store.DeleteWebhook(ctx, id1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.RetryWebhookDelivery(ctx, claimed[id1].ID, 1, time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	if claimed := claim(t, store, 10); len(claimed) != 0 {
+		t.Fatalf("Want: no deliveries, Got: %v\n", claimed)
+	}
+}
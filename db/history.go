@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// Audit is who and which request is making the changes, it is recorded in the history of the changed todos
+type Audit struct {
+	Actor     string
+	RequestID string
+}
+
+type auditKey struct{}
+
+// WithAudit returns with a context recording the audit in the history of the changes made with it
+func WithAudit(ctx context.Context, audit Audit) context.Context {
+	return context.WithValue(ctx, auditKey{}, audit)
+}
+
+// AuditFromContext returns with the audit of the context, it is empty if it was not set
+func AuditFromContext(ctx context.Context) Audit {
+	audit, _ := ctx.Value(auditKey{}).(Audit)
+	return audit
+}
+
+// History is implemented by the repositories recording a revision of the todo with the audit
+// of the context in the same transaction as every change. The history of a todo is deleted
+// when it is purged from the trash.
+type History interface {
+	// ListTodoHistory returns with one page of the revisions of the todo, the newest first,
+	// and the token of the next page
+	ListTodoHistory(context.Context, *todolistpb.GetTodoHistoryRequest) ([]*todolistpb.TodoRevision, string, error)
+	// GetTodoRevision returns an empty revision if it does not exist
+	GetTodoRevision(context.Context, int64) (*todolistpb.TodoRevision, error)
+}
+
+// historyPageToken is the position of the last revision of the page
+type historyPageToken struct {
+	TodoID int32 `json:"t"`
+	ID     int64 `json:"i"`
+}
+
+// HistoryPageSize returns with the number of revisions on a page of the request
+func HistoryPageSize(req *todolistpb.GetTodoHistoryRequest) int {
+	size := int(req.GetPageSize())
+	if size <= 0 {
+		return defaultPageSize
+	}
+
+	if size > maxPageSize {
+		return maxPageSize
+	}
+
+	return size
+}
+
+// parseHistoryPageToken is decoding the page token of the request, it returns nil for the first page
+func parseHistoryPageToken(req *todolistpb.GetTodoHistoryRequest) (*historyPageToken, error) {
+	if req.GetPageToken() == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(req.GetPageToken())
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var token historyPageToken
+	if err := json.Unmarshal(b, &token); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	if token.TodoID != req.GetTodoId() {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &token, nil
+}
+
+// nextHistoryPage is cutting the revisions to the page size, the revisions have to contain one
+// more than the page size if there is a next page
+func nextHistoryPage(req *todolistpb.GetTodoHistoryRequest, revisions []*todolistpb.TodoRevision) ([]*todolistpb.TodoRevision, string, error) {
+	size := HistoryPageSize(req)
+	if len(revisions) <= size {
+		return revisions, "", nil
+	}
+
+	revisions = revisions[:size]
+	b, err := json.Marshal(historyPageToken{TodoID: req.GetTodoId(), ID: revisions[size-1].GetId()})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return revisions, base64.RawURLEncoding.EncodeToString(b), nil
+}
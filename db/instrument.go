@@ -0,0 +1,379 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// Observer is called before every call of an instrumented repository with the context and the operation
+// of the call, the call is made with the returned context and done is called with its error when it returns
+type Observer func(ctx context.Context, operation string) (context.Context, func(err error))
+
+// Instrument returns with the repository calling the observer around the calls of repo, the returned
+// repository is a Watcher if repo is a watcher. The watches are not observed as they last until
+// the client disconnects.
+func Instrument(repo Repository, observer Observer) Repository {
+	r := &instrumented{Repository: repo, observer: observer}
+
+	if watcher, ok := repo.(Watcher); ok {
+		return &instrumentedWatcher{instrumented: r, Watcher: watcher}
+	}
+
+	return r
+}
+
+// instrumented is observing the calls of the wrapped repository
+type instrumented struct {
+	Repository
+	observer Observer
+}
+
+// instrumentedWatcher is observing the calls of the wrapped repository which is a watcher
+type instrumentedWatcher struct {
+	*instrumented
+	Watcher
+}
+
+func (r *instrumented) Insert(ctx context.Context, todo *todolistpb.Todo) (int32, error) {
+	ctx, done := r.observer(ctx, "insert")
+	id, err := r.Repository.Insert(ctx, todo)
+	done(err)
+
+	return id, err
+}
+
+func (r *instrumented) Get(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	ctx, done := r.observer(ctx, "get")
+	todo, err := r.Repository.Get(ctx, id)
+	done(err)
+
+	return todo, err
+}
+
+func (r *instrumented) Update(ctx context.Context, todo *todolistpb.Todo, paths []string, expectedVersion int64) (*todolistpb.Todo, error) {
+	ctx, done := r.observer(ctx, "update")
+	todo, err := r.Repository.Update(ctx, todo, paths, expectedVersion)
+	done(err)
+
+	return todo, err
+}
+
+func (r *instrumented) Delete(ctx context.Context, id int32, expectedVersion int64) (int64, error) {
+	ctx, done := r.observer(ctx, "delete")
+	count, err := r.Repository.Delete(ctx, id, expectedVersion)
+	done(err)
+
+	return count, err
+}
+
+func (r *instrumented) List(ctx context.Context, req *todolistpb.ListTodosRequest) ([]*todolistpb.Todo, string, error) {
+	ctx, done := r.observer(ctx, "list")
+	todos, next, err := r.Repository.List(ctx, req)
+	done(err)
+
+	return todos, next, err
+}
+
+func (r *instrumented) Complete(ctx context.Context, id int32, completedAt *timestamp.Timestamp) (*todolistpb.Todo, error) {
+	ctx, done := r.observer(ctx, "complete")
+	todo, err := r.Repository.Complete(ctx, id, completedAt)
+	done(err)
+
+	return todo, err
+}
+
+func (r *instrumented) Reopen(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	ctx, done := r.observer(ctx, "reopen")
+	todo, err := r.Repository.Reopen(ctx, id)
+	done(err)
+
+	return todo, err
+}
+
+func (r *instrumented) Restore(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	ctx, done := r.observer(ctx, "restore")
+	todo, err := r.Repository.Restore(ctx, id)
+	done(err)
+
+	return todo, err
+}
+
+func (r *instrumented) GetTrashed(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	ctx, done := r.observer(ctx, "get_trashed")
+	todo, err := r.Repository.GetTrashed(ctx, id)
+	done(err)
+
+	return todo, err
+}
+
+func (r *instrumented) ListTrash(ctx context.Context) ([]*todolistpb.Todo, error) {
+	ctx, done := r.observer(ctx, "list_trash")
+	todos, err := r.Repository.ListTrash(ctx)
+	done(err)
+
+	return todos, err
+}
+
+func (r *instrumented) CountTodos(ctx context.Context, dueBefore *timestamp.Timestamp) (int64, int64, error) {
+	ctx, done := r.observer(ctx, "count_todos")
+	open, overdue, err := r.Repository.CountTodos(ctx, dueBefore)
+	done(err)
+
+	return open, overdue, err
+}
+
+func (r *instrumented) Purge(ctx context.Context, deletedBefore *timestamp.Timestamp) (int64, error) {
+	ctx, done := r.observer(ctx, "purge")
+	count, err := r.Repository.Purge(ctx, deletedBefore)
+	done(err)
+
+	return count, err
+}
+
+func (r *instrumented) InsertTodoList(ctx context.Context, list *todolistpb.TodoList) (int32, error) {
+	ctx, done := r.observer(ctx, "insert_todo_list")
+	id, err := r.Repository.InsertTodoList(ctx, list)
+	done(err)
+
+	return id, err
+}
+
+func (r *instrumented) GetTodoList(ctx context.Context, id int32) (*todolistpb.TodoList, error) {
+	ctx, done := r.observer(ctx, "get_todo_list")
+	list, err := r.Repository.GetTodoList(ctx, id)
+	done(err)
+
+	return list, err
+}
+
+func (r *instrumented) UpdateTodoList(ctx context.Context, list *todolistpb.TodoList) (*todolistpb.TodoList, error) {
+	ctx, done := r.observer(ctx, "update_todo_list")
+	list, err := r.Repository.UpdateTodoList(ctx, list)
+	done(err)
+
+	return list, err
+}
+
+func (r *instrumented) DeleteTodoList(ctx context.Context, id int32, deleteTodos bool) (int64, error) {
+	ctx, done := r.observer(ctx, "delete_todo_list")
+	count, err := r.Repository.DeleteTodoList(ctx, id, deleteTodos)
+	done(err)
+
+	return count, err
+}
+
+func (r *instrumented) ListTodoLists(ctx context.Context) ([]*todolistpb.TodoList, error) {
+	ctx, done := r.observer(ctx, "list_todo_lists")
+	lists, err := r.Repository.ListTodoLists(ctx)
+	done(err)
+
+	return lists, err
+}
+
+// InstrumentOutbox returns with the outbox calling the observer around the processing of the events,
+// the call is observed with the events published by fn
+func InstrumentOutbox(outbox Outbox, observer Observer) Outbox {
+	return &instrumentedOutbox{Outbox: outbox, observer: observer}
+}
+
+// instrumentedOutbox is observing the calls of the wrapped outbox
+type instrumentedOutbox struct {
+	Outbox
+	observer Observer
+}
+
+func (o *instrumentedOutbox) ProcessOutbox(ctx context.Context, limit int, fn func(*OutboxEvent) error) (int, error) {
+	ctx, done := o.observer(ctx, "process_outbox")
+	count, err := o.Outbox.ProcessOutbox(ctx, limit, fn)
+	done(err)
+
+	return count, err
+}
+
+// InstrumentWebhooks returns with the webhook store calling the observer around the calls of store
+func InstrumentWebhooks(store WebhookStore, observer Observer) WebhookStore {
+	return &instrumentedWebhooks{WebhookStore: store, observer: observer}
+}
+
+// instrumentedWebhooks is observing the calls of the wrapped webhook store
+type instrumentedWebhooks struct {
+	WebhookStore
+	observer Observer
+}
+
+func (s *instrumentedWebhooks) InsertWebhook(ctx context.Context, w *todolistpb.Webhook) (int32, error) {
+	ctx, done := s.observer(ctx, "insert_webhook")
+	id, err := s.WebhookStore.InsertWebhook(ctx, w)
+	done(err)
+
+	return id, err
+}
+
+func (s *instrumentedWebhooks) GetWebhook(ctx context.Context, id int32) (*todolistpb.Webhook, error) {
+	ctx, done := s.observer(ctx, "get_webhook")
+	w, err := s.WebhookStore.GetWebhook(ctx, id)
+	done(err)
+
+	return w, err
+}
+
+func (s *instrumentedWebhooks) UpdateWebhook(ctx context.Context, w *todolistpb.Webhook) (*todolistpb.Webhook, error) {
+	ctx, done := s.observer(ctx, "update_webhook")
+	w, err := s.WebhookStore.UpdateWebhook(ctx, w)
+	done(err)
+
+	return w, err
+}
+
+func (s *instrumentedWebhooks) DeleteWebhook(ctx context.Context, id int32) (int64, error) {
+	ctx, done := s.observer(ctx, "delete_webhook")
+	count, err := s.WebhookStore.DeleteWebhook(ctx, id)
+	done(err)
+
+	return count, err
+}
+
+func (s *instrumentedWebhooks) ListWebhooks(ctx context.Context) ([]*todolistpb.Webhook, error) {
+	ctx, done := s.observer(ctx, "list_webhooks")
+	webhooks, err := s.WebhookStore.ListWebhooks(ctx)
+	done(err)
+
+	return webhooks, err
+}
+
+func (s *instrumentedWebhooks) InsertWebhookDelivery(ctx context.Context, d *todolistpb.WebhookDelivery) (int64, error) {
+	ctx, done := s.observer(ctx, "insert_webhook_delivery")
+	id, err := s.WebhookStore.InsertWebhookDelivery(ctx, d)
+	done(err)
+
+	return id, err
+}
+
+func (s *instrumentedWebhooks) ListWebhookDeliveries(ctx context.Context, webhookID int32, limit int) ([]*todolistpb.WebhookDelivery, error) {
+	ctx, done := s.observer(ctx, "list_webhook_deliveries")
+	deliveries, err := s.WebhookStore.ListWebhookDeliveries(ctx, webhookID, limit)
+	done(err)
+
+	return deliveries, err
+}
+
+func (s *instrumentedWebhooks) RecordWebhookResult(ctx context.Context, id int32, success bool, disableAfter int32) (*todolistpb.Webhook, error) {
+	ctx, done := s.observer(ctx, "record_webhook_result")
+	w, err := s.WebhookStore.RecordWebhookResult(ctx, id, success, disableAfter)
+	done(err)
+
+	return w, err
+}
+
+func (s *instrumentedWebhooks) EnqueueWebhookDelivery(ctx context.Context, d *QueuedDelivery) (int64, error) {
+	ctx, done := s.observer(ctx, "enqueue_webhook_delivery")
+	id, err := s.WebhookStore.EnqueueWebhookDelivery(ctx, d)
+	done(err)
+
+	return id, err
+}
+
+func (s *instrumentedWebhooks) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*QueuedDelivery, error) {
+	ctx, done := s.observer(ctx, "claim_webhook_deliveries")
+	deliveries, err := s.WebhookStore.ClaimWebhookDeliveries(ctx, limit, lease)
+	done(err)
+
+	return deliveries, err
+}
+
+func (s *instrumentedWebhooks) RetryWebhookDelivery(ctx context.Context, id int64, attempt int32, nextAttemptAt time.Time) error {
+	ctx, done := s.observer(ctx, "retry_webhook_delivery")
+	err := s.WebhookStore.RetryWebhookDelivery(ctx, id, attempt, nextAttemptAt)
+	done(err)
+
+	return err
+}
+
+func (s *instrumentedWebhooks) DequeueWebhookDelivery(ctx context.Context, id int64) error {
+	ctx, done := s.observer(ctx, "dequeue_webhook_delivery")
+	err := s.WebhookStore.DequeueWebhookDelivery(ctx, id)
+	done(err)
+
+	return err
+}
+
+// InstrumentHistory returns with the history calling the observer around the calls of history
+func InstrumentHistory(history History, observer Observer) History {
+	return &instrumentedHistory{History: history, observer: observer}
+}
+
+// instrumentedHistory is observing the calls of the wrapped history
+type instrumentedHistory struct {
+	History
+	observer Observer
+}
+
+func (h *instrumentedHistory) ListTodoHistory(ctx context.Context, req *todolistpb.GetTodoHistoryRequest) ([]*todolistpb.TodoRevision, string, error) {
+	ctx, done := h.observer(ctx, "list_todo_history")
+	revisions, next, err := h.History.ListTodoHistory(ctx, req)
+	done(err)
+
+	return revisions, next, err
+}
+
+func (h *instrumentedHistory) GetTodoRevision(ctx context.Context, id int64) (*todolistpb.TodoRevision, error) {
+	ctx, done := h.observer(ctx, "get_todo_revision")
+	revision, err := h.History.GetTodoRevision(ctx, id)
+	done(err)
+
+	return revision, err
+}
+
+// InstrumentMembers returns with the member store calling the observer around the calls of store
+func InstrumentMembers(store MemberStore, observer Observer) MemberStore {
+	return &instrumentedMembers{MemberStore: store, observer: observer}
+}
+
+// instrumentedMembers is observing the calls of the wrapped member store
+type instrumentedMembers struct {
+	MemberStore
+	observer Observer
+}
+
+func (s *instrumentedMembers) PutListMember(ctx context.Context, member *todolistpb.ListMember) (*todolistpb.ListMember, error) {
+	ctx, done := s.observer(ctx, "put_list_member")
+	member, err := s.MemberStore.PutListMember(ctx, member)
+	done(err)
+
+	return member, err
+}
+
+func (s *instrumentedMembers) GetListMember(ctx context.Context, listID int32, subject string) (*todolistpb.ListMember, error) {
+	ctx, done := s.observer(ctx, "get_list_member")
+	member, err := s.MemberStore.GetListMember(ctx, listID, subject)
+	done(err)
+
+	return member, err
+}
+
+func (s *instrumentedMembers) DeleteListMember(ctx context.Context, listID int32, subject string) (int64, error) {
+	ctx, done := s.observer(ctx, "delete_list_member")
+	count, err := s.MemberStore.DeleteListMember(ctx, listID, subject)
+	done(err)
+
+	return count, err
+}
+
+func (s *instrumentedMembers) ListListMembers(ctx context.Context, listID int32) ([]*todolistpb.ListMember, error) {
+	ctx, done := s.observer(ctx, "list_list_members")
+	members, err := s.MemberStore.ListListMembers(ctx, listID)
+	done(err)
+
+	return members, err
+}
+
+func (s *instrumentedMembers) ListMemberships(ctx context.Context, subject string) ([]*todolistpb.ListMember, error) {
+	ctx, done := s.observer(ctx, "list_memberships")
+	members, err := s.MemberStore.ListMemberships(ctx, subject)
+	done(err)
+
+	return members, err
+}
@@ -0,0 +1,276 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// ErrInvalidPageToken is returned when the page token can not be decoded
+// or it belongs to a different sort order
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// pageToken is the position of the last todo of the page in the sort order
+type pageToken struct {
+	SortBy     todolistpb.ListTodosRequest_SortBy `json:"s"`
+	Descending bool                               `json:"d"`
+	ID         int32                              `json:"i"`
+	Time       time.Time                          `json:"t,omitempty"`
+	Title      string                             `json:"ti,omitempty"`
+	Priority   int32                              `json:"p,omitempty"`
+}
+
+// PageSize returns with the number of todos on a page of the request
+func PageSize(req *todolistpb.ListTodosRequest) int {
+	size := int(req.GetPageSize())
+	if size <= 0 {
+		return defaultPageSize
+	}
+
+	if size > maxPageSize {
+		return maxPageSize
+	}
+
+	return size
+}
+
+// newPageToken is encoding the position of the todo as an opaque page token
+func newPageToken(req *todolistpb.ListTodosRequest, todo *todolistpb.Todo) (string, error) {
+	token := pageToken{
+		SortBy:     req.GetSortBy(),
+		Descending: req.GetDescending(),
+		ID:         todo.GetId(),
+	}
+
+	var err error
+	switch req.GetSortBy() {
+	case todolistpb.ListTodosRequest_DUE_DATE:
+		token.Time, err = ptypes.Timestamp(todo.GetDueDate())
+	case todolistpb.ListTodosRequest_CREATED_AT:
+		token.Time, err = ptypes.Timestamp(todo.GetCreatedAt())
+	case todolistpb.ListTodosRequest_TITLE:
+		token.Title = todo.GetTitle()
+	case todolistpb.ListTodosRequest_PRIORITY:
+		token.Priority = todo.GetPriority()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// parsePageToken is decoding the page token of the request, it returns nil for the first page
+func parsePageToken(req *todolistpb.ListTodosRequest) (*pageToken, error) {
+	if req.GetPageToken() == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(req.GetPageToken())
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var token pageToken
+	if err := json.Unmarshal(b, &token); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	if token.SortBy != req.GetSortBy() || token.Descending != req.GetDescending() {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &token, nil
+}
+
+// todo returns with a todo holding the sort position of the token
+func (p *pageToken) todo() (*todolistpb.Todo, error) {
+	ts, err := ptypes.TimestampProto(p.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	return &todolistpb.Todo{
+		Id:        p.ID,
+		Title:     p.Title,
+		Priority:  p.Priority,
+		DueDate:   ts,
+		CreatedAt: ts,
+	}, nil
+}
+
+// sortValue returns with the value of the sort column of the token
+func (p *pageToken) sortValue() interface{} {
+	switch p.SortBy {
+	case todolistpb.ListTodosRequest_DUE_DATE, todolistpb.ListTodosRequest_CREATED_AT:
+		return p.Time
+	case todolistpb.ListTodosRequest_TITLE:
+		return p.Title
+	case todolistpb.ListTodosRequest_PRIORITY:
+		return p.Priority
+	}
+
+	return p.ID
+}
+
+// matchTodo checks if the todo is matching all the filters of the request
+func matchTodo(req *todolistpb.ListTodosRequest, todo *todolistpb.Todo) bool {
+	if len(req.GetStatus()) > 0 {
+		found := false
+		for _, s := range req.GetStatus() {
+			if s == todo.GetStatus() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if req.GetDueBefore() != nil && compareTimestamps(todo.GetDueDate(), req.GetDueBefore()) >= 0 {
+		return false
+	}
+
+	if req.GetDueAfter() != nil && compareTimestamps(todo.GetDueDate(), req.GetDueAfter()) <= 0 {
+		return false
+	}
+
+	if !strings.Contains(strings.ToLower(todo.GetTitle()), strings.ToLower(req.GetTitleContains())) {
+		return false
+	}
+
+	if req.GetListId() != 0 && todo.GetListId() != req.GetListId() {
+		return false
+	}
+
+	if req.GetInbox() && todo.GetListId() != 0 {
+		return false
+	}
+
+	for _, tag := range req.GetTags() {
+		found := false
+		for _, t := range todo.GetTags() {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compareTodos is comparing the todos by the sort order of the request, the ID breaks the ties
+func compareTodos(req *todolistpb.ListTodosRequest, a, b *todolistpb.Todo) int {
+	var c int
+	switch req.GetSortBy() {
+	case todolistpb.ListTodosRequest_DUE_DATE:
+		c = compareTimestamps(a.GetDueDate(), b.GetDueDate())
+	case todolistpb.ListTodosRequest_CREATED_AT:
+		c = compareTimestamps(a.GetCreatedAt(), b.GetCreatedAt())
+	case todolistpb.ListTodosRequest_TITLE:
+		c = strings.Compare(a.GetTitle(), b.GetTitle())
+	case todolistpb.ListTodosRequest_PRIORITY:
+		c = compareInts(int64(a.GetPriority()), int64(b.GetPriority()))
+	}
+
+	if c == 0 {
+		c = compareInts(int64(a.GetId()), int64(b.GetId()))
+	}
+
+	if req.GetDescending() {
+		return -c
+	}
+
+	return c
+}
+
+// listTodos is filtering, sorting and paging the todos in memory the same way as the database does,
+// it returns with the todos of the page and the token of the next page
+func listTodos(req *todolistpb.ListTodosRequest, todos []*todolistpb.Todo) ([]*todolistpb.Todo, string, error) {
+	token, err := parsePageToken(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var after *todolistpb.Todo
+	if token != nil {
+		if after, err = token.todo(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	var todoList []*todolistpb.Todo
+	for _, t := range todos {
+		if !matchTodo(req, t) {
+			continue
+		}
+		if after != nil && compareTodos(req, t, after) <= 0 {
+			continue
+		}
+		todoList = append(todoList, t)
+	}
+
+	sort.Slice(todoList, func(i, j int) bool {
+		return compareTodos(req, todoList[i], todoList[j]) < 0
+	})
+
+	return nextPage(req, todoList)
+}
+
+// nextPage is cutting the todos to the page size, the todos have to contain one more than
+// the page size if there is a next page
+func nextPage(req *todolistpb.ListTodosRequest, todos []*todolistpb.Todo) ([]*todolistpb.Todo, string, error) {
+	size := PageSize(req)
+	if len(todos) <= size {
+		return todos, "", nil
+	}
+
+	todos = todos[:size]
+	next, err := newPageToken(req, todos[size-1])
+	if err != nil {
+		return nil, "", err
+	}
+
+	return todos, next, nil
+}
+
+func compareTimestamps(a, b *timestamp.Timestamp) int {
+	if c := compareInts(a.GetSeconds(), b.GetSeconds()); c != 0 {
+		return c
+	}
+
+	return compareInts(int64(a.GetNanos()), int64(b.GetNanos()))
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
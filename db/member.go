@@ -0,0 +1,23 @@
+package db
+
+import (
+	"context"
+
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// MemberStore is implemented by the repositories storing the members of the shared lists,
+// the members of a list are deleted together with the list. The members are not scoped
+// to the owner of the context, the roles are checked by the server.
+type MemberStore interface {
+	// PutListMember is inserting the member or changing its role if the subject is already a member of the list,
+	// it returns with the stored member
+	PutListMember(context.Context, *todolistpb.ListMember) (*todolistpb.ListMember, error)
+	// GetListMember returns an empty member if the subject is not a member of the list
+	GetListMember(ctx context.Context, listID int32, subject string) (*todolistpb.ListMember, error)
+	DeleteListMember(ctx context.Context, listID int32, subject string) (int64, error)
+	// ListListMembers returns with the members of the list ordered by the subject
+	ListListMembers(ctx context.Context, listID int32) ([]*todolistpb.ListMember, error)
+	// ListMemberships returns with the memberships of the subject ordered by the list
+	ListMemberships(ctx context.Context, subject string) ([]*todolistpb.ListMember, error)
+}
@@ -0,0 +1,1453 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Memory is an in-memory database, it behaves the same way as the SQL databases
+// and it can be saved to a JSON snapshot file
+type Memory struct {
+	// SnapshotPath is the file the data is saved to on Close, nothing is saved if it is empty
+	SnapshotPath string
+
+	mu         sync.RWMutex
+	todos      map[int32]*todolistpb.Todo
+	lists      map[int32]*todolistpb.TodoList
+	lastTodoID int32
+	lastListID int32
+	// the members are ordered by the list and the subject
+	members []*todolistpb.ListMember
+
+	// the changes are recorded in the outbox under the lock of the change
+	outbox      []*OutboxEvent
+	lastEventID int64
+	// outboxMu is serializing the processing of the outbox
+	outboxMu sync.Mutex
+
+	webhooks       map[int32]*todolistpb.Webhook
+	deliveries     []*todolistpb.WebhookDelivery
+	lastWebhookID  int32
+	lastDeliveryID int64
+	// the queued deliveries are ordered by their IDs
+	queue        []*QueuedDelivery
+	lastQueuedID int64
+
+	// the revisions are recorded under the lock of the change, in the order of their IDs
+	history        []*todolistpb.TodoRevision
+	lastRevisionID int64
+}
+
+// memorySnapshot is the JSON snapshot of the Memory database
+type memorySnapshot struct {
+	LastTodoID int32             `json:"last_todo_id"`
+	LastListID int32             `json:"last_list_id"`
+	Todos      []json.RawMessage `json:"todos"`
+	Lists      []json.RawMessage `json:"lists"`
+	Members    []json.RawMessage `json:"members,omitempty"`
+	// the unpublished events are kept, so they are published after a restart
+	LastEventID int64               `json:"last_event_id"`
+	Outbox      []memoryOutboxEvent `json:"outbox,omitempty"`
+
+	LastWebhookID  int32             `json:"last_webhook_id"`
+	LastDeliveryID int64             `json:"last_delivery_id"`
+	Webhooks       []json.RawMessage `json:"webhooks,omitempty"`
+	Deliveries     []json.RawMessage `json:"deliveries,omitempty"`
+	// the undelivered messages are kept, so they are delivered after a restart
+	LastQueuedID int64                  `json:"last_queued_id"`
+	Queue        []memoryQueuedDelivery `json:"queue,omitempty"`
+
+	LastRevisionID int64             `json:"last_revision_id"`
+	History        []json.RawMessage `json:"history,omitempty"`
+}
+
+// memoryOutboxEvent is an event of the outbox in the JSON snapshot
+type memoryOutboxEvent struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	Todo       json.RawMessage `json:"todo"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// memoryQueuedDelivery is a queued delivery in the JSON snapshot
+type memoryQueuedDelivery struct {
+	ID            int64     `json:"id"`
+	WebhookID     int32     `json:"webhook_id"`
+	EventID       int64     `json:"event_id"`
+	EventType     string    `json:"event_type"`
+	Body          []byte    `json:"body"`
+	Attempt       int32     `json:"attempt"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+}
+
+// NewMemory returns with an empty Memory database, the data of the snapshot file
+// is loaded if the file exists
+func NewMemory(snapshotPath string) (*Memory, error) {
+	m := &Memory{
+		SnapshotPath: snapshotPath,
+		todos:        make(map[int32]*todolistpb.Todo),
+		lists:        make(map[int32]*todolistpb.TodoList),
+		webhooks:     make(map[int32]*todolistpb.Webhook),
+	}
+
+	if snapshotPath == "" {
+		return m, nil
+	}
+
+	b, err := os.ReadFile(snapshotPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.restore(b); err != nil {
+		return nil, fmt.Errorf("could not load the snapshot %v: %v", snapshotPath, err)
+	}
+
+	return m, nil
+}
+
+// Close is saving the snapshot if the snapshot path is set
+func (m *Memory) Close() error {
+	if m.SnapshotPath == "" {
+		return nil
+	}
+
+	m.mu.RLock()
+	b, err := m.snapshot()
+	m.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	// the snapshot is replaced at once, so a crash can not leave a partial file behind
+	tmp := m.SnapshotPath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, m.SnapshotPath)
+}
+
+// Insert is inserting the data to the database
+func (m *Memory) Insert(ctx context.Context, todo *todolistpb.Todo) (int32, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+
+	if _, err := ptypes.Timestamp(todo.GetDueDate()); err != nil {
+		return -1, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkList(todo.GetListId()); err != nil {
+		return -1, err
+	}
+
+	t := cloneTodo(todo)
+	m.lastTodoID++
+	t.Id = m.lastTodoID
+	t.Version = 1
+	t.DeletedAt = nil
+	t.Owner, _ = TenantFromContext(ctx)
+	if t.CreatedAt == nil {
+		t.CreatedAt = ptypes.TimestampNow()
+	}
+
+	m.todos[t.Id] = t
+	m.record(todolistpb.WatchTodosResponse_CREATED, t)
+	m.recordRevision(ctx, nil, t)
+
+	return t.Id, nil
+}
+
+// Get is getting the data from the database, it returns an empty todo if it does not exist
+// or it is in the trash
+func (m *Memory) Get(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t, ok := m.liveTodo(ctx, id)
+	if !ok {
+		return &todolistpb.Todo{}, nil
+	}
+
+	return cloneTodo(t), nil
+}
+
+// Update is updating the fields of the paths in the database, if the expected version is not 0
+// it returns ErrVersionMismatch when the todo has a different version
+func (m *Memory) Update(ctx context.Context, todo *todolistpb.Todo, paths []string, expectedVersion int64) (*todolistpb.Todo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		if !hasUpdatePath(path) {
+			return nil, fmt.Errorf("unknown update path: %v", path)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.liveTodo(ctx, todo.GetId())
+	if !ok {
+		return &todolistpb.Todo{}, nil
+	}
+
+	if len(paths) == 0 {
+		return cloneTodo(old), nil
+	}
+
+	if expectedVersion != 0 && old.GetVersion() != expectedVersion {
+		return &todolistpb.Todo{}, ErrVersionMismatch
+	}
+
+	t := cloneTodo(old)
+	mergeTodo(t, todo, paths)
+
+	for _, path := range paths {
+		switch path {
+		case "due_date":
+			if _, err := ptypes.Timestamp(t.GetDueDate()); err != nil {
+				return nil, err
+			}
+		case "status":
+			// the completion time is kept if the todo was already done
+			t.CompletedAt = nil
+			if t.GetStatus() == todolistpb.Status_DONE {
+				t.CompletedAt = old.GetCompletedAt()
+				if t.CompletedAt == nil {
+					t.CompletedAt = todo.GetCompletedAt()
+				}
+			}
+		case "list_id":
+			if err := m.checkList(t.GetListId()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	t.Version++
+
+	// the merged fields are shared with the todo of the caller
+	m.todos[t.Id] = cloneTodo(t)
+	m.record(todolistpb.WatchTodosResponse_UPDATED, t)
+	m.recordRevision(ctx, old, t)
+
+	return cloneTodo(t), nil
+}
+
+// Complete is setting the todo to done, the completion time is kept if it was already done
+func (m *Memory) Complete(ctx context.Context, id int32, completedAt *timestamp.Timestamp) (*todolistpb.Todo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := ptypes.Timestamp(completedAt); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.liveTodo(ctx, id)
+	if !ok {
+		return &todolistpb.Todo{}, nil
+	}
+
+	before := cloneTodo(t)
+	if t.GetStatus() != todolistpb.Status_DONE {
+		t.CompletedAt = cloneTimestamp(completedAt)
+	}
+	t.Status = todolistpb.Status_DONE
+	t.Version++
+	m.record(todolistpb.WatchTodosResponse_UPDATED, t)
+	m.recordRevision(ctx, before, t)
+
+	return cloneTodo(t), nil
+}
+
+// Reopen is setting the todo to open and clears the completion time
+func (m *Memory) Reopen(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.liveTodo(ctx, id)
+	if !ok {
+		return &todolistpb.Todo{}, nil
+	}
+
+	before := cloneTodo(t)
+	t.Status = todolistpb.Status_OPEN
+	t.CompletedAt = nil
+	t.Version++
+	m.record(todolistpb.WatchTodosResponse_UPDATED, t)
+	m.recordRevision(ctx, before, t)
+
+	return cloneTodo(t), nil
+}
+
+// Delete is moving the todo to the trash, if the expected version is not 0
+// it returns ErrVersionMismatch when the todo has a different version
+func (m *Memory) Delete(ctx context.Context, id int32, expectedVersion int64) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.liveTodo(ctx, id)
+	if !ok {
+		return 0, nil
+	}
+
+	if expectedVersion != 0 && t.GetVersion() != expectedVersion {
+		return 0, ErrVersionMismatch
+	}
+
+	before := cloneTodo(t)
+	t.DeletedAt = ptypes.TimestampNow()
+	m.record(todolistpb.WatchTodosResponse_DELETED, t)
+	m.recordRevision(ctx, before, t)
+
+	return 1, nil
+}
+
+// Restore is moving the todo back from the trash, it returns an empty todo if it is not in the trash
+func (m *Memory) Restore(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.todos[id]
+	if !ok || t.GetDeletedAt() == nil || !OwnedBy(ctx, t.GetOwner()) {
+		return &todolistpb.Todo{}, nil
+	}
+
+	before := cloneTodo(t)
+	t.DeletedAt = nil
+	m.record(todolistpb.WatchTodosResponse_CREATED, t)
+	m.recordRevision(ctx, before, t)
+
+	return cloneTodo(t), nil
+}
+
+// GetTrashed is getting the todo from the trash, it returns an empty todo if it is not in the trash
+func (m *Memory) GetTrashed(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t, ok := m.todos[id]
+	if !ok || t.GetDeletedAt() == nil || !OwnedBy(ctx, t.GetOwner()) {
+		return &todolistpb.Todo{}, nil
+	}
+
+	return cloneTodo(t), nil
+}
+
+// ListTrash is listing the todos of the trash, the last deleted first
+func (m *Memory) ListTrash(ctx context.Context) ([]*todolistpb.Todo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	var todos []*todolistpb.Todo
+	for _, t := range m.todos {
+		if t.GetDeletedAt() != nil && OwnedBy(ctx, t.GetOwner()) {
+			todos = append(todos, cloneTodo(t))
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(todos, func(i, j int) bool {
+		if c := compareTimestamps(todos[i].GetDeletedAt(), todos[j].GetDeletedAt()); c != 0 {
+			return c > 0
+		}
+		return todos[i].GetId() > todos[j].GetId()
+	})
+
+	return todos, nil
+}
+
+// CountTodos is counting the open and in progress todos and the ones due before the time
+func (m *Memory) CountTodos(ctx context.Context, dueBefore *timestamp.Timestamp) (int64, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, -1, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var open, overdue int64
+	for _, t := range m.todos {
+		if t.GetDeletedAt() != nil || !OwnedBy(ctx, t.GetOwner()) {
+			continue
+		}
+
+		if t.GetStatus() != todolistpb.Status_OPEN && t.GetStatus() != todolistpb.Status_IN_PROGRESS {
+			continue
+		}
+
+		open++
+		if t.GetDueDate() != nil && compareTimestamps(t.GetDueDate(), dueBefore) < 0 {
+			overdue++
+		}
+	}
+
+	return open, overdue, nil
+}
+
+// Purge is deleting the todos of the trash permanently which were deleted before the time,
+// or all of them if the time is nil
+func (m *Memory) Purge(ctx context.Context, deletedBefore *timestamp.Timestamp) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	for id, t := range m.todos {
+		if t.GetDeletedAt() == nil || !OwnedBy(ctx, t.GetOwner()) {
+			continue
+		}
+
+		if deletedBefore != nil && compareTimestamps(t.GetDeletedAt(), deletedBefore) >= 0 {
+			continue
+		}
+
+		delete(m.todos, id)
+		count++
+	}
+
+	// the history of the purged todos is deleted with them
+	history := m.history[:0]
+	for _, r := range m.history {
+		if _, ok := m.todos[r.GetTodoId()]; ok {
+			history = append(history, r)
+		}
+	}
+	m.history = history
+
+	return count, nil
+}
+
+// List is listing the data matching the filters in the sort order of the request,
+// it returns with one page of the data and the token of the next page
+func (m *Memory) List(ctx context.Context, req *todolistpb.ListTodosRequest) ([]*todolistpb.Todo, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	m.mu.RLock()
+	todos := make([]*todolistpb.Todo, 0, len(m.todos))
+	for _, t := range m.todos {
+		if t.GetDeletedAt() == nil && OwnedBy(ctx, t.GetOwner()) {
+			todos = append(todos, cloneTodo(t))
+		}
+	}
+	m.mu.RUnlock()
+
+	return listTodos(req, todos)
+}
+
+// InsertTodoList is inserting the list to the database
+func (m *Memory) InsertTodoList(ctx context.Context, list *todolistpb.TodoList) (int32, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l := cloneTodoList(list)
+	m.lastListID++
+	l.Id = m.lastListID
+	l.Owner, _ = TenantFromContext(ctx)
+	if l.CreatedAt == nil {
+		l.CreatedAt = ptypes.TimestampNow()
+	}
+
+	m.lists[l.Id] = l
+
+	return l.Id, nil
+}
+
+// GetTodoList is getting the list from the database, it returns an empty list if it does not exist
+func (m *Memory) GetTodoList(ctx context.Context, id int32) (*todolistpb.TodoList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	l, ok := m.lists[id]
+	if !ok || !OwnedBy(ctx, l.GetOwner()) {
+		return &todolistpb.TodoList{}, nil
+	}
+
+	return cloneTodoList(l), nil
+}
+
+// UpdateTodoList is updating the list in the database
+func (m *Memory) UpdateTodoList(ctx context.Context, list *todolistpb.TodoList) (*todolistpb.TodoList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, ok := m.lists[list.GetId()]
+	if !ok || !OwnedBy(ctx, l.GetOwner()) {
+		return &todolistpb.TodoList{}, nil
+	}
+
+	l.Name = list.GetName()
+	l.Description = list.GetDescription()
+	l.Color = list.GetColor()
+
+	return cloneTodoList(l), nil
+}
+
+// DeleteTodoList is deleting the list from the database, its todos are moved to the trash
+// or to the inbox
+func (m *Memory) DeleteTodoList(ctx context.Context, id int32, deleteTodos bool) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if l, ok := m.lists[id]; !ok || !OwnedBy(ctx, l.GetOwner()) {
+		return 0, nil
+	}
+
+	for _, t := range m.todos {
+		if t.GetListId() != id {
+			continue
+		}
+
+		// the todos of the trash are moved to the inbox without an event, the moves to the trash
+		// and to the inbox are separate revisions the same way as in the SQL databases
+		before := cloneTodo(t)
+		switch {
+		case t.GetDeletedAt() != nil:
+			t.ListId = 0
+		case deleteTodos && OwnedBy(ctx, t.GetOwner()):
+			t.DeletedAt = ptypes.TimestampNow()
+			m.record(todolistpb.WatchTodosResponse_DELETED, t)
+			m.recordRevision(ctx, before, t)
+			before = cloneTodo(t)
+			t.ListId = 0
+		default:
+			t.ListId = 0
+			m.record(todolistpb.WatchTodosResponse_UPDATED, t)
+		}
+		m.recordRevision(ctx, before, t)
+	}
+
+	delete(m.lists, id)
+	m.deleteListMembers(id)
+
+	return 1, nil
+}
+
+// ListTodoLists is listing the lists
+func (m *Memory) ListTodoLists(ctx context.Context) ([]*todolistpb.TodoList, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var lists []*todolistpb.TodoList
+	for _, l := range m.sortedLists() {
+		if OwnedBy(ctx, l.GetOwner()) {
+			lists = append(lists, cloneTodoList(l))
+		}
+	}
+
+	return lists, nil
+}
+
+// PutListMember is inserting the member to the database or changing its role
+func (m *Memory) PutListMember(ctx context.Context, member *todolistpb.ListMember) (*todolistpb.ListMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.lists[member.GetListId()]; !ok {
+		return nil, fmt.Errorf("todo list does not exist: %v", member.GetListId())
+	}
+
+	i, ok := m.findListMember(member.GetListId(), member.GetSubject())
+	if ok {
+		m.members[i].Role = member.GetRole()
+		return proto.Clone(m.members[i]).(*todolistpb.ListMember), nil
+	}
+
+	lm := proto.Clone(member).(*todolistpb.ListMember)
+	if lm.CreatedAt == nil {
+		lm.CreatedAt = ptypes.TimestampNow()
+	}
+
+	m.members = append(m.members, nil)
+	copy(m.members[i+1:], m.members[i:])
+	m.members[i] = lm
+
+	return proto.Clone(lm).(*todolistpb.ListMember), nil
+}
+
+// GetListMember is getting the member of the list from the database, it returns an empty member if it does not exist
+func (m *Memory) GetListMember(ctx context.Context, listID int32, subject string) (*todolistpb.ListMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	i, ok := m.findListMember(listID, subject)
+	if !ok {
+		return &todolistpb.ListMember{}, nil
+	}
+
+	return proto.Clone(m.members[i]).(*todolistpb.ListMember), nil
+}
+
+// DeleteListMember is deleting the member of the list from the database
+func (m *Memory) DeleteListMember(ctx context.Context, listID int32, subject string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i, ok := m.findListMember(listID, subject)
+	if !ok {
+		return 0, nil
+	}
+
+	m.members = append(m.members[:i], m.members[i+1:]...)
+
+	return 1, nil
+}
+
+// ListListMembers is listing the members of the list
+func (m *Memory) ListListMembers(ctx context.Context, listID int32) ([]*todolistpb.ListMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var members []*todolistpb.ListMember
+	for _, lm := range m.members {
+		if lm.GetListId() == listID {
+			members = append(members, proto.Clone(lm).(*todolistpb.ListMember))
+		}
+	}
+
+	return members, nil
+}
+
+// ListMemberships is listing the memberships of the subject
+func (m *Memory) ListMemberships(ctx context.Context, subject string) ([]*todolistpb.ListMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var members []*todolistpb.ListMember
+	for _, lm := range m.members {
+		if lm.GetSubject() == subject {
+			members = append(members, proto.Clone(lm).(*todolistpb.ListMember))
+		}
+	}
+
+	return members, nil
+}
+
+// ProcessOutbox is calling fn with the oldest events of the outbox
+func (m *Memory) ProcessOutbox(ctx context.Context, limit int, fn func(*OutboxEvent) error) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	m.outboxMu.Lock()
+	defer m.outboxMu.Unlock()
+
+	// the events are only appended by the changes, so the first ones are not changed while fn is called
+	m.mu.RLock()
+	events := m.outbox
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	m.mu.RUnlock()
+
+	count := 0
+	var fnErr error
+	for _, e := range events {
+		if fnErr = fn(e); fnErr != nil {
+			break
+		}
+		count++
+	}
+
+	m.mu.Lock()
+	m.outbox = append([]*OutboxEvent(nil), m.outbox[count:]...)
+	m.mu.Unlock()
+
+	return count, fnErr
+}
+
+// InsertWebhook is inserting the webhook of the owner of the context to the database
+func (m *Memory) InsertWebhook(ctx context.Context, webhook *todolistpb.Webhook) (int32, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w := proto.Clone(webhook).(*todolistpb.Webhook)
+	m.lastWebhookID++
+	w.Id = m.lastWebhookID
+	w.Owner, _ = TenantFromContext(ctx)
+	w.FailureCount = 0
+	if len(w.EventTypes) == 0 {
+		w.EventTypes = nil
+	}
+	if w.CreatedAt == nil {
+		w.CreatedAt = ptypes.TimestampNow()
+	}
+
+	m.webhooks[w.Id] = w
+
+	return w.Id, nil
+}
+
+// GetWebhook is getting the webhook from the database, it returns an empty webhook if it does not exist
+func (m *Memory) GetWebhook(ctx context.Context, id int32) (*todolistpb.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	w, ok := m.webhooks[id]
+	if !ok || !OwnedBy(ctx, w.GetOwner()) {
+		return &todolistpb.Webhook{}, nil
+	}
+
+	return proto.Clone(w).(*todolistpb.Webhook), nil
+}
+
+// UpdateWebhook is updating the webhook in the database, the secret is kept if it is empty
+func (m *Memory) UpdateWebhook(ctx context.Context, webhook *todolistpb.Webhook) (*todolistpb.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.webhooks[webhook.GetId()]
+	if !ok || !OwnedBy(ctx, w.GetOwner()) {
+		return &todolistpb.Webhook{}, nil
+	}
+
+	w.Url = webhook.GetUrl()
+	w.EventTypes = append([]string(nil), webhook.GetEventTypes()...)
+	if webhook.GetSecret() != "" {
+		w.Secret = webhook.GetSecret()
+	}
+	w.Disabled = webhook.GetDisabled()
+	w.FailureCount = 0
+
+	return proto.Clone(w).(*todolistpb.Webhook), nil
+}
+
+// DeleteWebhook is deleting the webhook, its deliveries and its queue from the database
+func (m *Memory) DeleteWebhook(ctx context.Context, id int32) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if w, ok := m.webhooks[id]; !ok || !OwnedBy(ctx, w.GetOwner()) {
+		return 0, nil
+	}
+
+	delete(m.webhooks, id)
+
+	var deliveries []*todolistpb.WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.GetWebhookId() != id {
+			deliveries = append(deliveries, d)
+		}
+	}
+	m.deliveries = deliveries
+
+	var queue []*QueuedDelivery
+	for _, d := range m.queue {
+		if d.WebhookID != id {
+			queue = append(queue, d)
+		}
+	}
+	m.queue = queue
+
+	return 1, nil
+}
+
+// ListWebhooks is listing the webhooks of the owner of the context
+func (m *Memory) ListWebhooks(ctx context.Context) ([]*todolistpb.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var webhooks []*todolistpb.Webhook
+	for _, w := range m.sortedWebhooks() {
+		if OwnedBy(ctx, w.GetOwner()) {
+			webhooks = append(webhooks, proto.Clone(w).(*todolistpb.Webhook))
+		}
+	}
+
+	return webhooks, nil
+}
+
+// InsertWebhookDelivery is inserting the attempt of a delivery to the database, it returns 0 if the webhook does not exist
+func (m *Memory) InsertWebhookDelivery(ctx context.Context, delivery *todolistpb.WebhookDelivery) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if w, ok := m.webhooks[delivery.GetWebhookId()]; !ok || !OwnedBy(ctx, w.GetOwner()) {
+		return 0, nil
+	}
+
+	d := proto.Clone(delivery).(*todolistpb.WebhookDelivery)
+	m.lastDeliveryID++
+	d.Id = m.lastDeliveryID
+	if d.AttemptedAt == nil {
+		d.AttemptedAt = ptypes.TimestampNow()
+	}
+
+	m.deliveries = append(m.deliveries, d)
+
+	return d.Id, nil
+}
+
+// ListWebhookDeliveries is listing the last deliveries of the webhook, the newest first
+func (m *Memory) ListWebhookDeliveries(ctx context.Context, webhookID int32, limit int) ([]*todolistpb.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if w, ok := m.webhooks[webhookID]; !ok || !OwnedBy(ctx, w.GetOwner()) {
+		return nil, nil
+	}
+
+	var deliveries []*todolistpb.WebhookDelivery
+	for i := len(m.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if d := m.deliveries[i]; d.GetWebhookId() == webhookID {
+			deliveries = append(deliveries, proto.Clone(d).(*todolistpb.WebhookDelivery))
+		}
+	}
+
+	return deliveries, nil
+}
+
+// RecordWebhookResult is updating the failure count of the webhook, it is disabled when the count reaches disableAfter
+func (m *Memory) RecordWebhookResult(ctx context.Context, id int32, success bool, disableAfter int32) (*todolistpb.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.webhooks[id]
+	if !ok || !OwnedBy(ctx, w.GetOwner()) {
+		return &todolistpb.Webhook{}, nil
+	}
+
+	if success {
+		w.FailureCount = 0
+	} else {
+		w.FailureCount++
+		if disableAfter > 0 && w.FailureCount >= disableAfter {
+			w.Disabled = true
+		}
+	}
+
+	return proto.Clone(w).(*todolistpb.Webhook), nil
+}
+
+// EnqueueWebhookDelivery is queueing the message to the webhook, it returns 0 if the webhook does not exist
+// or the event is already queued to it
+func (m *Memory) EnqueueWebhookDelivery(ctx context.Context, delivery *QueuedDelivery) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if w, ok := m.webhooks[delivery.WebhookID]; !ok || !OwnedBy(ctx, w.GetOwner()) {
+		return 0, nil
+	}
+
+	if delivery.EventID != 0 {
+		for _, d := range m.queue {
+			if d.WebhookID == delivery.WebhookID && d.EventID == delivery.EventID {
+				return 0, nil
+			}
+		}
+	}
+
+	d := *delivery
+	m.lastQueuedID++
+	d.ID = m.lastQueuedID
+	d.Attempt = 0
+	d.Body = append([]byte(nil), delivery.Body...)
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = time.Now()
+	}
+
+	m.queue = append(m.queue, &d)
+
+	return d.ID, nil
+}
+
+// ClaimWebhookDeliveries is claiming the oldest due delivery of the webhooks for the lease
+func (m *Memory) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*QueuedDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	heads := make(map[int32]bool)
+
+	var deliveries []*QueuedDelivery
+	for _, d := range m.queue {
+		if len(deliveries) == limit {
+			break
+		}
+
+		if heads[d.WebhookID] {
+			continue
+		}
+		heads[d.WebhookID] = true
+
+		if d.NextAttemptAt.After(now) {
+			continue
+		}
+
+		d.NextAttemptAt = now.Add(lease)
+		claimed := *d
+		deliveries = append(deliveries, &claimed)
+	}
+
+	return deliveries, nil
+}
+
+// RetryWebhookDelivery is setting the failed attempts and the next attempt of the queued delivery
+func (m *Memory) RetryWebhookDelivery(ctx context.Context, id int64, attempt int32, nextAttemptAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, d := range m.queue {
+		if d.ID == id {
+			d.Attempt = attempt
+			d.NextAttemptAt = nextAttemptAt
+		}
+	}
+
+	return nil
+}
+
+// DequeueWebhookDelivery is deleting the delivery from the queue
+func (m *Memory) DequeueWebhookDelivery(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, d := range m.queue {
+		if d.ID == id {
+			m.queue = append(m.queue[:i:i], m.queue[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// ListTodoHistory is listing one page of the revisions of the todo, the newest first
+func (m *Memory) ListTodoHistory(ctx context.Context, req *todolistpb.GetTodoHistoryRequest) ([]*todolistpb.TodoRevision, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	token, err := parseHistoryPageToken(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if t, ok := m.todos[req.GetTodoId()]; !ok || !OwnedBy(ctx, t.GetOwner()) {
+		return nextHistoryPage(req, nil)
+	}
+
+	// one more revision is read to know if there is a next page
+	size := HistoryPageSize(req) + 1
+	var revisions []*todolistpb.TodoRevision
+	for i := len(m.history) - 1; i >= 0 && len(revisions) < size; i-- {
+		r := m.history[i]
+		if r.GetTodoId() != req.GetTodoId() || (token != nil && r.GetId() >= token.ID) {
+			continue
+		}
+		revisions = append(revisions, proto.Clone(r).(*todolistpb.TodoRevision))
+	}
+
+	return nextHistoryPage(req, revisions)
+}
+
+// GetTodoRevision is getting the revision from the database, it returns an empty revision if it does not exist
+func (m *Memory) GetTodoRevision(ctx context.Context, id int64) (*todolistpb.TodoRevision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// the revisions are ordered by their IDs
+	i := sort.Search(len(m.history), func(i int) bool { return m.history[i].GetId() >= id })
+	if i == len(m.history) || m.history[i].GetId() != id {
+		return &todolistpb.TodoRevision{}, nil
+	}
+
+	if t, ok := m.todos[m.history[i].GetTodoId()]; !ok || !OwnedBy(ctx, t.GetOwner()) {
+		return &todolistpb.TodoRevision{}, nil
+	}
+
+	return proto.Clone(m.history[i]).(*todolistpb.TodoRevision), nil
+}
+
+// record is adding the change of the todo to the outbox, it is called under the write lock
+func (m *Memory) record(eventType todolistpb.WatchTodosResponse_EventType, todo *todolistpb.Todo) {
+	m.lastEventID++
+	m.outbox = append(m.outbox, &OutboxEvent{
+		ID:         m.lastEventID,
+		Type:       eventType,
+		Todo:       cloneTodo(todo),
+		OccurredAt: time.Now(),
+	})
+}
+
+// recordRevision is adding the change of the todo to the history with the audit of the context,
+// before is nil for a new todo, it is called under the write lock
+func (m *Memory) recordRevision(ctx context.Context, before, after *todolistpb.Todo) {
+	action := todolistpb.TodoRevision_UPDATED
+	switch {
+	case before == nil:
+		action = todolistpb.TodoRevision_CREATED
+	case before.GetDeletedAt() == nil && after.GetDeletedAt() != nil:
+		action = todolistpb.TodoRevision_DELETED
+	case before.GetDeletedAt() != nil && after.GetDeletedAt() == nil:
+		action = todolistpb.TodoRevision_RESTORED
+	}
+
+	r := &todolistpb.TodoRevision{
+		TodoId:    after.GetId(),
+		Action:    action,
+		After:     cloneTodo(after),
+		ChangedAt: ptypes.TimestampNow(),
+	}
+	if before != nil {
+		r.Before = cloneTodo(before)
+	}
+
+	audit := AuditFromContext(ctx)
+	r.Actor = audit.Actor
+	r.RequestId = audit.RequestID
+
+	m.lastRevisionID++
+	r.Id = m.lastRevisionID
+	m.history = append(m.history, r)
+}
+
+// sortedTodos returns with the stored todos ordered by ID
+func (m *Memory) sortedTodos() []*todolistpb.Todo {
+	todos := make([]*todolistpb.Todo, 0, len(m.todos))
+	for _, t := range m.todos {
+		todos = append(todos, t)
+	}
+
+	sort.Slice(todos, func(i, j int) bool {
+		return todos[i].GetId() < todos[j].GetId()
+	})
+
+	return todos
+}
+
+// sortedLists returns with the stored lists ordered by ID
+func (m *Memory) sortedLists() []*todolistpb.TodoList {
+	lists := make([]*todolistpb.TodoList, 0, len(m.lists))
+	for _, l := range m.lists {
+		lists = append(lists, l)
+	}
+
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].GetId() < lists[j].GetId()
+	})
+
+	return lists
+}
+
+// sortedWebhooks returns with the stored webhooks ordered by ID
+func (m *Memory) sortedWebhooks() []*todolistpb.Webhook {
+	webhooks := make([]*todolistpb.Webhook, 0, len(m.webhooks))
+	for _, w := range m.webhooks {
+		webhooks = append(webhooks, w)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].GetId() < webhooks[j].GetId()
+	})
+
+	return webhooks
+}
+
+// findListMember returns with the index of the member, or the index it has to be inserted at if it does not exist
+func (m *Memory) findListMember(listID int32, subject string) (int, bool) {
+	i := sort.Search(len(m.members), func(i int) bool {
+		lm := m.members[i]
+		return lm.GetListId() > listID || lm.GetListId() == listID && lm.GetSubject() >= subject
+	})
+
+	return i, i < len(m.members) && m.members[i].GetListId() == listID && m.members[i].GetSubject() == subject
+}
+
+// deleteListMembers is deleting the members of the list, the same way as the foreign key does
+func (m *Memory) deleteListMembers(listID int32) {
+	var members []*todolistpb.ListMember
+	for _, lm := range m.members {
+		if lm.GetListId() != listID {
+			members = append(members, lm)
+		}
+	}
+	m.members = members
+}
+
+// checkList returns an error if the list does not exist, the same way as the foreign key does
+func (m *Memory) checkList(id int32) error {
+	if id == 0 {
+		return nil
+	}
+
+	if _, ok := m.lists[id]; !ok {
+		return fmt.Errorf("todo list does not exist: %v", id)
+	}
+
+	return nil
+}
+
+// snapshot is encoding the data as JSON
+func (m *Memory) snapshot() ([]byte, error) {
+	s := memorySnapshot{
+		LastTodoID: m.lastTodoID,
+		LastListID: m.lastListID,
+		Todos:      []json.RawMessage{},
+		Lists:      []json.RawMessage{},
+	}
+
+	for _, t := range m.sortedTodos() {
+		b, err := protojson.Marshal(t)
+		if err != nil {
+			return nil, err
+		}
+		s.Todos = append(s.Todos, b)
+	}
+
+	for _, l := range m.sortedLists() {
+		b, err := protojson.Marshal(l)
+		if err != nil {
+			return nil, err
+		}
+		s.Lists = append(s.Lists, b)
+	}
+
+	for _, lm := range m.members {
+		b, err := protojson.Marshal(lm)
+		if err != nil {
+			return nil, err
+		}
+		s.Members = append(s.Members, b)
+	}
+
+	s.LastEventID = m.lastEventID
+	for _, e := range m.outbox {
+		b, err := protojson.Marshal(e.Todo)
+		if err != nil {
+			return nil, err
+		}
+		s.Outbox = append(s.Outbox, memoryOutboxEvent{ID: e.ID, Type: e.Type.String(), Todo: b, OccurredAt: e.OccurredAt})
+	}
+
+	s.LastWebhookID = m.lastWebhookID
+	s.LastDeliveryID = m.lastDeliveryID
+	for _, w := range m.sortedWebhooks() {
+		b, err := protojson.Marshal(w)
+		if err != nil {
+			return nil, err
+		}
+		s.Webhooks = append(s.Webhooks, b)
+	}
+
+	for _, d := range m.deliveries {
+		b, err := protojson.Marshal(d)
+		if err != nil {
+			return nil, err
+		}
+		s.Deliveries = append(s.Deliveries, b)
+	}
+
+	s.LastQueuedID = m.lastQueuedID
+	for _, d := range m.queue {
+		s.Queue = append(s.Queue, memoryQueuedDelivery(*d))
+	}
+
+	s.LastRevisionID = m.lastRevisionID
+	for _, r := range m.history {
+		b, err := protojson.Marshal(r)
+		if err != nil {
+			return nil, err
+		}
+		s.History = append(s.History, b)
+	}
+
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// restore is loading the data from the JSON snapshot
+func (m *Memory) restore(b []byte) error {
+	var s memorySnapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	for _, raw := range s.Todos {
+		var t todolistpb.Todo
+		if err := protojson.Unmarshal(raw, &t); err != nil {
+			return err
+		}
+		m.todos[t.GetId()] = &t
+	}
+
+	for _, raw := range s.Lists {
+		var l todolistpb.TodoList
+		if err := protojson.Unmarshal(raw, &l); err != nil {
+			return err
+		}
+		m.lists[l.GetId()] = &l
+	}
+
+	for _, raw := range s.Members {
+		var lm todolistpb.ListMember
+		if err := protojson.Unmarshal(raw, &lm); err != nil {
+			return err
+		}
+		m.members = append(m.members, &lm)
+	}
+
+	for _, e := range s.Outbox {
+		var t todolistpb.Todo
+		if err := protojson.Unmarshal(e.Todo, &t); err != nil {
+			return err
+		}
+		m.outbox = append(m.outbox, &OutboxEvent{
+			ID:         e.ID,
+			Type:       todolistpb.WatchTodosResponse_EventType(todolistpb.WatchTodosResponse_EventType_value[e.Type]),
+			Todo:       &t,
+			OccurredAt: e.OccurredAt,
+		})
+	}
+
+	for _, raw := range s.Webhooks {
+		var w todolistpb.Webhook
+		if err := protojson.Unmarshal(raw, &w); err != nil {
+			return err
+		}
+		m.webhooks[w.GetId()] = &w
+	}
+
+	for _, raw := range s.Deliveries {
+		var d todolistpb.WebhookDelivery
+		if err := protojson.Unmarshal(raw, &d); err != nil {
+			return err
+		}
+		m.deliveries = append(m.deliveries, &d)
+	}
+
+	for _, d := range s.Queue {
+		d := QueuedDelivery(d)
+		m.queue = append(m.queue, &d)
+	}
+
+	for _, raw := range s.History {
+		var r todolistpb.TodoRevision
+		if err := protojson.Unmarshal(raw, &r); err != nil {
+			return err
+		}
+		m.history = append(m.history, &r)
+	}
+
+	m.lastTodoID = s.LastTodoID
+	m.lastListID = s.LastListID
+	m.lastEventID = s.LastEventID
+	m.lastWebhookID = s.LastWebhookID
+	m.lastDeliveryID = s.LastDeliveryID
+	m.lastQueuedID = s.LastQueuedID
+	m.lastRevisionID = s.LastRevisionID
+
+	return nil
+}
+
+// liveTodo returns with the todo if it exists, it is not in the trash and it is owned by the tenant of the context
+func (m *Memory) liveTodo(ctx context.Context, id int32) (*todolistpb.Todo, bool) {
+	t, ok := m.todos[id]
+	if !ok || t.GetDeletedAt() != nil || !OwnedBy(ctx, t.GetOwner()) {
+		return nil, false
+	}
+
+	return t, true
+}
+
+// cloneTodo returns with a copy of the todo, no tags are stored as nil the same way as the databases return them.
+// The fields are copied one by one, so the copy is a new message the same way as the todos of the databases
+// and it is equal to them with reflect.DeepEqual.
+func cloneTodo(todo *todolistpb.Todo) *todolistpb.Todo {
+	t := &todolistpb.Todo{
+		Id:          todo.GetId(),
+		Title:       todo.GetTitle(),
+		Note:        todo.GetNote(),
+		DueDate:     cloneTimestamp(todo.GetDueDate()),
+		Status:      todo.GetStatus(),
+		CompletedAt: cloneTimestamp(todo.GetCompletedAt()),
+		Priority:    todo.GetPriority(),
+		CreatedAt:   cloneTimestamp(todo.GetCreatedAt()),
+		ListId:      todo.GetListId(),
+		Version:     todo.GetVersion(),
+		DeletedAt:   cloneTimestamp(todo.GetDeletedAt()),
+		Owner:       todo.GetOwner(),
+	}
+	if len(todo.GetTags()) > 0 {
+		t.Tags = append([]string(nil), todo.GetTags()...)
+	}
+
+	return t
+}
+
+// cloneTodoList returns with a copy of the list made the same way as cloneTodo
+func cloneTodoList(list *todolistpb.TodoList) *todolistpb.TodoList {
+	return &todolistpb.TodoList{
+		Id:          list.GetId(),
+		Name:        list.GetName(),
+		Description: list.GetDescription(),
+		Color:       list.GetColor(),
+		CreatedAt:   cloneTimestamp(list.GetCreatedAt()),
+		Owner:       list.GetOwner(),
+	}
+}
+
+// cloneTimestamp returns with a copy of the timestamp, nil if it is not set
+func cloneTimestamp(ts *timestamp.Timestamp) *timestamp.Timestamp {
+	if ts == nil {
+		return nil
+	}
+
+	return &timestamp.Timestamp{Seconds: ts.GetSeconds(), Nanos: ts.GetNanos()}
+}
+
+func hasUpdatePath(path string) bool {
+	for _, p := range UpdatePaths {
+		if p == path {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,268 @@
+package db_test
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func setupMemory(t *testing.T) *db.Memory {
+	m, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func TestMemoryInsertGet(t *testing.T) {
+	memory := setupMemory(t)
+	ctx := context.Background()
+
+	id, err := memory.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := memory.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(1, "Test Todo")
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	// the stored todo is not changed by the caller
+	got.Title = "Changed"
+	got, err = memory.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	got, err = memory.Get(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v\n", got)
+	}
+}
+
+func TestMemoryUpdate(t *testing.T) {
+	memory := setupMemory(t)
+	ctx := context.Background()
+
+	id, err := memory.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todo := getTestTodo(id, "Update Test Todo")
+	todo.Status = todolistpb.Status_DONE
+	todo.CompletedAt = todo.GetDueDate()
+
+	got, err := memory.Update(ctx, todo, db.UpdatePaths, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(id, "Update Test Todo")
+	want.Status = todolistpb.Status_DONE
+	want.CompletedAt = want.GetDueDate()
+	want.Version = 2
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	// only the title is updated, the rest of the fields are kept
+	got, err = memory.Update(ctx, &todolistpb.Todo{Id: id, Title: "Renamed Test Todo"}, []string{"title"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want.Title = "Renamed Test Todo"
+	want.Version = 3
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	if _, err := memory.Update(ctx, todo, db.UpdatePaths, 2); err != db.ErrVersionMismatch {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrVersionMismatch, err)
+	}
+
+	todo = getTestTodo(id, "Test Todo")
+	todo.ListId = 1
+	if _, err := memory.Update(ctx, todo, []string{"list_id"}, 0); err == nil {
+		t.Fatalf("Want: error for unknown list, Got: nil\n")
+	}
+}
+
+func TestMemoryDelete(t *testing.T) {
+	memory := setupMemory(t)
+	ctx := context.Background()
+
+	id, err := memory.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := memory.Delete(ctx, id, 2); err != db.ErrVersionMismatch {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrVersionMismatch, err)
+	}
+
+	for _, want := range []int64{1, 0} {
+		got, err := memory.Delete(ctx, id, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Fatalf("Want: %v, Got: %v\n", want, got)
+		}
+	}
+}
+
+func TestMemoryConcurrentInsert(t *testing.T) {
+	memory := setupMemory(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := memory.Insert(ctx, getTestTodo(0, "Test Todo")); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	todos, _, err := memory.List(ctx, &todolistpb.ListTodosRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(todos) != 50 || todos[49].GetId() != 50 {
+		t.Fatalf("Want: 50 todos, Got: %v\n", len(todos))
+	}
+}
+
+func TestMemorySnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.json")
+	ctx := context.Background()
+
+	memory, err := db.NewMemory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listID, err := memory.InsertTodoList(ctx, getTestTodoList(0, "Test List"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todo := getTestTodo(0, "Test Todo")
+	todo.Tags = []string{"home"}
+	todo.ListId = listID
+	id, err := memory.Insert(ctx, todo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := memory.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := memory.PutListMember(ctx, &todolistpb.ListMember{ListId: listID, Subject: "bob", Role: todolistpb.ListMember_EDITOR}); err != nil {
+		t.Fatal(err)
+	}
+
+	webhookID, err := memory.InsertWebhook(ctx, &todolistpb.Webhook{Url: "http://example.com", Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := memory.InsertWebhookDelivery(ctx, &todolistpb.WebhookDelivery{WebhookId: webhookID, EventId: 1, Attempt: 1, Success: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := memory.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	memory, err = db.NewMemory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := memory.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	lists, err := memory.ListTodoLists(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(lists, []*todolistpb.TodoList{getTestTodoList(listID, "Test List")}) {
+		t.Fatalf("Want: %v, Got: %v\n", listID, lists)
+	}
+
+	member, err := memory.GetListMember(ctx, listID, "bob")
+	if err != nil || member.GetRole() != todolistpb.ListMember_EDITOR {
+		t.Fatalf("Want: bob, Got: %v %v\n", member, err)
+	}
+
+	webhook, err := memory.GetWebhook(ctx, webhookID)
+	if err != nil || webhook.GetSecret() != "secret" {
+		t.Fatalf("Want: %v, Got: %v %v\n", webhookID, webhook, err)
+	}
+
+	deliveries, err := memory.ListWebhookDeliveries(ctx, webhookID, 10)
+	if err != nil || len(deliveries) != 1 || !deliveries[0].GetSuccess() {
+		t.Fatalf("Want: 1 delivery, Got: %v %v\n", deliveries, err)
+	}
+
+	revisions, _, err := memory.ListTodoHistory(ctx, &todolistpb.GetTodoHistoryRequest{TodoId: id})
+	if err != nil || len(revisions) != 1 || !proto.Equal(revisions[0].GetAfter(), want) {
+		t.Fatalf("Want: 1 revision, Got: %v %v\n", revisions, err)
+	}
+
+	// the IDs are not reused after loading the snapshot
+	next, err := memory.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next != id+1 {
+		t.Fatalf("Want: %v, Got: %v\n", id+1, next)
+	}
+
+	revisions, _, err = memory.ListTodoHistory(ctx, &todolistpb.GetTodoHistoryRequest{TodoId: next})
+	if err != nil || len(revisions) != 1 || revisions[0].GetId() != 2 {
+		t.Fatalf("Want: revision 2, Got: %v %v\n", revisions, err)
+	}
+}
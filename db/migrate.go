@@ -0,0 +1,333 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+//go:embed migrations/sqlite/*.sql
+var sqliteMigrations embed.FS
+
+// migrationLockID is the key of the Postgres advisory lock held while migrating
+const migrationLockID = 8675309
+
+// migrationDialect holds the database specific queries of the migrator
+type migrationDialect struct {
+	createTable string
+	lock        string
+	unlock      string
+}
+
+var postgresDialect = migrationDialect{
+	createTable: `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		VERSION BIGINT PRIMARY KEY,
+		NAME TEXT NOT NULL,
+		APPLIED_AT TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+	);
+	`,
+	lock:   `SELECT pg_advisory_lock($1);`,
+	unlock: `SELECT pg_advisory_unlock($1);`,
+}
+
+// sqliteDialect has no lock, the database file is owned by a single instance
+var sqliteDialect = migrationDialect{
+	createTable: `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		VERSION INTEGER PRIMARY KEY,
+		NAME TEXT NOT NULL,
+		APPLIED_AT TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	`,
+}
+
+// migrationFile matches the <version>_<name>.<up|down>.sql file names
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus holds the state of a migration in the database
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator is applying the schema migrations to the database
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []Migration
+
+	dialect migrationDialect
+}
+
+// NewPostgresMigrator returns with a Migrator using the embedded Postgres migrations
+func NewPostgresMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := LoadMigrations(postgresMigrations, "migrations/postgres")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{DB: db, Migrations: migrations, dialect: postgresDialect}, nil
+}
+
+// NewSQLiteMigrator returns with a Migrator using the embedded SQLite migrations
+func NewSQLiteMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := LoadMigrations(sqliteMigrations, "migrations/sqlite")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{DB: db, Migrations: migrations, dialect: sqliteDialect}, nil
+}
+
+// LoadMigrations is loading the migrations from the directory ordered by version
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		match := migrationFile.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %v", e.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %v has different names: %v, %v", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	var migrations []Migration
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %v has no up step", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up is applying all the pending migrations and returns with the applied ones
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.Migrations {
+			if _, ok := versions[mig.Version]; ok {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, mig.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2);`, mig.Version, mig.Name); err != nil {
+				return fmt.Errorf("migration %v_%v: %v", mig.Version, mig.Name, err)
+			}
+			applied = append(applied, mig)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down is rolling back the last applied migration, it returns nil if there was nothing to roll back
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var rolledBack *Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if version == 0 {
+			return nil
+		}
+
+		mig := m.find(version)
+		if mig == nil {
+			return fmt.Errorf("unknown migration version: %v", version)
+		}
+
+		if mig.Down == "" {
+			return fmt.Errorf("migration %v_%v can not be rolled back", mig.Version, mig.Name)
+		}
+
+		if err := m.apply(ctx, conn, mig.Down, `DELETE FROM schema_migrations WHERE version = $1;`, mig.Version); err != nil {
+			return fmt.Errorf("migration %v_%v: %v", mig.Version, mig.Name, err)
+		}
+		rolledBack = mig
+
+		return nil
+	})
+
+	return rolledBack, err
+}
+
+// Version returns with the version of the last applied migration, 0 if none was applied
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	var version int64
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		var err error
+		version, err = currentVersion(ctx, conn)
+		return err
+	})
+
+	return version, err
+}
+
+// Status returns with the state of every known migration
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.Migrations {
+			appliedAt, ok := versions[mig.Version]
+			statuses = append(statuses, MigrationStatus{
+				Migration: mig,
+				Applied:   ok,
+				AppliedAt: appliedAt,
+			})
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.Migrations {
+		if m.Migrations[i].Version == version {
+			return &m.Migrations[i]
+		}
+	}
+
+	return nil
+}
+
+// apply is running the migration script and the bookkeeping query in one transaction
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, script string, query string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// withLock is holding an advisory lock on a dedicated connection,
+// so concurrently starting instances are migrating one after the other
+func (m *Migrator) withLock(ctx context.Context, fn func(*sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.dialect.lock != "" {
+		if _, err := conn.ExecContext(ctx, m.dialect.lock, migrationLockID); err != nil {
+			return fmt.Errorf("could not acquire the migration lock: %v", err)
+		}
+		defer conn.ExecContext(context.Background(), m.dialect.unlock, migrationLockID)
+	}
+
+	if _, err := conn.ExecContext(ctx, m.dialect.createTable); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		versions[version] = appliedAt
+	}
+
+	return versions, rows.Err()
+}
+
+func currentVersion(ctx context.Context, conn *sql.Conn) (int64, error) {
+	var version int64
+	err := conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations;`).Scan(&version)
+
+	return version, err
+}
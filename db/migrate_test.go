@@ -0,0 +1,94 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/halimi/todo-list-service/db"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0002_add_note.up.sql":   {Data: []byte("ALTER TABLE t ADD note TEXT;")},
+		"m/0002_add_note.down.sql": {Data: []byte("ALTER TABLE t DROP note;")},
+		"m/0001_create.up.sql":     {Data: []byte("CREATE TABLE t (id INT);")},
+	}
+
+	got, err := db.LoadMigrations(fsys, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []db.Migration{
+		{Version: 1, Name: "create", Up: "CREATE TABLE t (id INT);"},
+		{Version: 2, Name: "add_note", Up: "ALTER TABLE t ADD note TEXT;", Down: "ALTER TABLE t DROP note;"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Want: %v, Got: %v\n", want[i], got[i])
+		}
+	}
+}
+
+func TestLoadMigrationsInvalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"bad name":   {"m/create.up.sql": {Data: []byte("SELECT 1;")}},
+		"missing up": {"m/0001_create.down.sql": {Data: []byte("SELECT 1;")}},
+	}
+
+	for name, fsys := range tests {
+		if _, err := db.LoadMigrations(fsys, "m"); err == nil {
+			t.Fatalf("%v: want error, got nil", name)
+		}
+	}
+}
+
+func TestMigrateDownUp(t *testing.T) {
+	conn := setupDB()
+	defer conn.Close()
+
+	ctx := context.Background()
+	m, err := db.NewPostgresMigrator(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest := m.Migrations[len(m.Migrations)-1]
+
+	got, err := m.Version(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != latest.Version {
+		t.Fatalf("Want: %v, Got: %v\n", latest.Version, got)
+	}
+
+	rolledBack, err := m.Down(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rolledBack.Version != latest.Version {
+		t.Fatalf("Want: %v, Got: %v\n", latest.Version, rolledBack.Version)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statuses[len(statuses)-1].Applied {
+		t.Fatalf("Want: pending, Got: applied\n")
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 || applied[0].Version != latest.Version {
+		t.Fatalf("Want: %v, Got: %v\n", latest.Version, applied)
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS todo;
+DROP SEQUENCE IF EXISTS todo_id;
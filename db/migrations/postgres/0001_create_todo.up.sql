@@ -0,0 +1,7 @@
+CREATE SEQUENCE IF NOT EXISTS todo_id START 1;
+CREATE TABLE IF NOT EXISTS todo (
+	ID serial PRIMARY KEY,
+	TITLE TEXT NOT NULL,
+	NOTE TEXT,
+	DUE_DATE TIMESTAMP WITH TIME ZONE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE todo
+	DROP COLUMN COMPLETED_AT,
+	DROP COLUMN STATUS;
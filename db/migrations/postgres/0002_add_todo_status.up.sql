@@ -0,0 +1,4 @@
+ALTER TABLE todo
+	ADD COLUMN STATUS TEXT NOT NULL DEFAULT 'OPEN'
+		CHECK (STATUS IN ('OPEN', 'IN_PROGRESS', 'DONE', 'CANCELLED')),
+	ADD COLUMN COMPLETED_AT TIMESTAMP WITH TIME ZONE;
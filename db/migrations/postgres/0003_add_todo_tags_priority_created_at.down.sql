@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS todo_tags_idx;
+DROP INDEX IF EXISTS todo_priority_idx;
+DROP INDEX IF EXISTS todo_title_idx;
+DROP INDEX IF EXISTS todo_created_at_idx;
+DROP INDEX IF EXISTS todo_due_date_idx;
+
+ALTER TABLE todo
+	DROP COLUMN CREATED_AT,
+	DROP COLUMN PRIORITY,
+	DROP COLUMN TAGS;
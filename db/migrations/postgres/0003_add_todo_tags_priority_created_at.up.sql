@@ -0,0 +1,10 @@
+ALTER TABLE todo
+	ADD COLUMN TAGS TEXT[] NOT NULL DEFAULT '{}',
+	ADD COLUMN PRIORITY INTEGER NOT NULL DEFAULT 0,
+	ADD COLUMN CREATED_AT TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+
+CREATE INDEX todo_due_date_idx ON todo (DUE_DATE, ID);
+CREATE INDEX todo_created_at_idx ON todo (CREATED_AT, ID);
+CREATE INDEX todo_title_idx ON todo ((TITLE COLLATE "C"), ID);
+CREATE INDEX todo_priority_idx ON todo (PRIORITY, ID);
+CREATE INDEX todo_tags_idx ON todo USING GIN (TAGS);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS todo_list_id_idx;
+
+ALTER TABLE todo
+	DROP COLUMN LIST_ID;
+
+DROP TABLE IF EXISTS todo_list;
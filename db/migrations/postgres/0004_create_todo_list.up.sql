@@ -0,0 +1,13 @@
+CREATE TABLE todo_list (
+	ID serial PRIMARY KEY,
+	NAME TEXT NOT NULL,
+	DESCRIPTION TEXT NOT NULL DEFAULT '',
+	COLOR TEXT NOT NULL DEFAULT '',
+	CREATED_AT TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- the todos of a deleted list are moved to the inbox, unless they are deleted together with the list
+ALTER TABLE todo
+	ADD COLUMN LIST_ID INTEGER REFERENCES todo_list (ID) ON DELETE SET NULL;
+
+CREATE INDEX todo_list_id_idx ON todo (LIST_ID);
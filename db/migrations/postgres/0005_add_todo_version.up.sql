@@ -0,0 +1,2 @@
+ALTER TABLE todo
+	ADD COLUMN VERSION BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+DROP TRIGGER todo_event_trigger ON todo;
+DROP FUNCTION todo_event_notify();
+DROP TABLE todo_event;
@@ -0,0 +1,42 @@
+-- the changes of the todos are recorded by a trigger and announced on the todo_event channel.
+--
+-- The IDs of the events are taken from the sequence when the rows are inserted, but the rows are
+-- visible only when their transactions commit, so a watcher reading the events after an ID could
+-- skip an event committed later with a lower ID. The writes of the todos are not serialized to
+-- prevent it, every event records the transaction it was inserted by instead. When a watcher finds
+-- a missing ID before a visible event, the transaction holding it was in progress in the snapshot
+-- of the read, so the watcher waits until the transactions of that snapshot finish and reads again.
+-- An ID still missing then belongs to a rolled back transaction and it is skipped.
+CREATE TABLE todo_event (
+	ID BIGSERIAL PRIMARY KEY,
+	TYPE TEXT NOT NULL CHECK (TYPE IN ('CREATED', 'UPDATED', 'DELETED')),
+	TODO JSONB NOT NULL,
+	XID XID8 NOT NULL DEFAULT pg_current_xact_id(),
+	CREATED_AT TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- the watches starting without a resume token are looking up the events of the transactions
+-- in progress when they started
+CREATE INDEX todo_event_xid_idx ON todo_event (XID);
+
+CREATE FUNCTION todo_event_notify() RETURNS trigger AS $$
+DECLARE
+	event_id BIGINT;
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		INSERT INTO todo_event (TYPE, TODO) VALUES ('DELETED', to_jsonb(OLD)) RETURNING ID INTO event_id;
+	ELSIF TG_OP = 'INSERT' THEN
+		INSERT INTO todo_event (TYPE, TODO) VALUES ('CREATED', to_jsonb(NEW)) RETURNING ID INTO event_id;
+	ELSE
+		INSERT INTO todo_event (TYPE, TODO) VALUES ('UPDATED', to_jsonb(NEW)) RETURNING ID INTO event_id;
+	END IF;
+
+	PERFORM pg_notify('todo_event', event_id::TEXT);
+
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_event_trigger
+	AFTER INSERT OR UPDATE OR DELETE ON todo
+	FOR EACH ROW EXECUTE PROCEDURE todo_event_notify();
@@ -0,0 +1,3 @@
+DROP TRIGGER todo_outbox_trigger ON todo;
+DROP FUNCTION todo_outbox_record();
+DROP TABLE outbox;
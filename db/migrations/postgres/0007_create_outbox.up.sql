@@ -0,0 +1,26 @@
+-- the changes of the todos are recorded in the outbox by a trigger in the same transaction as the change,
+-- the events are removed by the relay when they are published
+CREATE TABLE outbox (
+	ID BIGSERIAL PRIMARY KEY,
+	TYPE TEXT NOT NULL CHECK (TYPE IN ('CREATED', 'UPDATED', 'DELETED')),
+	TODO JSONB NOT NULL,
+	CREATED_AT TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE FUNCTION todo_outbox_record() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		INSERT INTO outbox (TYPE, TODO) VALUES ('DELETED', to_jsonb(OLD));
+	ELSIF TG_OP = 'INSERT' THEN
+		INSERT INTO outbox (TYPE, TODO) VALUES ('CREATED', to_jsonb(NEW));
+	ELSE
+		INSERT INTO outbox (TYPE, TODO) VALUES ('UPDATED', to_jsonb(NEW));
+	END IF;
+
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_outbox_trigger
+	AFTER INSERT OR UPDATE OR DELETE ON todo
+	FOR EACH ROW EXECUTE PROCEDURE todo_outbox_record();
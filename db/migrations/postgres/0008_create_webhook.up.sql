@@ -0,0 +1,24 @@
+CREATE TABLE webhook (
+	ID serial PRIMARY KEY,
+	URL TEXT NOT NULL,
+	EVENT_TYPES TEXT[] NOT NULL DEFAULT '{}',
+	SECRET TEXT NOT NULL,
+	DISABLED BOOLEAN NOT NULL DEFAULT false,
+	FAILURE_COUNT INTEGER NOT NULL DEFAULT 0,
+	CREATED_AT TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- every attempt of a delivery is recorded, they are deleted together with the webhook
+CREATE TABLE webhook_delivery (
+	ID BIGSERIAL PRIMARY KEY,
+	WEBHOOK_ID INTEGER NOT NULL REFERENCES webhook (ID) ON DELETE CASCADE,
+	EVENT_ID BIGINT NOT NULL,
+	EVENT_TYPE TEXT NOT NULL,
+	ATTEMPT INTEGER NOT NULL,
+	STATUS_CODE INTEGER NOT NULL DEFAULT 0,
+	ERROR TEXT NOT NULL DEFAULT '',
+	SUCCESS BOOLEAN NOT NULL,
+	ATTEMPTED_AT TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhook_delivery_webhook_id_idx ON webhook_delivery (WEBHOOK_ID, ID);
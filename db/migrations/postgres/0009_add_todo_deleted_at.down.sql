@@ -0,0 +1,39 @@
+-- the todos of the trash are purged before the triggers record the deletions again
+DELETE FROM todo WHERE DELETED_AT IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION todo_event_notify() RETURNS trigger AS $$
+DECLARE
+	event_id BIGINT;
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		INSERT INTO todo_event (TYPE, TODO) VALUES ('DELETED', to_jsonb(OLD)) RETURNING ID INTO event_id;
+	ELSIF TG_OP = 'INSERT' THEN
+		INSERT INTO todo_event (TYPE, TODO) VALUES ('CREATED', to_jsonb(NEW)) RETURNING ID INTO event_id;
+	ELSE
+		INSERT INTO todo_event (TYPE, TODO) VALUES ('UPDATED', to_jsonb(NEW)) RETURNING ID INTO event_id;
+	END IF;
+
+	PERFORM pg_notify('todo_event', event_id::TEXT);
+
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION todo_outbox_record() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		INSERT INTO outbox (TYPE, TODO) VALUES ('DELETED', to_jsonb(OLD));
+	ELSIF TG_OP = 'INSERT' THEN
+		INSERT INTO outbox (TYPE, TODO) VALUES ('CREATED', to_jsonb(NEW));
+	ELSE
+		INSERT INTO outbox (TYPE, TODO) VALUES ('UPDATED', to_jsonb(NEW));
+	END IF;
+
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX todo_deleted_at_idx;
+
+ALTER TABLE todo
+	DROP COLUMN DELETED_AT;
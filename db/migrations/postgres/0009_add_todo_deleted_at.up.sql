@@ -0,0 +1,63 @@
+-- the deleted todos are kept in the trash until they are restored or purged
+ALTER TABLE todo
+	ADD COLUMN DELETED_AT TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX todo_deleted_at_idx ON todo (DELETED_AT) WHERE DELETED_AT IS NOT NULL;
+
+-- moving a todo to the trash is recorded as a deletion and restoring it as a creation,
+-- the changes of the todos in the trash and their purge are not recorded
+CREATE OR REPLACE FUNCTION todo_event_notify() RETURNS trigger AS $$
+DECLARE
+	event_id BIGINT;
+	event_type TEXT;
+	event_todo JSONB;
+BEGIN
+	IF TG_OP = 'INSERT' THEN
+		event_type := 'CREATED';
+		event_todo := to_jsonb(NEW);
+	ELSIF TG_OP = 'DELETE' THEN
+		IF OLD.DELETED_AT IS NOT NULL THEN
+			RETURN NULL;
+		END IF;
+		event_type := 'DELETED';
+		event_todo := to_jsonb(OLD);
+	ELSIF OLD.DELETED_AT IS NULL AND NEW.DELETED_AT IS NOT NULL THEN
+		event_type := 'DELETED';
+		event_todo := to_jsonb(NEW);
+	ELSIF OLD.DELETED_AT IS NOT NULL AND NEW.DELETED_AT IS NULL THEN
+		event_type := 'CREATED';
+		event_todo := to_jsonb(NEW);
+	ELSIF NEW.DELETED_AT IS NOT NULL THEN
+		RETURN NULL;
+	ELSE
+		event_type := 'UPDATED';
+		event_todo := to_jsonb(NEW);
+	END IF;
+
+	INSERT INTO todo_event (TYPE, TODO) VALUES (event_type, event_todo) RETURNING ID INTO event_id;
+
+	PERFORM pg_notify('todo_event', event_id::TEXT);
+
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION todo_outbox_record() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'INSERT' THEN
+		INSERT INTO outbox (TYPE, TODO) VALUES ('CREATED', to_jsonb(NEW));
+	ELSIF TG_OP = 'DELETE' THEN
+		IF OLD.DELETED_AT IS NULL THEN
+			INSERT INTO outbox (TYPE, TODO) VALUES ('DELETED', to_jsonb(OLD));
+		END IF;
+	ELSIF OLD.DELETED_AT IS NULL AND NEW.DELETED_AT IS NOT NULL THEN
+		INSERT INTO outbox (TYPE, TODO) VALUES ('DELETED', to_jsonb(NEW));
+	ELSIF OLD.DELETED_AT IS NOT NULL AND NEW.DELETED_AT IS NULL THEN
+		INSERT INTO outbox (TYPE, TODO) VALUES ('CREATED', to_jsonb(NEW));
+	ELSIF NEW.DELETED_AT IS NULL THEN
+		INSERT INTO outbox (TYPE, TODO) VALUES ('UPDATED', to_jsonb(NEW));
+	END IF;
+
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
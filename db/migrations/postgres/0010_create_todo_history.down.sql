@@ -0,0 +1,3 @@
+DROP TRIGGER todo_history_trigger ON todo;
+DROP FUNCTION todo_history_record();
+DROP TABLE todo_history;
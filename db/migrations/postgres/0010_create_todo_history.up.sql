@@ -0,0 +1,47 @@
+-- every change of the todos is recorded by a trigger in the same transaction as the change, the actor
+-- and the request id are read from the todo.actor and todo.request_id settings of the transaction
+CREATE TABLE todo_history (
+	ID BIGSERIAL PRIMARY KEY,
+	TODO_ID INTEGER NOT NULL REFERENCES todo (ID) ON DELETE CASCADE,
+	ACTION TEXT NOT NULL CHECK (ACTION IN ('CREATED', 'UPDATED', 'DELETED', 'RESTORED')),
+	TODO_BEFORE JSONB,
+	TODO_AFTER JSONB NOT NULL,
+	ACTOR TEXT NOT NULL DEFAULT '',
+	REQUEST_ID TEXT NOT NULL DEFAULT '',
+	CHANGED_AT TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX todo_history_todo_id_idx ON todo_history (TODO_ID, ID);
+
+CREATE FUNCTION todo_history_record() RETURNS trigger AS $$
+DECLARE
+	history_action TEXT;
+	todo_before JSONB;
+BEGIN
+	IF TG_OP = 'INSERT' THEN
+		history_action := 'CREATED';
+	ELSE
+		todo_before := to_jsonb(OLD);
+		IF OLD.DELETED_AT IS NULL AND NEW.DELETED_AT IS NOT NULL THEN
+			history_action := 'DELETED';
+		ELSIF OLD.DELETED_AT IS NOT NULL AND NEW.DELETED_AT IS NULL THEN
+			history_action := 'RESTORED';
+		ELSE
+			history_action := 'UPDATED';
+		END IF;
+	END IF;
+
+	INSERT INTO todo_history (TODO_ID, ACTION, TODO_BEFORE, TODO_AFTER, ACTOR, REQUEST_ID)
+	VALUES (
+		NEW.ID, history_action, todo_before, to_jsonb(NEW),
+		COALESCE(current_setting('todo.actor', true), ''),
+		COALESCE(current_setting('todo.request_id', true), '')
+	);
+
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_history_trigger
+	AFTER INSERT OR UPDATE ON todo
+	FOR EACH ROW EXECUTE PROCEDURE todo_history_record();
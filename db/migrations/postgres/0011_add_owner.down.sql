@@ -0,0 +1,16 @@
+ALTER TABLE todo_list NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE todo_list DISABLE ROW LEVEL SECURITY;
+ALTER TABLE todo NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE todo DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY todo_list_owner_policy ON todo_list;
+DROP POLICY todo_owner_policy ON todo;
+
+DROP INDEX todo_list_owner_idx;
+DROP INDEX todo_owner_idx;
+
+ALTER TABLE todo_list
+	DROP COLUMN OWNER;
+
+ALTER TABLE todo
+	DROP COLUMN OWNER;
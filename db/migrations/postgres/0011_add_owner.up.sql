@@ -0,0 +1,19 @@
+-- the todos and the lists are owned by the subject of the principal creating them, the rows
+-- created without authentication are owned by the empty owner
+ALTER TABLE todo
+	ADD COLUMN OWNER TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE todo_list
+	ADD COLUMN OWNER TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX todo_owner_idx ON todo (OWNER, ID);
+CREATE INDEX todo_list_owner_idx ON todo_list (OWNER, ID);
+
+-- the policies are only applied when the row-level security is enabled on the tables, the owner
+-- is read from the todo.owner setting of the transaction and todo.all_owners is set by the
+-- background jobs working with the rows of every owner
+CREATE POLICY todo_owner_policy ON todo
+	USING (current_setting('todo.all_owners', true) = 'true' OR OWNER = current_setting('todo.owner', true));
+
+CREATE POLICY todo_list_owner_policy ON todo_list
+	USING (current_setting('todo.all_owners', true) = 'true' OR OWNER = current_setting('todo.owner', true));
@@ -0,0 +1,10 @@
+-- the members of the shared lists, they are deleted together with the list, the owner of the list is not a member
+CREATE TABLE list_member (
+	LIST_ID INTEGER NOT NULL REFERENCES todo_list (ID) ON DELETE CASCADE,
+	SUBJECT TEXT NOT NULL,
+	ROLE TEXT NOT NULL CHECK (ROLE IN ('VIEWER', 'EDITOR', 'OWNER')),
+	CREATED_AT TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+	PRIMARY KEY (LIST_ID, SUBJECT)
+);
+
+CREATE INDEX list_member_subject_idx ON list_member (SUBJECT, LIST_ID);
@@ -0,0 +1,4 @@
+DROP INDEX webhook_owner_idx;
+
+ALTER TABLE webhook
+	DROP COLUMN OWNER;
@@ -0,0 +1,6 @@
+-- the webhooks are owned by the subject of the principal creating them like the todos and the lists,
+-- the webhooks created before are owned by the empty owner
+ALTER TABLE webhook
+	ADD COLUMN OWNER TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX webhook_owner_idx ON webhook (OWNER, ID);
@@ -0,0 +1 @@
+DROP INDEX todo_event_created_at_idx;
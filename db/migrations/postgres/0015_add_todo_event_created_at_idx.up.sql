@@ -0,0 +1,2 @@
+-- the old events are pruned by their time, the watches can not be resumed from the pruned events
+CREATE INDEX todo_event_created_at_idx ON todo_event (CREATED_AT);
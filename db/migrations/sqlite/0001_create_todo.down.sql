@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS todo;
+DROP TABLE IF EXISTS todo_list;
@@ -0,0 +1,28 @@
+-- the timestamps are stored as microseconds since the Unix epoch, the tags as a JSON array
+CREATE TABLE todo_list (
+	ID INTEGER PRIMARY KEY AUTOINCREMENT,
+	NAME TEXT NOT NULL,
+	DESCRIPTION TEXT NOT NULL DEFAULT '',
+	COLOR TEXT NOT NULL DEFAULT '',
+	CREATED_AT INTEGER NOT NULL
+);
+
+CREATE TABLE todo (
+	ID INTEGER PRIMARY KEY AUTOINCREMENT,
+	TITLE TEXT NOT NULL,
+	NOTE TEXT,
+	DUE_DATE INTEGER,
+	STATUS TEXT NOT NULL DEFAULT 'OPEN' CHECK (STATUS IN ('OPEN', 'IN_PROGRESS', 'DONE', 'CANCELLED')),
+	COMPLETED_AT INTEGER,
+	TAGS TEXT NOT NULL DEFAULT '[]',
+	PRIORITY INTEGER NOT NULL DEFAULT 0,
+	CREATED_AT INTEGER NOT NULL,
+	LIST_ID INTEGER REFERENCES todo_list (ID) ON DELETE SET NULL,
+	VERSION INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE INDEX todo_due_date_idx ON todo (DUE_DATE, ID);
+CREATE INDEX todo_created_at_idx ON todo (CREATED_AT, ID);
+CREATE INDEX todo_title_idx ON todo (TITLE, ID);
+CREATE INDEX todo_priority_idx ON todo (PRIORITY, ID);
+CREATE INDEX todo_list_id_idx ON todo (LIST_ID);
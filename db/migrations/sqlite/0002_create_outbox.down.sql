@@ -0,0 +1,4 @@
+DROP TRIGGER todo_outbox_insert;
+DROP TRIGGER todo_outbox_update;
+DROP TRIGGER todo_outbox_delete;
+DROP TABLE outbox;
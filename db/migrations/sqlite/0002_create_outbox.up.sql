@@ -0,0 +1,38 @@
+-- the changes of the todos are recorded in the outbox by triggers in the same transaction as the change,
+-- the events are removed by the relay when they are published
+CREATE TABLE outbox (
+	ID INTEGER PRIMARY KEY AUTOINCREMENT,
+	TYPE TEXT NOT NULL CHECK (TYPE IN ('CREATED', 'UPDATED', 'DELETED')),
+	TODO TEXT NOT NULL,
+	CREATED_AT INTEGER NOT NULL
+);
+
+CREATE TRIGGER todo_outbox_insert AFTER INSERT ON todo
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('CREATED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_update AFTER UPDATE ON todo
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('UPDATED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_delete AFTER DELETE ON todo
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('DELETED', json_object(
+		'id', OLD.ID, 'title', OLD.TITLE, 'note', OLD.NOTE, 'due_date', OLD.DUE_DATE,
+		'status', OLD.STATUS, 'completed_at', OLD.COMPLETED_AT, 'tags', json(OLD.TAGS),
+		'priority', OLD.PRIORITY, 'created_at', OLD.CREATED_AT, 'list_id', OLD.LIST_ID, 'version', OLD.VERSION
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
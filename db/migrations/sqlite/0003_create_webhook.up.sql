@@ -0,0 +1,25 @@
+-- the event types are stored as a JSON array
+CREATE TABLE webhook (
+	ID INTEGER PRIMARY KEY AUTOINCREMENT,
+	URL TEXT NOT NULL,
+	EVENT_TYPES TEXT NOT NULL DEFAULT '[]',
+	SECRET TEXT NOT NULL,
+	DISABLED INTEGER NOT NULL DEFAULT 0,
+	FAILURE_COUNT INTEGER NOT NULL DEFAULT 0,
+	CREATED_AT INTEGER NOT NULL
+);
+
+-- every attempt of a delivery is recorded, they are deleted together with the webhook
+CREATE TABLE webhook_delivery (
+	ID INTEGER PRIMARY KEY AUTOINCREMENT,
+	WEBHOOK_ID INTEGER NOT NULL REFERENCES webhook (ID) ON DELETE CASCADE,
+	EVENT_ID INTEGER NOT NULL,
+	EVENT_TYPE TEXT NOT NULL,
+	ATTEMPT INTEGER NOT NULL,
+	STATUS_CODE INTEGER NOT NULL DEFAULT 0,
+	ERROR TEXT NOT NULL DEFAULT '',
+	SUCCESS INTEGER NOT NULL,
+	ATTEMPTED_AT INTEGER NOT NULL
+);
+
+CREATE INDEX webhook_delivery_webhook_id_idx ON webhook_delivery (WEBHOOK_ID, ID);
@@ -0,0 +1,42 @@
+-- the todos of the trash are purged before the triggers record the deletions again
+DELETE FROM todo WHERE DELETED_AT IS NOT NULL;
+
+DROP TRIGGER todo_outbox_insert;
+DROP TRIGGER todo_outbox_update;
+DROP TRIGGER todo_outbox_trash;
+DROP TRIGGER todo_outbox_restore;
+DROP TRIGGER todo_outbox_delete;
+
+CREATE TRIGGER todo_outbox_insert AFTER INSERT ON todo
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('CREATED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_update AFTER UPDATE ON todo
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('UPDATED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_delete AFTER DELETE ON todo
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('DELETED', json_object(
+		'id', OLD.ID, 'title', OLD.TITLE, 'note', OLD.NOTE, 'due_date', OLD.DUE_DATE,
+		'status', OLD.STATUS, 'completed_at', OLD.COMPLETED_AT, 'tags', json(OLD.TAGS),
+		'priority', OLD.PRIORITY, 'created_at', OLD.CREATED_AT, 'list_id', OLD.LIST_ID, 'version', OLD.VERSION
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+DROP INDEX todo_deleted_at_idx;
+
+ALTER TABLE todo DROP COLUMN DELETED_AT;
@@ -0,0 +1,69 @@
+-- the deleted todos are kept in the trash until they are restored or purged
+ALTER TABLE todo ADD COLUMN DELETED_AT INTEGER;
+
+CREATE INDEX todo_deleted_at_idx ON todo (DELETED_AT) WHERE DELETED_AT IS NOT NULL;
+
+-- moving a todo to the trash is recorded as a deletion and restoring it as a creation,
+-- the changes of the todos in the trash and their purge are not recorded
+DROP TRIGGER todo_outbox_insert;
+DROP TRIGGER todo_outbox_update;
+DROP TRIGGER todo_outbox_delete;
+
+CREATE TRIGGER todo_outbox_insert AFTER INSERT ON todo
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('CREATED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_update AFTER UPDATE ON todo
+	WHEN OLD.DELETED_AT IS NULL AND NEW.DELETED_AT IS NULL
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('UPDATED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_trash AFTER UPDATE ON todo
+	WHEN OLD.DELETED_AT IS NULL AND NEW.DELETED_AT IS NOT NULL
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('DELETED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_restore AFTER UPDATE ON todo
+	WHEN OLD.DELETED_AT IS NOT NULL AND NEW.DELETED_AT IS NULL
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('CREATED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_delete AFTER DELETE ON todo
+	WHEN OLD.DELETED_AT IS NULL
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('DELETED', json_object(
+		'id', OLD.ID, 'title', OLD.TITLE, 'note', OLD.NOTE, 'due_date', OLD.DUE_DATE,
+		'status', OLD.STATUS, 'completed_at', OLD.COMPLETED_AT, 'tags', json(OLD.TAGS),
+		'priority', OLD.PRIORITY, 'created_at', OLD.CREATED_AT, 'list_id', OLD.LIST_ID, 'version', OLD.VERSION,
+		'deleted_at', OLD.DELETED_AT
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
@@ -0,0 +1,4 @@
+DROP TRIGGER todo_history_insert;
+DROP TRIGGER todo_history_update;
+DROP TABLE todo_history;
+DROP TABLE audit_context;
@@ -0,0 +1,57 @@
+-- every change of the todos is recorded by a trigger in the same transaction as the change, the actor
+-- and the request id are read from the audit_context row written at the start of the transaction
+CREATE TABLE audit_context (
+	ID INTEGER PRIMARY KEY CHECK (ID = 1),
+	ACTOR TEXT NOT NULL DEFAULT '',
+	REQUEST_ID TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE todo_history (
+	ID INTEGER PRIMARY KEY AUTOINCREMENT,
+	TODO_ID INTEGER NOT NULL REFERENCES todo (ID) ON DELETE CASCADE,
+	ACTION TEXT NOT NULL CHECK (ACTION IN ('CREATED', 'UPDATED', 'DELETED', 'RESTORED')),
+	TODO_BEFORE TEXT,
+	TODO_AFTER TEXT NOT NULL,
+	ACTOR TEXT NOT NULL DEFAULT '',
+	REQUEST_ID TEXT NOT NULL DEFAULT '',
+	CHANGED_AT INTEGER NOT NULL
+);
+
+CREATE INDEX todo_history_todo_id_idx ON todo_history (TODO_ID, ID);
+
+CREATE TRIGGER todo_history_insert AFTER INSERT ON todo
+BEGIN
+	INSERT INTO todo_history (TODO_ID, ACTION, TODO_BEFORE, TODO_AFTER, ACTOR, REQUEST_ID, CHANGED_AT)
+	VALUES (NEW.ID, 'CREATED', NULL, json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT
+	),
+		COALESCE((SELECT ACTOR FROM audit_context WHERE ID = 1), ''),
+		COALESCE((SELECT REQUEST_ID FROM audit_context WHERE ID = 1), ''),
+		CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_history_update AFTER UPDATE ON todo
+BEGIN
+	INSERT INTO todo_history (TODO_ID, ACTION, TODO_BEFORE, TODO_AFTER, ACTOR, REQUEST_ID, CHANGED_AT)
+	VALUES (NEW.ID, CASE
+		WHEN OLD.DELETED_AT IS NULL AND NEW.DELETED_AT IS NOT NULL THEN 'DELETED'
+		WHEN OLD.DELETED_AT IS NOT NULL AND NEW.DELETED_AT IS NULL THEN 'RESTORED'
+		ELSE 'UPDATED'
+	END, json_object(
+		'id', OLD.ID, 'title', OLD.TITLE, 'note', OLD.NOTE, 'due_date', OLD.DUE_DATE,
+		'status', OLD.STATUS, 'completed_at', OLD.COMPLETED_AT, 'tags', json(OLD.TAGS),
+		'priority', OLD.PRIORITY, 'created_at', OLD.CREATED_AT, 'list_id', OLD.LIST_ID, 'version', OLD.VERSION,
+		'deleted_at', OLD.DELETED_AT
+	), json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT
+	),
+		COALESCE((SELECT ACTOR FROM audit_context WHERE ID = 1), ''),
+		COALESCE((SELECT REQUEST_ID FROM audit_context WHERE ID = 1), ''),
+		CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
@@ -0,0 +1,112 @@
+-- the todos and the lists are owned by the subject of the principal creating them, the rows
+-- created without authentication are owned by the empty owner
+ALTER TABLE todo ADD COLUMN OWNER TEXT NOT NULL DEFAULT '';
+ALTER TABLE todo_list ADD COLUMN OWNER TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX todo_owner_idx ON todo (OWNER, ID);
+CREATE INDEX todo_list_owner_idx ON todo_list (OWNER, ID);
+
+-- the owner is recorded in the events and the history of the todos
+DROP TRIGGER todo_outbox_insert;
+DROP TRIGGER todo_outbox_update;
+DROP TRIGGER todo_outbox_trash;
+DROP TRIGGER todo_outbox_restore;
+DROP TRIGGER todo_outbox_delete;
+DROP TRIGGER todo_history_insert;
+DROP TRIGGER todo_history_update;
+
+CREATE TRIGGER todo_outbox_insert AFTER INSERT ON todo
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('CREATED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT, 'owner', NEW.OWNER
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_update AFTER UPDATE ON todo
+	WHEN OLD.DELETED_AT IS NULL AND NEW.DELETED_AT IS NULL
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('UPDATED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT, 'owner', NEW.OWNER
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_trash AFTER UPDATE ON todo
+	WHEN OLD.DELETED_AT IS NULL AND NEW.DELETED_AT IS NOT NULL
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('DELETED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT, 'owner', NEW.OWNER
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_restore AFTER UPDATE ON todo
+	WHEN OLD.DELETED_AT IS NOT NULL AND NEW.DELETED_AT IS NULL
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('CREATED', json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT, 'owner', NEW.OWNER
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_outbox_delete AFTER DELETE ON todo
+	WHEN OLD.DELETED_AT IS NULL
+BEGIN
+	INSERT INTO outbox (TYPE, TODO, CREATED_AT)
+	VALUES ('DELETED', json_object(
+		'id', OLD.ID, 'title', OLD.TITLE, 'note', OLD.NOTE, 'due_date', OLD.DUE_DATE,
+		'status', OLD.STATUS, 'completed_at', OLD.COMPLETED_AT, 'tags', json(OLD.TAGS),
+		'priority', OLD.PRIORITY, 'created_at', OLD.CREATED_AT, 'list_id', OLD.LIST_ID, 'version', OLD.VERSION,
+		'deleted_at', OLD.DELETED_AT, 'owner', OLD.OWNER
+	), CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_history_insert AFTER INSERT ON todo
+BEGIN
+	INSERT INTO todo_history (TODO_ID, ACTION, TODO_BEFORE, TODO_AFTER, ACTOR, REQUEST_ID, CHANGED_AT)
+	VALUES (NEW.ID, 'CREATED', NULL, json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT, 'owner', NEW.OWNER
+	),
+		COALESCE((SELECT ACTOR FROM audit_context WHERE ID = 1), ''),
+		COALESCE((SELECT REQUEST_ID FROM audit_context WHERE ID = 1), ''),
+		CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
+
+CREATE TRIGGER todo_history_update AFTER UPDATE ON todo
+BEGIN
+	INSERT INTO todo_history (TODO_ID, ACTION, TODO_BEFORE, TODO_AFTER, ACTOR, REQUEST_ID, CHANGED_AT)
+	VALUES (NEW.ID, CASE
+		WHEN OLD.DELETED_AT IS NULL AND NEW.DELETED_AT IS NOT NULL THEN 'DELETED'
+		WHEN OLD.DELETED_AT IS NOT NULL AND NEW.DELETED_AT IS NULL THEN 'RESTORED'
+		ELSE 'UPDATED'
+	END, json_object(
+		'id', OLD.ID, 'title', OLD.TITLE, 'note', OLD.NOTE, 'due_date', OLD.DUE_DATE,
+		'status', OLD.STATUS, 'completed_at', OLD.COMPLETED_AT, 'tags', json(OLD.TAGS),
+		'priority', OLD.PRIORITY, 'created_at', OLD.CREATED_AT, 'list_id', OLD.LIST_ID, 'version', OLD.VERSION,
+		'deleted_at', OLD.DELETED_AT, 'owner', OLD.OWNER
+	), json_object(
+		'id', NEW.ID, 'title', NEW.TITLE, 'note', NEW.NOTE, 'due_date', NEW.DUE_DATE,
+		'status', NEW.STATUS, 'completed_at', NEW.COMPLETED_AT, 'tags', json(NEW.TAGS),
+		'priority', NEW.PRIORITY, 'created_at', NEW.CREATED_AT, 'list_id', NEW.LIST_ID, 'version', NEW.VERSION,
+		'deleted_at', NEW.DELETED_AT, 'owner', NEW.OWNER
+	),
+		COALESCE((SELECT ACTOR FROM audit_context WHERE ID = 1), ''),
+		COALESCE((SELECT REQUEST_ID FROM audit_context WHERE ID = 1), ''),
+		CAST(unixepoch('subsec') * 1000000 AS INTEGER));
+END;
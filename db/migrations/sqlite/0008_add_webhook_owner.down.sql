@@ -0,0 +1,3 @@
+DROP INDEX webhook_owner_idx;
+
+ALTER TABLE webhook DROP COLUMN OWNER;
@@ -0,0 +1,15 @@
+-- the messages of the events are queued to the webhooks by the relay of the outbox and they are
+-- delivered by the dispatcher outside of the transactions, the oldest message of a webhook is
+-- delivered first. An event is queued to a webhook only once, the todo.due events have no ID.
+CREATE TABLE webhook_queue (
+	ID INTEGER PRIMARY KEY AUTOINCREMENT,
+	WEBHOOK_ID INTEGER NOT NULL REFERENCES webhook (ID) ON DELETE CASCADE,
+	EVENT_ID INTEGER NOT NULL,
+	EVENT_TYPE TEXT NOT NULL,
+	BODY BLOB NOT NULL,
+	ATTEMPT INTEGER NOT NULL DEFAULT 0,
+	NEXT_ATTEMPT_AT INTEGER NOT NULL
+);
+
+CREATE INDEX webhook_queue_webhook_id_idx ON webhook_queue (WEBHOOK_ID, ID);
+CREATE UNIQUE INDEX webhook_queue_event_idx ON webhook_queue (WEBHOOK_ID, EVENT_ID) WHERE EVENT_ID <> 0;
@@ -1,10 +1,12 @@
 package db
 
 import (
+	"context"
 	"log"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/halimi/todo-list-service/todolistpb"
 )
 
@@ -18,34 +20,113 @@ func (m *MockDB) Close() error {
 }
 
 // Insert is inserting the data to the database
-func (m *MockDB) Insert(todo *todolistpb.Todo) (int32, error) {
+func (m *MockDB) Insert(ctx context.Context, todo *todolistpb.Todo) (int32, error) {
 	id := todo.GetId() + 1
 	return id, nil
 }
 
 // Get is getting the data from the database
-func (m *MockDB) Get(id int32) (*todolistpb.Todo, error) {
+func (m *MockDB) Get(ctx context.Context, id int32) (*todolistpb.Todo, error) {
 	return getTestTodo(id, "Test Todo"), nil
 }
 
-// Update is updating the data in the database
-func (m *MockDB) Update(todo *todolistpb.Todo) (*todolistpb.Todo, error) {
-	return todo, nil
+// Update is updating the fields of the paths in the database
+func (m *MockDB) Update(ctx context.Context, todo *todolistpb.Todo, paths []string, expectedVersion int64) (*todolistpb.Todo, error) {
+	t := getTestTodo(todo.GetId(), "Test Todo")
+	if expectedVersion != 0 && expectedVersion != t.GetVersion() {
+		return &todolistpb.Todo{}, ErrVersionMismatch
+	}
+
+	mergeTodo(t, todo, paths)
+	t.Version++
+	return t, nil
 }
 
 // Delete is deleting the data from the database
-func (m *MockDB) Delete(id int32) (int64, error) {
+func (m *MockDB) Delete(ctx context.Context, id int32, expectedVersion int64) (int64, error) {
 	return 0, nil
 }
 
-// List is listing the data
-func (m *MockDB) List() ([]*todolistpb.Todo, error) {
+// List is listing one page of the data
+func (m *MockDB) List(ctx context.Context, req *todolistpb.ListTodosRequest) ([]*todolistpb.Todo, string, error) {
 	var tl []*todolistpb.Todo
 	tl = append(tl, getTestTodo(1, "Test Todo"))
 	tl = append(tl, getTestTodo(2, "Test Todo"))
+	return listTodos(req, tl)
+}
+
+// Complete is setting the todo to done
+func (m *MockDB) Complete(ctx context.Context, id int32, completedAt *timestamp.Timestamp) (*todolistpb.Todo, error) {
+	todo := getTestTodo(id, "Test Todo")
+	todo.Status = todolistpb.Status_DONE
+	todo.CompletedAt = completedAt
+	todo.Version++
+	return todo, nil
+}
+
+// Reopen is setting the todo to open
+func (m *MockDB) Reopen(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	todo := getTestTodo(id, "Test Todo")
+	todo.Version++
+	return todo, nil
+}
+
+// Restore is moving the todo back from the trash
+func (m *MockDB) Restore(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	return getTestTodo(id, "Test Todo"), nil
+}
+
+// GetTrashed is getting the todo from the trash
+func (m *MockDB) GetTrashed(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	return getTestTodo(id, "Test Todo"), nil
+}
+
+// ListTrash is listing the todos of the trash
+func (m *MockDB) ListTrash(ctx context.Context) ([]*todolistpb.Todo, error) {
+	var tl []*todolistpb.Todo
+	tl = append(tl, getTestTodo(1, "Test Todo"))
 	return tl, nil
 }
 
+// CountTodos is counting the open todos
+func (m *MockDB) CountTodos(ctx context.Context, dueBefore *timestamp.Timestamp) (int64, int64, error) {
+	return 1, 0, nil
+}
+
+// Purge is deleting the todos of the trash permanently
+func (m *MockDB) Purge(ctx context.Context, deletedBefore *timestamp.Timestamp) (int64, error) {
+	return 1, nil
+}
+
+// InsertTodoList is inserting the list to the database
+func (m *MockDB) InsertTodoList(ctx context.Context, list *todolistpb.TodoList) (int32, error) {
+	id := list.GetId() + 1
+	return id, nil
+}
+
+// GetTodoList is getting the list from the database
+func (m *MockDB) GetTodoList(ctx context.Context, id int32) (*todolistpb.TodoList, error) {
+	return getTestTodoList(id, "Test List"), nil
+}
+
+// UpdateTodoList is updating the list in the database
+func (m *MockDB) UpdateTodoList(ctx context.Context, list *todolistpb.TodoList) (*todolistpb.TodoList, error) {
+	return list, nil
+}
+
+// DeleteTodoList is deleting the list from the database
+func (m *MockDB) DeleteTodoList(ctx context.Context, id int32, deleteTodos bool) (int64, error) {
+	return 0, nil
+}
+
+// ListTodoLists is listing the lists
+func (m *MockDB) ListTodoLists(ctx context.Context) ([]*todolistpb.TodoList, error) {
+	var ll []*todolistpb.TodoList
+	ll = append(ll, getTestTodoList(1, "Test List"))
+	ll = append(ll, getTestTodoList(2, "Test List"))
+	return ll, nil
+}
+
 func getTestTodo(id int32, title string) *todolistpb.Todo {
 	dd, err := ptypes.TimestampProto(time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local))
 	if err != nil {
@@ -57,5 +138,21 @@ func getTestTodo(id int32, title string) *todolistpb.Todo {
 		Title:   title,
 		Note:    "This is a test",
 		DueDate: dd,
+		Version: 1,
+	}
+}
+
+func getTestTodoList(id int32, name string) *todolistpb.TodoList {
+	ca, err := ptypes.TimestampProto(time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		log.Fatalf("Could not convert timestamp: %v", err)
+	}
+
+	return &todolistpb.TodoList{
+		Id:          id,
+		Name:        name,
+		Description: "This is a test",
+		Color:       "#ffffff",
+		CreatedAt:   ca,
 	}
 }
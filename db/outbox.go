@@ -0,0 +1,25 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// OutboxEvent is a change of a todo, it is recorded in the outbox in the same transaction as the change
+type OutboxEvent struct {
+	ID         int64
+	Type       todolistpb.WatchTodosResponse_EventType
+	Todo       *todolistpb.Todo
+	OccurredAt time.Time
+}
+
+// Outbox is implemented by the repositories recording the changes of the todos in an outbox
+type Outbox interface {
+	// ProcessOutbox is calling fn with the oldest events of the outbox in the order of the changes,
+	// at most limit events. It stops at the first error of fn, the events are removed from the
+	// outbox only if fn returned without error for them. It returns with the number of the
+	// removed events.
+	ProcessOutbox(ctx context.Context, limit int, fn func(*OutboxEvent) error) (int, error)
+}
@@ -1,28 +1,22 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/halimi/todo-list-service/todolistpb"
-	_ "github.com/lib/pq" // Init pq library
+	"github.com/lib/pq"
 )
 
-const createTable = `
-DROP SEQUENCE IF EXISTS todo_id;
-DROP TABLE IF EXISTS todo;
-CREATE SEQUENCE todo_id START 1;
-CREATE TABLE todo (
-	ID serial PRIMARY KEY,
-	TITLE TEXT NOT NULL,
-	NOTE TEXT,
-	DUE_DATE TIMESTAMP WITH TIME ZONE
-);
-`
-
 // PostgresConfig holds the configs
 type PostgresConfig struct {
 	User     string
@@ -34,18 +28,47 @@ type PostgresConfig struct {
 // Postgres sql interface
 type Postgres struct {
 	DB *sql.DB
+	// Listener is waking up the watchers on the notifications of the todo_event channel,
+	// without it the watchers are polling the events
+	Listener *pq.Listener
+	// RowLevelSecurity is running every query in a transaction with the owner settings of the
+	// row-level security policies, it has to be set if the policies are enforced
+	RowLevelSecurity bool
+
+	listenOnce sync.Once
+	wake       notifier
 }
 
+const (
+	// todoEventChannel is the channel of the notifications about the new todo events
+	todoEventChannel = "todo_event"
+	// watchBatchSize is the maximum number of the events read by a watcher at once
+	watchBatchSize = 100
+	// watchPollInterval is the interval of reading the events without notifications
+	watchPollInterval = time.Second
+	// watchListenPollInterval is the interval of reading the events with notifications,
+	// in case a notification was lost while the listener was reconnecting
+	watchListenPollInterval = 30 * time.Second
+	// watchGapPollInterval is the interval of checking if the transactions of the missing events finished
+	watchGapPollInterval = 10 * time.Millisecond
+)
+
 // Close is closing the database connection
 func (p *Postgres) Close() error {
+	if p.Listener != nil {
+		if err := p.Listener.Close(); err != nil {
+			return err
+		}
+	}
+
 	return p.DB.Close()
 }
 
 // Insert is inserting the data to the database
-func (p *Postgres) Insert(todo *todolistpb.Todo) (int32, error) {
+func (p *Postgres) Insert(ctx context.Context, todo *todolistpb.Todo) (int32, error) {
 	query := `
-	INSERT INTO todo (id, title, note, due_date)
-	VALUES (nextval('todo_id'), $1, $2, $3)
+	INSERT INTO todo (id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, owner)
+	VALUES (nextval('todo_id'), $1, $2, $3, $4, $5, $6, $7, COALESCE($8, now()), $9, $10)
 	RETURNING id;
 	`
 
@@ -54,96 +77,277 @@ func (p *Postgres) Insert(todo *todolistpb.Todo) (int32, error) {
 		return -1, err
 	}
 
-	rows, err := p.DB.Query(query, todo.GetTitle(), todo.GetNote(), ts)
+	completedAt, err := nullTime(todo.GetCompletedAt())
+	if err != nil {
+		return -1, err
+	}
+
+	createdAt, err := nullTime(todo.GetCreatedAt())
 	if err != nil {
 		return -1, err
 	}
 
+	owner, _ := TenantFromContext(ctx)
+
 	var id int32
-	for rows.Next() {
-		if err := rows.Scan(&id); err != nil {
-			return -1, err
-		}
+	err = p.audited(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, query, todo.GetTitle(), todo.GetNote(), ts, todo.GetStatus().String(), completedAt,
+			tagsArray(todo.GetTags()), todo.GetPriority(), createdAt, nullID(todo.GetListId()), owner).Scan(&id)
+	})
+	if err != nil {
+		return -1, err
 	}
 
 	return id, nil
 }
 
-// Get is getting the data from the database
-func (p *Postgres) Get(id int32) (*todolistpb.Todo, error) {
+// Get is getting the data from the database, the todos of the trash are not found
+func (p *Postgres) Get(ctx context.Context, id int32) (*todolistpb.Todo, error) {
 	query := `
-	SELECT *
+	SELECT id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner
 	FROM todo
-	WHERE id = $1;
+	WHERE id = $1 AND deleted_at IS NULL AND ($2::TEXT IS NULL OR owner = $2);
 	`
 
-	rows, err := p.DB.Query(query, id)
-	if err != nil {
-		return nil, err
+	var t *todolistpb.Todo
+	err := p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, id, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		t, err = scanOneTodo(rows)
+		return err
+	})
+
+	return t, err
+}
+
+// Update is updating the fields of the paths in the database, if the expected version is not 0
+// it returns ErrVersionMismatch when the todo has a different version
+func (p *Postgres) Update(ctx context.Context, todo *todolistpb.Todo, paths []string, expectedVersion int64) (*todolistpb.Todo, error) {
+	if len(paths) == 0 {
+		return p.Get(ctx, todo.GetId())
 	}
 
-	var t todolistpb.Todo
-	var ts time.Time
-	for rows.Next() {
-		if err := rows.Scan(&t.Id, &t.Title, &t.Note, &ts); err != nil {
-			return nil, err
+	var set []string
+	var args queryArgs
+	for _, path := range paths {
+		switch path {
+		case "title":
+			set = append(set, "title = "+args.add(todo.GetTitle()))
+		case "note":
+			set = append(set, "note = "+args.add(todo.GetNote()))
+		case "due_date":
+			ts, err := ptypes.Timestamp(todo.GetDueDate())
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, "due_date = "+args.add(ts))
+		case "status":
+			completedAt, err := nullTime(todo.GetCompletedAt())
+			if err != nil {
+				return nil, err
+			}
+			// the completion time is kept if the todo was already done
+			s := args.add(todo.GetStatus().String())
+			set = append(set, "status = "+s,
+				"completed_at = CASE WHEN "+s+" = 'DONE' THEN COALESCE(completed_at, "+args.add(completedAt)+") END")
+		case "tags":
+			set = append(set, "tags = "+args.add(tagsArray(todo.GetTags())))
+		case "priority":
+			set = append(set, "priority = "+args.add(todo.GetPriority()))
+		case "list_id":
+			set = append(set, "list_id = "+args.add(nullID(todo.GetListId())))
+		default:
+			return nil, fmt.Errorf("unknown update path: %v", path)
 		}
 	}
 
-	t.DueDate, err = ptypes.TimestampProto(ts)
+	set = append(set, "version = version + 1")
+
+	where := "id = " + args.add(todo.GetId()) + " AND deleted_at IS NULL"
+	if owner := ownerArg(ctx); owner.Valid {
+		where += " AND owner = " + args.add(owner.String)
+	}
+	if expectedVersion != 0 {
+		where += " AND version = " + args.add(expectedVersion)
+	}
+
+	query := fmt.Sprintf(`
+	UPDATE todo
+	SET %v
+	WHERE %v
+	RETURNING id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner;
+	`, strings.Join(set, ", "), where)
+
+	t, err := p.queryTodo(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
-	return &t, nil
+
+	if t.GetId() == 0 && expectedVersion != 0 {
+		return t, p.checkVersion(ctx, todo.GetId())
+	}
+
+	return t, nil
 }
 
-// Update is updating the data in the database
-func (p *Postgres) Update(todo *todolistpb.Todo) (*todolistpb.Todo, error) {
+// Complete is setting the todo to done, the completion time is kept if it was already done
+func (p *Postgres) Complete(ctx context.Context, id int32, completedAt *timestamp.Timestamp) (*todolistpb.Todo, error) {
 	query := `
 	UPDATE todo
-	SET title = $1, note = $2, due_date = $3
-	WHERE id = $4
-	RETURNING id, title, note, due_date;
+	SET status = 'DONE',
+		completed_at = CASE WHEN status = 'DONE' THEN completed_at ELSE $1 END,
+		version = version + 1
+	WHERE id = $2 AND deleted_at IS NULL AND ($3::TEXT IS NULL OR owner = $3)
+	RETURNING id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner;
 	`
 
-	ts, err := ptypes.Timestamp(todo.GetDueDate())
+	ts, err := ptypes.Timestamp(completedAt)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := p.DB.Query(query, todo.GetTitle(), todo.GetNote(), ts, todo.GetId())
+	return p.queryTodo(ctx, query, ts, id, ownerArg(ctx))
+}
+
+// Reopen is setting the todo to open and clears the completion time
+func (p *Postgres) Reopen(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	query := `
+	UPDATE todo
+	SET status = 'OPEN', completed_at = NULL, version = version + 1
+	WHERE id = $1 AND deleted_at IS NULL AND ($2::TEXT IS NULL OR owner = $2)
+	RETURNING id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner;
+	`
+
+	return p.queryTodo(ctx, query, id, ownerArg(ctx))
+}
+
+// Delete is moving the todo to the trash, if the expected version is not 0
+// it returns ErrVersionMismatch when the todo has a different version
+func (p *Postgres) Delete(ctx context.Context, id int32, expectedVersion int64) (int64, error) {
+	query := `
+	UPDATE todo
+	SET deleted_at = now()
+	WHERE id = $1 AND deleted_at IS NULL AND ($2::BIGINT = 0 OR version = $2) AND ($3::TEXT IS NULL OR owner = $3);
+	`
+
+	var count int64
+	err := p.audited(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, id, expectedVersion, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+
+		count, err = res.RowsAffected()
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return -1, err
 	}
 
-	var t todolistpb.Todo
-	for rows.Next() {
-		if err := rows.Scan(&t.Id, &t.Title, &t.Note, &ts); err != nil {
-			return nil, err
-		}
+	if count == 0 && expectedVersion != 0 {
+		return count, p.checkVersion(ctx, id)
 	}
 
-	t.DueDate, err = ptypes.TimestampProto(ts)
+	return count, nil
+}
+
+// Restore is moving the todo back from the trash, it returns an empty todo if it is not in the trash
+func (p *Postgres) Restore(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	query := `
+	UPDATE todo
+	SET deleted_at = NULL
+	WHERE id = $1 AND deleted_at IS NOT NULL AND ($2::TEXT IS NULL OR owner = $2)
+	RETURNING id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner;
+	`
+
+	return p.queryTodo(ctx, query, id, ownerArg(ctx))
+}
+
+// GetTrashed is getting the todo from the trash, it returns an empty todo if it is not in the trash
+func (p *Postgres) GetTrashed(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	query := `
+	SELECT id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner
+	FROM todo
+	WHERE id = $1 AND deleted_at IS NOT NULL AND ($2::TEXT IS NULL OR owner = $2);
+	`
+
+	var t *todolistpb.Todo
+	err := p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, id, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		t, err = scanOneTodo(rows)
+		return err
+	})
+
+	return t, err
+}
+
+// ListTrash is listing the todos of the trash, the last deleted first
+func (p *Postgres) ListTrash(ctx context.Context) ([]*todolistpb.Todo, error) {
+	query := `
+	SELECT id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner
+	FROM todo
+	WHERE deleted_at IS NOT NULL AND ($1::TEXT IS NULL OR owner = $1)
+	ORDER BY deleted_at DESC, id DESC;
+	`
+
+	var todoList []*todolistpb.Todo
+	err := p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			t, err := scanTodo(rows)
+			if err != nil {
+				return err
+			}
+			todoList = append(todoList, t)
+		}
+
+		return rows.Err()
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &t, nil
+	return todoList, nil
 }
 
-// Delete is deleting the data from the database
-func (p *Postgres) Delete(id int32) (int64, error) {
+// Purge is deleting the todos of the trash permanently which were deleted before the time,
+// or all of them if the time is nil
+func (p *Postgres) Purge(ctx context.Context, deletedBefore *timestamp.Timestamp) (int64, error) {
 	query := `
 	DELETE FROM todo
-	WHERE id = $1;
+	WHERE deleted_at IS NOT NULL AND ($1::TIMESTAMP WITH TIME ZONE IS NULL OR deleted_at < $1)
+		AND ($2::TEXT IS NULL OR owner = $2);
 	`
 
-	res, err := p.DB.Exec(query, id)
+	before, err := nullTime(deletedBefore)
 	if err != nil {
 		return -1, err
 	}
 
-	count, err := res.RowsAffected()
+	var count int64
+	err = p.scoped(ctx, func(q querier) error {
+		res, err := q.ExecContext(ctx, query, before, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+
+		count, err = res.RowsAffected()
+		return err
+	})
 	if err != nil {
 		return -1, err
 	}
@@ -151,64 +355,1563 @@ func (p *Postgres) Delete(id int32) (int64, error) {
 	return count, nil
 }
 
-// List is listing the data
-func (p *Postgres) List() ([]*todolistpb.Todo, error) {
+// CountTodos is counting the open and in progress todos and the ones due before the time
+func (p *Postgres) CountTodos(ctx context.Context, dueBefore *timestamp.Timestamp) (int64, int64, error) {
 	query := `
-	SELECT *
+	SELECT COUNT(*), COUNT(*) FILTER (WHERE due_date < $1)
 	FROM todo
-	ORDER BY id;
+	WHERE status IN ('OPEN', 'IN_PROGRESS') AND deleted_at IS NULL AND ($2::TEXT IS NULL OR owner = $2);
 	`
 
-	rows, err := p.DB.Query(query)
+	before, err := ptypes.Timestamp(dueBefore)
 	if err != nil {
-		return nil, err
+		return -1, -1, err
+	}
+
+	var open, overdue int64
+	err = p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, before, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if err := rows.Scan(&open, &overdue); err != nil {
+				return err
+			}
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return -1, -1, err
+	}
+
+	return open, overdue, nil
+}
+
+// audited is running the function in a transaction, the audit of the context is recorded
+// in the history of the todos changed by it
+func (p *Postgres) audited(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// the settings are local to the transaction, they are read by the todo_history trigger
+	// and by the row-level security policies
+	audit := AuditFromContext(ctx)
+	owner, all := TenantFromContext(ctx)
+	query := `
+	SELECT set_config('todo.actor', $1, true), set_config('todo.request_id', $2, true),
+		set_config('todo.owner', $3, true), set_config('todo.all_owners', $4, true);
+	`
+	if _, err := tx.ExecContext(ctx, query, audit.Actor, audit.RequestID, owner, strconv.FormatBool(all)); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// querier is running the queries on the database or in a transaction
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// scoped is running the function on the database, or in a transaction with the owner settings
+// of the context if the row-level security is enforced
+func (p *Postgres) scoped(ctx context.Context, fn func(querier) error) error {
+	if !p.RowLevelSecurity {
+		return fn(p.DB)
+	}
+
+	return p.audited(ctx, func(tx *sql.Tx) error {
+		return fn(tx)
+	})
+}
+
+// ownerArg returns with the owner of the context as a query argument, it is NULL if the queries
+// are not scoped
+func ownerArg(ctx context.Context) sql.NullString {
+	owner, all := TenantFromContext(ctx)
+	return sql.NullString{String: owner, Valid: !all}
+}
+
+// queryTodo is changing the todo in an audited transaction, it returns an empty todo if there was no row
+func (p *Postgres) queryTodo(ctx context.Context, query string, args ...interface{}) (*todolistpb.Todo, error) {
+	var t *todolistpb.Todo
+	err := p.audited(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		t, err = scanOneTodo(rows)
+		return err
+	})
+
+	return t, err
+}
+
+// checkVersion is called when nothing was changed with an expected version,
+// it returns ErrVersionMismatch if the todo exists, so it must have a different version
+func (p *Postgres) checkVersion(ctx context.Context, id int32) error {
+	t, err := p.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if t.GetId() != 0 {
+		return ErrVersionMismatch
+	}
+
+	return nil
+}
+
+// sortColumns are the columns of the sort orders
+var sortColumns = map[todolistpb.ListTodosRequest_SortBy]string{
+	todolistpb.ListTodosRequest_ID:         "id",
+	todolistpb.ListTodosRequest_DUE_DATE:   "due_date",
+	todolistpb.ListTodosRequest_CREATED_AT: "created_at",
+	todolistpb.ListTodosRequest_TITLE:      `title COLLATE "C"`,
+	todolistpb.ListTodosRequest_PRIORITY:   "priority",
+}
+
+// List is listing the data matching the filters in the sort order of the request,
+// it returns with one page of the data and the token of the next page
+func (p *Postgres) List(ctx context.Context, req *todolistpb.ListTodosRequest) ([]*todolistpb.Todo, string, error) {
+	token, err := parsePageToken(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the todos of the trash are not listed
+	where := []string{"deleted_at IS NULL"}
+	var args queryArgs
+
+	if owner := ownerArg(ctx); owner.Valid {
+		where = append(where, "owner = "+args.add(owner.String))
+	}
+
+	if len(req.GetStatus()) > 0 {
+		var statuses []string
+		for _, s := range req.GetStatus() {
+			statuses = append(statuses, s.String())
+		}
+		where = append(where, "status = ANY("+args.add(pq.Array(statuses))+")")
+	}
+
+	if req.GetDueBefore() != nil {
+		ts, err := ptypes.Timestamp(req.GetDueBefore())
+		if err != nil {
+			return nil, "", err
+		}
+		where = append(where, "due_date < "+args.add(ts))
+	}
+
+	if req.GetDueAfter() != nil {
+		ts, err := ptypes.Timestamp(req.GetDueAfter())
+		if err != nil {
+			return nil, "", err
+		}
+		where = append(where, "due_date > "+args.add(ts))
+	}
+
+	if req.GetTitleContains() != "" {
+		where = append(where, "strpos(lower(title), lower("+args.add(req.GetTitleContains())+")) > 0")
+	}
+
+	if len(req.GetTags()) > 0 {
+		where = append(where, "tags @> "+args.add(pq.Array(req.GetTags())))
+	}
+
+	if req.GetListId() != 0 {
+		where = append(where, "list_id = "+args.add(req.GetListId()))
+	}
+
+	if req.GetInbox() {
+		where = append(where, "list_id IS NULL")
+	}
+
+	column := sortColumns[req.GetSortBy()]
+	direction, cmp := "ASC", ">"
+	if req.GetDescending() {
+		direction, cmp = "DESC", "<"
+	}
+
+	if token != nil {
+		where = append(where, fmt.Sprintf("(%v, id) %v (%v, %v)", column, cmp, args.add(token.sortValue()), args.add(token.ID)))
 	}
 
+	query := `
+	SELECT id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner
+	FROM todo
+	WHERE ` + strings.Join(where, " AND ") + "\n\t"
+	query += fmt.Sprintf("ORDER BY %v %v, id %v\n\tLIMIT %v;", column, direction, direction, args.add(PageSize(req)+1))
+
 	var todoList []*todolistpb.Todo
-	for rows.Next() {
-		var t todolistpb.Todo
-		var ts time.Time
+	err = p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
 
-		if err := rows.Scan(&t.Id, &t.Title, &t.Note, &ts); err != nil {
-			return nil, err
+		for rows.Next() {
+			t, err := scanTodo(rows)
+			if err != nil {
+				return err
+			}
+			todoList = append(todoList, t)
 		}
 
-		t.DueDate, err = ptypes.TimestampProto(ts)
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return nextPage(req, todoList)
+}
+
+// InsertTodoList is inserting the list to the database
+func (p *Postgres) InsertTodoList(ctx context.Context, list *todolistpb.TodoList) (int32, error) {
+	query := `
+	INSERT INTO todo_list (name, description, color, created_at, owner)
+	VALUES ($1, $2, $3, COALESCE($4, now()), $5)
+	RETURNING id;
+	`
+
+	createdAt, err := nullTime(list.GetCreatedAt())
+	if err != nil {
+		return -1, err
+	}
+
+	owner, _ := TenantFromContext(ctx)
+
+	var id int32
+	err = p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, list.GetName(), list.GetDescription(), list.GetColor(), createdAt, owner)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
 		}
-		todoList = append(todoList, &t)
+
+		return rows.Err()
+	})
+	if err != nil {
+		return -1, err
 	}
 
-	return todoList, nil
+	return id, nil
 }
 
-// Setup the databse
-func Setup(c *PostgresConfig) *sql.DB {
-	db, err := ConnectPostgres(c)
+// GetTodoList is getting the list from the database
+func (p *Postgres) GetTodoList(ctx context.Context, id int32) (*todolistpb.TodoList, error) {
+	query := `
+	SELECT id, name, description, color, created_at, owner
+	FROM todo_list
+	WHERE id = $1 AND ($2::TEXT IS NULL OR owner = $2);
+	`
+
+	var l *todolistpb.TodoList
+	err := p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, id, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		l, err = scanOneTodoList(rows)
+		return err
+	})
+
+	return l, err
+}
+
+// UpdateTodoList is updating the list in the database
+func (p *Postgres) UpdateTodoList(ctx context.Context, list *todolistpb.TodoList) (*todolistpb.TodoList, error) {
+	query := `
+	UPDATE todo_list
+	SET name = $1, description = $2, color = $3
+	WHERE id = $4 AND ($5::TEXT IS NULL OR owner = $5)
+	RETURNING id, name, description, color, created_at, owner;
+	`
+
+	var l *todolistpb.TodoList
+	err := p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, list.GetName(), list.GetDescription(), list.GetColor(), list.GetId(), ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		l, err = scanOneTodoList(rows)
+		return err
+	})
+
+	return l, err
+}
+
+// DeleteTodoList is deleting the list from the database, its todos are moved to the trash
+// or to the inbox
+func (p *Postgres) DeleteTodoList(ctx context.Context, id int32, deleteTodos bool) (int64, error) {
+	var count int64
+	err := p.audited(ctx, func(tx *sql.Tx) error {
+		if deleteTodos {
+			query := `
+			UPDATE todo
+			SET deleted_at = now()
+			WHERE list_id = $1 AND deleted_at IS NULL AND ($2::TEXT IS NULL OR owner = $2);
+			`
+
+			if _, err := tx.ExecContext(ctx, query, id, ownerArg(ctx)); err != nil {
+				return err
+			}
+		}
+
+		// the todos, the trashed ones too, are moved to the inbox by the foreign key
+		query := `
+		DELETE FROM todo_list
+		WHERE id = $1 AND ($2::TEXT IS NULL OR owner = $2);
+		`
+
+		res, err := tx.ExecContext(ctx, query, id, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+
+		count, err = res.RowsAffected()
+		return err
+	})
 	if err != nil {
-		log.Fatalf("Could not connect to the database: %v", err)
+		return -1, err
 	}
 
-	if _, err = db.Exec(createTable); err != nil {
-		log.Fatalf("Could not create the table: %v", err)
+	return count, nil
+}
+
+// ListTodoLists is listing the lists
+func (p *Postgres) ListTodoLists(ctx context.Context) ([]*todolistpb.TodoList, error) {
+	query := `
+	SELECT id, name, description, color, created_at, owner
+	FROM todo_list
+	WHERE $1::TEXT IS NULL OR owner = $1
+	ORDER BY id;
+	`
+
+	var lists []*todolistpb.TodoList
+	err := p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			l, err := scanTodoList(rows)
+			if err != nil {
+				return err
+			}
+			lists = append(lists, l)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return db
+	return lists, nil
 }
 
-// ConnectPostgres is connecting to a Postgres database
-func ConnectPostgres(c *PostgresConfig) (*sql.DB, error) {
-	connStr := fmt.Sprintf("postgres://%v:%v@%v:%v/postgres?sslmode=disable", c.User, c.Password, c.Host, c.Port)
-	db, err := sql.Open("postgres", connStr)
+// PutListMember is inserting the member to the database or changing its role
+func (p *Postgres) PutListMember(ctx context.Context, member *todolistpb.ListMember) (*todolistpb.ListMember, error) {
+	query := `
+	INSERT INTO list_member (list_id, subject, role, created_at)
+	VALUES ($1, $2, $3, COALESCE($4, now()))
+	ON CONFLICT (list_id, subject) DO UPDATE SET role = EXCLUDED.role
+	RETURNING list_id, subject, role, created_at;
+	`
+
+	createdAt, err := nullTime(member.GetCreatedAt())
 	if err != nil {
 		return nil, err
 	}
 
-	err = db.Ping()
+	rows, err := p.DB.QueryContext(ctx, query, member.GetListId(), member.GetSubject(), member.GetRole().String(), createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneListMember(rows)
+}
+
+// GetListMember is getting the member of the list from the database
+func (p *Postgres) GetListMember(ctx context.Context, listID int32, subject string) (*todolistpb.ListMember, error) {
+	query := `
+	SELECT list_id, subject, role, created_at
+	FROM list_member
+	WHERE list_id = $1 AND subject = $2;
+	`
+
+	rows, err := p.DB.QueryContext(ctx, query, listID, subject)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneListMember(rows)
+}
+
+// DeleteListMember is deleting the member of the list from the database
+func (p *Postgres) DeleteListMember(ctx context.Context, listID int32, subject string) (int64, error) {
+	query := `
+	DELETE FROM list_member
+	WHERE list_id = $1 AND subject = $2;
+	`
+
+	res, err := p.DB.ExecContext(ctx, query, listID, subject)
+	if err != nil {
+		return -1, err
+	}
+
+	return res.RowsAffected()
+}
+
+// ListListMembers is listing the members of the list
+func (p *Postgres) ListListMembers(ctx context.Context, listID int32) ([]*todolistpb.ListMember, error) {
+	query := `
+	SELECT list_id, subject, role, created_at
+	FROM list_member
+	WHERE list_id = $1
+	ORDER BY subject;
+	`
+
+	return p.queryListMembers(ctx, query, listID)
+}
+
+// ListMemberships is listing the memberships of the subject
+func (p *Postgres) ListMemberships(ctx context.Context, subject string) ([]*todolistpb.ListMember, error) {
+	query := `
+	SELECT list_id, subject, role, created_at
+	FROM list_member
+	WHERE subject = $1
+	ORDER BY list_id;
+	`
+
+	return p.queryListMembers(ctx, query, subject)
+}
+
+// queryListMembers is reading the members returned by the query
+func (p *Postgres) queryListMembers(ctx context.Context, query string, args ...interface{}) ([]*todolistpb.ListMember, error) {
+	rows, err := p.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*todolistpb.ListMember
+	for rows.Next() {
+		m, err := scanListMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, rows.Err()
+}
+
+// Watch is calling fn with the events recorded by the trigger of the todo table after the resume
+// token, it returns ErrResumeTokenExpired if the event of the token is not in the table anymore.
+// The events are read in the order of their IDs, an event is not skipped if it is committed later
+// than the events after it, see the migration of the todo_event table.
+func (p *Postgres) Watch(ctx context.Context, resumeToken string, fn func(*Event) error) error {
+	interval := watchPollInterval
+	if p.Listener != nil {
+		p.listenOnce.Do(func() { go p.listen() })
+		interval = watchListenPollInterval
+	}
+
+	after, err := p.watchStart(ctx, resumeToken, fn)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	// gap is the first visible event after a missing one and the snapshot it was found in
+	var gap *watchGap
+
+	for {
+		// the wait channel is taken before reading the events to not miss a notification
+		wait := p.wake.wait()
+
+		snapshot, events, err := p.eventsAfter(ctx, after)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		missing := false
+		for _, e := range events {
+			// the events before a gap are missing until the transactions of the snapshot finish
+			if e.id > after+1 && (gap == nil || !gap.settled || e.id > gap.id) {
+				missing = true
+				if gap == nil || e.id > gap.id {
+					gap = &watchGap{id: e.id, snapshot: snapshot}
+				}
+				break
+			}
+
+			if err := fn(e.event); err != nil {
+				return err
+			}
+			after = e.id
+		}
+
+		if gap != nil && after >= gap.id {
+			gap = nil
+		}
+
+		if missing {
+			settled, err := p.snapshotFinished(ctx, gap.snapshot)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return err
+			}
+
+			// the events are read again, the ones still missing are of rolled back transactions
+			if gap.settled = settled; settled {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(watchGapPollInterval):
+			}
+			continue
+		}
+
+		if len(events) == watchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wait:
+		case <-time.After(interval):
+		}
+	}
+}
+
+// watchGap is the first visible event after a missing event of a watch
+type watchGap struct {
+	id int64
+	// snapshot is the snapshot of the read finding the gap, its transactions were holding the missing events
+	snapshot string
+	// settled is true if the transactions of the snapshot finished, so the missing events are rolled back
+	settled bool
+}
+
+// listen is waking up the watchers on every notification, and after the reconnects of the
+// listener too when it sends nil
+func (p *Postgres) listen() {
+	for range p.Listener.NotificationChannel() {
+		p.wake.notify()
+	}
+}
+
+// watchStart returns with the ID of the last event before the watch. A watch without a resume token
+// starts after the last visible event, the transactions in progress can hold lower IDs, so their events
+// are passed to fn when they finish.
+func (p *Postgres) watchStart(ctx context.Context, resumeToken string, fn func(*Event) error) (int64, error) {
+	if resumeToken == "" {
+		var id int64
+		var snapshot string
+		err := p.DB.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0), pg_current_snapshot()::TEXT FROM todo_event;").Scan(&id, &snapshot)
+		if err != nil {
+			return 0, err
+		}
+
+		return id, p.lateEvents(ctx, id, snapshot, fn)
+	}
+
+	id, err := strconv.ParseInt(resumeToken, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidResumeToken
+	}
+
+	var found bool
+	if err := p.DB.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM todo_event WHERE id = $1);", id).Scan(&found); err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, ErrResumeTokenExpired
+	}
+
+	return id, nil
+}
+
+// lateEvents is waiting until the transactions of the snapshot finish and calling fn with their events
+// up to the ID, in the order of the IDs
+func (p *Postgres) lateEvents(ctx context.Context, until int64, snapshot string, fn func(*Event) error) error {
+	for {
+		settled, err := p.snapshotFinished(ctx, snapshot)
+		if err != nil {
+			return err
+		}
+
+		if settled {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(watchGapPollInterval):
+		}
+	}
+
+	query := `
+	SELECT id, type, todo
+	FROM todo_event
+	WHERE id <= $1 AND xid IN (SELECT pg_snapshot_xip($2::pg_snapshot))
+	ORDER BY id;
+	`
+
+	rows, err := p.DB.QueryContext(ctx, query, until, snapshot)
+	if err != nil {
+		return err
+	}
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if err := fn(e.event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// snapshotFinished returns true if none of the transactions in progress in the snapshot is in progress anymore
+func (p *Postgres) snapshotFinished(ctx context.Context, snapshot string) (bool, error) {
+	query := `
+	SELECT NOT EXISTS (
+		SELECT 1 FROM pg_snapshot_xip($1::pg_snapshot) AS xip (xid) WHERE pg_xact_status(xip.xid) = 'in progress'
+	);
+	`
+
+	var finished bool
+	err := p.DB.QueryRowContext(ctx, query, snapshot).Scan(&finished)
+
+	return finished, err
+}
+
+// PruneEvents is deleting the events recorded before the time, the watches can not be resumed from them
+func (p *Postgres) PruneEvents(ctx context.Context, before time.Time) (int64, error) {
+	res, err := p.DB.ExecContext(ctx, "DELETE FROM todo_event WHERE created_at < $1;", before)
+	if err != nil {
+		return -1, err
+	}
+
+	return res.RowsAffected()
+}
+
+// postgresEvent is an event of the todo_event table
+type postgresEvent struct {
+	id    int64
+	event *Event
+}
+
+// eventsAfter is reading the next batch of the events after the ID, it returns with the snapshot of the read too
+func (p *Postgres) eventsAfter(ctx context.Context, after int64) (string, []postgresEvent, error) {
+	// the snapshot and the events are read in the same snapshot
+	tx, err := p.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return "", nil, err
+	}
+	defer tx.Rollback()
+
+	var snapshot string
+	if err := tx.QueryRowContext(ctx, "SELECT pg_current_snapshot()::TEXT;").Scan(&snapshot); err != nil {
+		return "", nil, err
+	}
+
+	query := `
+	SELECT id, type, todo
+	FROM todo_event
+	WHERE id > $1
+	ORDER BY id
+	LIMIT $2;
+	`
+
+	rows, err := tx.QueryContext(ctx, query, after, watchBatchSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return snapshot, events, tx.Commit()
+}
+
+// scanEvents returns with the events of the rows, the rows are closed
+func scanEvents(rows *sql.Rows) ([]postgresEvent, error) {
+	defer rows.Close()
+
+	var events []postgresEvent
+	for rows.Next() {
+		var e postgresEvent
+		var eventType string
+		var todo []byte
+		if err := rows.Scan(&e.id, &eventType, &todo); err != nil {
+			return nil, err
+		}
+
+		t, err := decodeEventTodo(todo)
+		if err != nil {
+			return nil, err
+		}
+
+		e.event = &Event{
+			Type:        todolistpb.WatchTodosResponse_EventType(todolistpb.WatchTodosResponse_EventType_value[eventType]),
+			Todo:        t,
+			ResumeToken: strconv.FormatInt(e.id, 10),
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// ProcessOutbox is calling fn with the oldest events of the outbox, the events are locked until
+// they are processed, so the relays of the other instances are skipping them
+func (p *Postgres) ProcessOutbox(ctx context.Context, limit int, fn func(*OutboxEvent) error) (int, error) {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+	SELECT id, type, todo, created_at
+	FROM outbox
+	ORDER BY id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED;
+	`
+
+	rows, err := tx.QueryContext(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var events []*OutboxEvent
+	for rows.Next() {
+		var e OutboxEvent
+		var eventType string
+		var todo []byte
+		if err := rows.Scan(&e.ID, &eventType, &todo, &e.OccurredAt); err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		if e.Todo, err = decodeEventTodo(todo); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		e.Type = todolistpb.WatchTodosResponse_EventType(todolistpb.WatchTodosResponse_EventType_value[eventType])
+		events = append(events, &e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var ids []int64
+	var fnErr error
+	for _, e := range events {
+		if fnErr = fn(e); fnErr != nil {
+			break
+		}
+		ids = append(ids, e.ID)
+	}
+
+	if len(ids) > 0 {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM outbox WHERE id = ANY($1);", pq.Array(ids)); err != nil {
+			return 0, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ids), fnErr
+}
+
+// eventTodo is the row of the todo table as it is recorded by the trigger
+type eventTodo struct {
+	ID          int32      `json:"id"`
+	Title       string     `json:"title"`
+	Note        string     `json:"note"`
+	DueDate     time.Time  `json:"due_date"`
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completed_at"`
+	Tags        []string   `json:"tags"`
+	Priority    int32      `json:"priority"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ListID      int32      `json:"list_id"`
+	Version     int64      `json:"version"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+	Owner       string     `json:"owner"`
+}
+
+// decodeEventTodo is converting the JSON row of the todo to a todo
+func decodeEventTodo(b []byte) (*todolistpb.Todo, error) {
+	var row eventTodo
+	if err := json.Unmarshal(b, &row); err != nil {
+		return nil, err
+	}
+
+	t := &todolistpb.Todo{
+		Id:       row.ID,
+		Title:    row.Title,
+		Note:     row.Note,
+		Status:   todolistpb.Status(todolistpb.Status_value[row.Status]),
+		Tags:     row.Tags,
+		Priority: row.Priority,
+		ListId:   row.ListID,
+		Version:  row.Version,
+		Owner:    row.Owner,
+	}
+
+	if len(t.Tags) == 0 {
+		t.Tags = nil
+	}
+
+	var err error
+	t.DueDate, err = ptypes.TimestampProto(row.DueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	t.CreatedAt, err = ptypes.TimestampProto(row.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if row.CompletedAt != nil {
+		t.CompletedAt, err = ptypes.TimestampProto(*row.CompletedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if row.DeletedAt != nil {
+		t.DeletedAt, err = ptypes.TimestampProto(*row.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// InsertWebhook is inserting the webhook of the owner of the context to the database
+func (p *Postgres) InsertWebhook(ctx context.Context, webhook *todolistpb.Webhook) (int32, error) {
+	query := `
+	INSERT INTO webhook (url, event_types, secret, disabled, created_at, owner)
+	VALUES ($1, $2, $3, $4, COALESCE($5, now()), $6)
+	RETURNING id;
+	`
+
+	createdAt, err := nullTime(webhook.GetCreatedAt())
+	if err != nil {
+		return -1, err
+	}
+
+	owner, _ := TenantFromContext(ctx)
+
+	rows, err := p.DB.QueryContext(ctx, query, webhook.GetUrl(), tagsArray(webhook.GetEventTypes()), webhook.GetSecret(), webhook.GetDisabled(), createdAt, owner)
+	if err != nil {
+		return -1, err
+	}
+	defer rows.Close()
+
+	var id int32
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return -1, err
+		}
+	}
+
+	return id, rows.Err()
+}
+
+// GetWebhook is getting the webhook from the database
+func (p *Postgres) GetWebhook(ctx context.Context, id int32) (*todolistpb.Webhook, error) {
+	query := `
+	SELECT id, url, event_types, secret, disabled, failure_count, created_at, owner
+	FROM webhook
+	WHERE id = $1 AND ($2::TEXT IS NULL OR owner = $2);
+	`
+
+	rows, err := p.DB.QueryContext(ctx, query, id, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneWebhook(rows)
+}
+
+// UpdateWebhook is updating the webhook in the database, the secret is kept if it is empty
+func (p *Postgres) UpdateWebhook(ctx context.Context, webhook *todolistpb.Webhook) (*todolistpb.Webhook, error) {
+	query := `
+	UPDATE webhook
+	SET url = $1, event_types = $2, secret = COALESCE(NULLIF($3, ''), secret), disabled = $4, failure_count = 0
+	WHERE id = $5 AND ($6::TEXT IS NULL OR owner = $6)
+	RETURNING id, url, event_types, secret, disabled, failure_count, created_at, owner;
+	`
+
+	rows, err := p.DB.QueryContext(ctx, query, webhook.GetUrl(), tagsArray(webhook.GetEventTypes()), webhook.GetSecret(), webhook.GetDisabled(), webhook.GetId(), ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneWebhook(rows)
+}
+
+// DeleteWebhook is deleting the webhook from the database, its deliveries are deleted by the foreign key
+func (p *Postgres) DeleteWebhook(ctx context.Context, id int32) (int64, error) {
+	query := `
+	DELETE FROM webhook
+	WHERE id = $1 AND ($2::TEXT IS NULL OR owner = $2);
+	`
+
+	res, err := p.DB.ExecContext(ctx, query, id, ownerArg(ctx))
+	if err != nil {
+		return -1, err
+	}
+
+	return res.RowsAffected()
+}
+
+// ListWebhooks is listing the webhooks of the owner of the context
+func (p *Postgres) ListWebhooks(ctx context.Context) ([]*todolistpb.Webhook, error) {
+	query := `
+	SELECT id, url, event_types, secret, disabled, failure_count, created_at, owner
+	FROM webhook
+	WHERE ($1::TEXT IS NULL OR owner = $1)
+	ORDER BY id;
+	`
+
+	rows, err := p.DB.QueryContext(ctx, query, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*todolistpb.Webhook
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// InsertWebhookDelivery is inserting the attempt of a delivery to the database, it returns 0 if the webhook does not exist
+func (p *Postgres) InsertWebhookDelivery(ctx context.Context, delivery *todolistpb.WebhookDelivery) (int64, error) {
+	query := `
+	INSERT INTO webhook_delivery (webhook_id, event_id, event_type, attempt, status_code, error, success, attempted_at)
+	SELECT id, $2::BIGINT, $3::TEXT, $4::INTEGER, $5::INTEGER, $6::TEXT, $7::BOOLEAN, COALESCE($8::TIMESTAMP WITH TIME ZONE, now())
+	FROM webhook
+	WHERE id = $1 AND ($9::TEXT IS NULL OR owner = $9)
+	RETURNING id;
+	`
+
+	attemptedAt, err := nullTime(delivery.GetAttemptedAt())
+	if err != nil {
+		return -1, err
+	}
+
+	rows, err := p.DB.QueryContext(ctx, query, delivery.GetWebhookId(), delivery.GetEventId(), delivery.GetEventType(),
+		delivery.GetAttempt(), delivery.GetStatusCode(), delivery.GetError(), delivery.GetSuccess(), attemptedAt, ownerArg(ctx))
+	if err != nil {
+		return -1, err
+	}
+	defer rows.Close()
+
+	var id int64
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return -1, err
+		}
+	}
+
+	return id, rows.Err()
+}
+
+// ListWebhookDeliveries is listing the last deliveries of the webhook, the newest first
+func (p *Postgres) ListWebhookDeliveries(ctx context.Context, webhookID int32, limit int) ([]*todolistpb.WebhookDelivery, error) {
+	query := `
+	SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.attempt, d.status_code, d.error, d.success, d.attempted_at
+	FROM webhook_delivery d
+	JOIN webhook w ON w.id = d.webhook_id
+	WHERE d.webhook_id = $1 AND ($3::TEXT IS NULL OR w.owner = $3)
+	ORDER BY d.id DESC
+	LIMIT $2;
+	`
+
+	rows, err := p.DB.QueryContext(ctx, query, webhookID, limit, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*todolistpb.WebhookDelivery
+	for rows.Next() {
+		var d todolistpb.WebhookDelivery
+		var attemptedAt time.Time
+
+		if err := rows.Scan(&d.Id, &d.WebhookId, &d.EventId, &d.EventType, &d.Attempt, &d.StatusCode, &d.Error, &d.Success, &attemptedAt); err != nil {
+			return nil, err
+		}
+
+		if d.AttemptedAt, err = ptypes.TimestampProto(attemptedAt); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, &d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// RecordWebhookResult is updating the failure count of the webhook, it is disabled when the count reaches disableAfter
+func (p *Postgres) RecordWebhookResult(ctx context.Context, id int32, success bool, disableAfter int32) (*todolistpb.Webhook, error) {
+	query := `
+	UPDATE webhook
+	SET failure_count = CASE WHEN $1 THEN 0 ELSE failure_count + 1 END,
+		disabled = disabled OR (NOT $1 AND $2 > 0 AND failure_count + 1 >= $2)
+	WHERE id = $3 AND ($4::TEXT IS NULL OR owner = $4)
+	RETURNING id, url, event_types, secret, disabled, failure_count, created_at, owner;
+	`
+
+	rows, err := p.DB.QueryContext(ctx, query, success, disableAfter, id, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneWebhook(rows)
+}
+
+// EnqueueWebhookDelivery is queueing the message to the webhook, it returns 0 if the webhook does not exist
+// or the event is already queued to it
+func (p *Postgres) EnqueueWebhookDelivery(ctx context.Context, delivery *QueuedDelivery) (int64, error) {
+	query := `
+	INSERT INTO webhook_queue (webhook_id, event_id, event_type, body, next_attempt_at)
+	SELECT id, $2::BIGINT, $3::TEXT, $4::BYTEA, COALESCE($5::TIMESTAMP WITH TIME ZONE, now())
+	FROM webhook
+	WHERE id = $1 AND ($6::TEXT IS NULL OR owner = $6)
+	ON CONFLICT (webhook_id, event_id) WHERE event_id <> 0 DO NOTHING
+	RETURNING id;
+	`
+
+	nextAttemptAt := sql.NullTime{Time: delivery.NextAttemptAt, Valid: !delivery.NextAttemptAt.IsZero()}
+
+	rows, err := p.DB.QueryContext(ctx, query, delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.Body, nextAttemptAt, ownerArg(ctx))
+	if err != nil {
+		return -1, err
+	}
+	defer rows.Close()
+
+	var id int64
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return -1, err
+		}
+	}
+
+	return id, rows.Err()
+}
+
+// ClaimWebhookDeliveries is claiming the oldest due delivery of the webhooks for the lease. The claimed
+// rows are checked again by the update, so a delivery claimed by an other instance at the same time is skipped.
+func (p *Postgres) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*QueuedDelivery, error) {
+	query := `
+	UPDATE webhook_queue q
+	SET next_attempt_at = now() + make_interval(secs => $2)
+	FROM (
+		SELECT id
+		FROM (
+			SELECT DISTINCT ON (webhook_id) id, next_attempt_at
+			FROM webhook_queue
+			ORDER BY webhook_id, id
+		) head
+		WHERE next_attempt_at <= now()
+		ORDER BY id
+		LIMIT $1
+	) due
+	WHERE q.id = due.id AND q.next_attempt_at <= now()
+	RETURNING q.id, q.webhook_id, q.event_id, q.event_type, q.body, q.attempt, q.next_attempt_at;
+	`
+
+	rows, err := p.DB.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*QueuedDelivery
+	for rows.Next() {
+		var d QueuedDelivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Body, &d.Attempt, &d.NextAttemptAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// RetryWebhookDelivery is setting the failed attempts and the next attempt of the queued delivery
+func (p *Postgres) RetryWebhookDelivery(ctx context.Context, id int64, attempt int32, nextAttemptAt time.Time) error {
+	query := `
+	UPDATE webhook_queue
+	SET attempt = $2, next_attempt_at = $3
+	WHERE id = $1;
+	`
+
+	_, err := p.DB.ExecContext(ctx, query, id, attempt, nextAttemptAt)
+	return err
+}
+
+// DequeueWebhookDelivery is deleting the delivery from the queue
+func (p *Postgres) DequeueWebhookDelivery(ctx context.Context, id int64) error {
+	_, err := p.DB.ExecContext(ctx, "DELETE FROM webhook_queue WHERE id = $1;", id)
+	return err
+}
+
+// ListTodoHistory is listing one page of the revisions of the todo, the newest first
+func (p *Postgres) ListTodoHistory(ctx context.Context, req *todolistpb.GetTodoHistoryRequest) ([]*todolistpb.TodoRevision, string, error) {
+	token, err := parseHistoryPageToken(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var before int64
+	if token != nil {
+		before = token.ID
+	}
+
+	query := `
+	SELECT h.id, h.todo_id, h.action, h.todo_before, h.todo_after, h.actor, h.request_id, h.changed_at
+	FROM todo_history h
+	JOIN todo t ON t.id = h.todo_id
+	WHERE h.todo_id = $1 AND ($2::BIGINT = 0 OR h.id < $2) AND ($4::TEXT IS NULL OR t.owner = $4)
+	ORDER BY h.id DESC
+	LIMIT $3;
+	`
+
+	// one more revision is read to know if there is a next page
+	var revisions []*todolistpb.TodoRevision
+	err = p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, req.GetTodoId(), before, HistoryPageSize(req)+1, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			r, err := scanRevision(rows)
+			if err != nil {
+				return err
+			}
+			revisions = append(revisions, r)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return nextHistoryPage(req, revisions)
+}
+
+// GetTodoRevision is getting the revision from the database
+func (p *Postgres) GetTodoRevision(ctx context.Context, id int64) (*todolistpb.TodoRevision, error) {
+	query := `
+	SELECT h.id, h.todo_id, h.action, h.todo_before, h.todo_after, h.actor, h.request_id, h.changed_at
+	FROM todo_history h
+	JOIN todo t ON t.id = h.todo_id
+	WHERE h.id = $1 AND ($2::TEXT IS NULL OR t.owner = $2);
+	`
+
+	r := &todolistpb.TodoRevision{}
+	err := p.scoped(ctx, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, id, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if r, err = scanRevision(rows); err != nil {
+				return err
+			}
+		}
+
+		return rows.Err()
+	})
+
+	return r, err
+}
+
+// scanRevision is reading the revision from the current row
+func scanRevision(rows *sql.Rows) (*todolistpb.TodoRevision, error) {
+	var r todolistpb.TodoRevision
+	var action string
+	var before, after []byte
+	var changedAt time.Time
+
+	if err := rows.Scan(&r.Id, &r.TodoId, &action, &before, &after, &r.Actor, &r.RequestId, &changedAt); err != nil {
+		return nil, err
+	}
+
+	r.Action = todolistpb.TodoRevision_Action(todolistpb.TodoRevision_Action_value[action])
+
+	var err error
+	if before != nil {
+		if r.Before, err = decodeEventTodo(before); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.After, err = decodeEventTodo(after); err != nil {
+		return nil, err
+	}
+
+	if r.ChangedAt, err = ptypes.TimestampProto(changedAt); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// scanOneTodoList is reading the list from the result, it returns an empty list if there was no row
+func scanOneTodoList(rows *sql.Rows) (*todolistpb.TodoList, error) {
+	l := &todolistpb.TodoList{}
+	for rows.Next() {
+		var err error
+		if l, err = scanTodoList(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, rows.Err()
+}
+
+// scanTodoList is reading the list from the current row
+func scanTodoList(rows *sql.Rows) (*todolistpb.TodoList, error) {
+	var l todolistpb.TodoList
+	var createdAt time.Time
+
+	if err := rows.Scan(&l.Id, &l.Name, &l.Description, &l.Color, &createdAt, &l.Owner); err != nil {
+		return nil, err
+	}
+
+	var err error
+	l.CreatedAt, err = ptypes.TimestampProto(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+// scanOneListMember is reading the member from the result, it returns an empty member if there was no row
+func scanOneListMember(rows *sql.Rows) (*todolistpb.ListMember, error) {
+	m := &todolistpb.ListMember{}
+	for rows.Next() {
+		var err error
+		if m, err = scanListMember(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, rows.Err()
+}
+
+// scanListMember is reading the member from the current row
+func scanListMember(rows *sql.Rows) (*todolistpb.ListMember, error) {
+	var m todolistpb.ListMember
+	var role string
+	var createdAt time.Time
+
+	if err := rows.Scan(&m.ListId, &m.Subject, &role, &createdAt); err != nil {
+		return nil, err
+	}
+
+	m.Role = todolistpb.ListMember_Role(todolistpb.ListMember_Role_value[role])
+
+	var err error
+	m.CreatedAt, err = ptypes.TimestampProto(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// scanOneWebhook is reading the webhook from the result, it returns an empty webhook if there was no row
+func scanOneWebhook(rows *sql.Rows) (*todolistpb.Webhook, error) {
+	w := &todolistpb.Webhook{}
+	for rows.Next() {
+		var err error
+		if w, err = scanWebhook(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, rows.Err()
+}
+
+// scanWebhook is reading the webhook from the current row
+func scanWebhook(rows *sql.Rows) (*todolistpb.Webhook, error) {
+	var w todolistpb.Webhook
+	var createdAt time.Time
+
+	if err := rows.Scan(&w.Id, &w.Url, pq.Array(&w.EventTypes), &w.Secret, &w.Disabled, &w.FailureCount, &createdAt, &w.Owner); err != nil {
+		return nil, err
+	}
+
+	if len(w.EventTypes) == 0 {
+		w.EventTypes = nil
+	}
+
+	var err error
+	w.CreatedAt, err = ptypes.TimestampProto(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// scanOneTodo is reading the todo from the result, it returns an empty todo if there was no row
+func scanOneTodo(rows *sql.Rows) (*todolistpb.Todo, error) {
+	t := &todolistpb.Todo{}
+	for rows.Next() {
+		var err error
+		if t, err = scanTodo(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, rows.Err()
+}
+
+// scanTodo is reading the todo from the current row
+func scanTodo(rows *sql.Rows) (*todolistpb.Todo, error) {
+	var t todolistpb.Todo
+	var ts time.Time
+	var status string
+	var completedAt sql.NullTime
+	var createdAt time.Time
+	var listID sql.NullInt32
+	var deletedAt sql.NullTime
+
+	if err := rows.Scan(&t.Id, &t.Title, &t.Note, &ts, &status, &completedAt, pq.Array(&t.Tags), &t.Priority, &createdAt, &listID, &t.Version, &deletedAt, &t.Owner); err != nil {
+		return nil, err
+	}
+
+	t.ListId = listID.Int32
+
+	var err error
+	t.DueDate, err = ptypes.TimestampProto(ts)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Status = todolistpb.Status(todolistpb.Status_value[status])
+
+	if len(t.Tags) == 0 {
+		t.Tags = nil
+	}
+
+	t.CreatedAt, err = ptypes.TimestampProto(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		t.CompletedAt, err = ptypes.TimestampProto(completedAt.Time)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if deletedAt.Valid {
+		t.DeletedAt, err = ptypes.TimestampProto(deletedAt.Time)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &t, nil
+}
+
+// nullTime is converting the optional timestamp to a nullable database value
+func nullTime(ts *timestamp.Timestamp) (sql.NullTime, error) {
+	if ts == nil {
+		return sql.NullTime{}, nil
+	}
+
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
+
+// queryArgs are the arguments of a query built at runtime
+type queryArgs []interface{}
+
+// add is appending the argument and returns with its placeholder
+func (a *queryArgs) add(v interface{}) string {
+	*a = append(*a, v)
+	return fmt.Sprintf("$%v", len(*a))
+}
+
+// nullID returns with NULL for the 0 ID
+func nullID(id int32) sql.NullInt32 {
+	return sql.NullInt32{Int32: id, Valid: id != 0}
+}
+
+// tagsArray returns with an empty array instead of NULL if there are no tags
+func tagsArray(tags []string) pq.StringArray {
+	if tags == nil {
+		return pq.StringArray{}
+	}
+
+	return pq.StringArray(tags)
+}
+
+// Setup the databse
+func Setup(c *PostgresConfig) *sql.DB {
+	db, err := ConnectPostgres(c)
+	if err != nil {
+		log.Fatalf("Could not connect to the database: %v", err)
+	}
+
+	m, err := NewPostgresMigrator(db)
+	if err != nil {
+		log.Fatalf("Could not load the migrations: %v", err)
+	}
+
+	applied, err := m.Up(context.Background())
+	if err != nil {
+		log.Fatalf("Could not migrate the database: %v", err)
+	}
+
+	for _, mig := range applied {
+		log.Printf("Applied migration %v_%v", mig.Version, mig.Name)
+	}
+
+	return db
+}
+
+// EnforceRowLevelSecurity is enabling the row-level security policies of the owners on the todo
+// and the todo_list tables, they are applied to the owner of the tables too. The repository has
+// to be used with RowLevelSecurity set after it, otherwise no rows are visible.
+func EnforceRowLevelSecurity(ctx context.Context, db *sql.DB) error {
+	query := `
+	ALTER TABLE todo ENABLE ROW LEVEL SECURITY;
+	ALTER TABLE todo FORCE ROW LEVEL SECURITY;
+	ALTER TABLE todo_list ENABLE ROW LEVEL SECURITY;
+	ALTER TABLE todo_list FORCE ROW LEVEL SECURITY;
+	`
+
+	_, err := db.ExecContext(ctx, query)
+	return err
+}
+
+// ConnectPostgres is connecting to a Postgres database
+func ConnectPostgres(c *PostgresConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", postgresConnStr(c))
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Ping()
 	if err != nil {
 		return nil, err
 	}
 
 	return db, nil
 }
+
+// ListenPostgres returns with a listener of the todo changes for the watchers, it is reconnecting
+// to the database when the connection is lost
+func ListenPostgres(c *PostgresConfig) (*pq.Listener, error) {
+	l := pq.NewListener(postgresConnStr(c), time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Database listener error: %v", err)
+		}
+	})
+
+	if err := l.Listen(todoEventChannel); err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// postgresConnStr returns with the connection string of the database
+func postgresConnStr(c *PostgresConfig) string {
+	return fmt.Sprintf("postgres://%v:%v@%v:%v/postgres?sslmode=disable", c.User, c.Password, c.Host, c.Port)
+}
@@ -1,9 +1,12 @@
 package db_test
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"log"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -19,7 +22,33 @@ func setupDB() *sql.DB {
 		Host:     "localhost",
 		Port:     "5432",
 	}
-	return db.Setup(config)
+	conn := db.Setup(config)
+	resetDB(conn)
+	return conn
+}
+
+// resetDB is rolling back all the migrations and applying them again to start with empty tables
+func resetDB(conn *sql.DB) {
+	ctx := context.Background()
+
+	m, err := db.NewPostgresMigrator(conn)
+	if err != nil {
+		log.Fatalf("Could not load the migrations: %v", err)
+	}
+
+	for {
+		mig, err := m.Down(ctx)
+		if err != nil {
+			log.Fatalf("Could not migrate down: %v", err)
+		}
+		if mig == nil {
+			break
+		}
+	}
+
+	if _, err := m.Up(ctx); err != nil {
+		log.Fatalf("Could not migrate up: %v", err)
+	}
 }
 
 func getTestTodo(id int32, title string) *todolistpb.Todo {
@@ -29,18 +58,22 @@ func getTestTodo(id int32, title string) *todolistpb.Todo {
 	}
 
 	return &todolistpb.Todo{
-		Id:      id,
-		Title:   title,
-		Note:    "This is a test",
-		DueDate: dd,
+		Id:        id,
+		Title:     title,
+		Note:      "This is a test",
+		DueDate:   dd,
+		CreatedAt: dd,
+		Version:   1,
 	}
 }
 
 func TestInsert(t *testing.T) {
-	postgres := &db.Postgres{setupDB()}
+	postgres := &db.Postgres{DB: setupDB()}
 	defer postgres.Close()
 
-	got, err := postgres.Insert(getTestTodo(0, "Test Todo"))
+	ctx := context.Background()
+
+	got, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -53,15 +86,17 @@ func TestInsert(t *testing.T) {
 }
 
 func TestGet(t *testing.T) {
-	postgres := &db.Postgres{setupDB()}
+	postgres := &db.Postgres{DB: setupDB()}
 	defer postgres.Close()
 
-	id, err := postgres.Insert(getTestTodo(0, "Test Todo"))
+	ctx := context.Background()
+
+	id, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	got, err := postgres.Get(id)
+	got, err := postgres.Get(ctx, id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -74,36 +109,122 @@ func TestGet(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	postgres := &db.Postgres{setupDB()}
+	postgres := &db.Postgres{DB: setupDB()}
 	defer postgres.Close()
 
-	id, err := postgres.Insert(getTestTodo(0, "Test Todo"))
+	ctx := context.Background()
+
+	id, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	want := getTestTodo(id, "Update Test Todo")
 
-	got, err := postgres.Update(want)
+	got, err := postgres.Update(ctx, want, db.UpdatePaths, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want.Version = 2
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+}
+
+func TestUpdateMask(t *testing.T) {
+	postgres := &db.Postgres{DB: setupDB()}
+	defer postgres.Close()
+
+	ctx := context.Background()
+
+	id, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// only the title is updated, the rest of the fields are kept
+	got, err := postgres.Update(ctx, &todolistpb.Todo{Id: id, Title: "Renamed Test Todo"}, []string{"title"}, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	want := getTestTodo(id, "Renamed Test Todo")
+	want.Version = 2
+
 	if !reflect.DeepEqual(got, want) {
 		t.Fatalf("Want: %v, Got: %v\n", want, got)
 	}
 }
 
+func TestUpdateVersion(t *testing.T) {
+	postgres := &db.Postgres{DB: setupDB()}
+	defer postgres.Close()
+
+	ctx := context.Background()
+
+	id, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todo := getTestTodo(id, "Update Test Todo")
+
+	if _, err := postgres.Update(ctx, todo, db.UpdatePaths, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// the version moved to 2 with the first update
+	_, err = postgres.Update(ctx, todo, db.UpdatePaths, 1)
+	if err != db.ErrVersionMismatch {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrVersionMismatch, err)
+	}
+
+	_, err = postgres.Update(ctx, getTestTodo(id+1, "Test Todo"), db.UpdatePaths, 1)
+	if err != nil {
+		t.Fatalf("Want: nil, Got: %v\n", err)
+	}
+}
+
+func TestDeleteVersion(t *testing.T) {
+	postgres := &db.Postgres{DB: setupDB()}
+	defer postgres.Close()
+
+	ctx := context.Background()
+
+	id, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := postgres.Delete(ctx, id, 2); err != db.ErrVersionMismatch {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrVersionMismatch, err)
+	}
+
+	got, err := postgres.Delete(ctx, id, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want int64 = 1
+	if got != want {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+}
+
 func TestDelete(t *testing.T) {
-	postgres := &db.Postgres{setupDB()}
+	postgres := &db.Postgres{DB: setupDB()}
 	defer postgres.Close()
 
-	id, err := postgres.Insert(getTestTodo(0, "Test Todo"))
+	ctx := context.Background()
+
+	id, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	got, err := postgres.Delete(id)
+	got, err := postgres.Delete(ctx, id, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -115,24 +236,30 @@ func TestDelete(t *testing.T) {
 }
 
 func TestList(t *testing.T) {
-	postgres := &db.Postgres{setupDB()}
+	postgres := &db.Postgres{DB: setupDB()}
 	defer postgres.Close()
 
-	id1, err := postgres.Insert(getTestTodo(0, "Test Todo"))
+	ctx := context.Background()
+
+	id1, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	id2, err := postgres.Insert(getTestTodo(0, "Test Todo"))
+	id2, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	got, err := postgres.List()
+	got, next, err := postgres.List(ctx, &todolistpb.ListTodosRequest{})
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	if next != "" {
+		t.Fatalf("Want: no next page, Got: %v\n", next)
+	}
+
 	var want []*todolistpb.Todo
 	want = append(want, getTestTodo(id1, "Test Todo"))
 	want = append(want, getTestTodo(id2, "Test Todo"))
@@ -141,3 +268,433 @@ func TestList(t *testing.T) {
 		t.Fatalf("Want: %v, Got: %v\n", want, got)
 	}
 }
+
+func TestComplete(t *testing.T) {
+	postgres := &db.Postgres{DB: setupDB()}
+	defer postgres.Close()
+
+	ctx := context.Background()
+
+	id, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	completedAt, err := ptypes.TimestampProto(time.Date(2000, 1, 2, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := postgres.Complete(ctx, id, completedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(id, "Test Todo")
+	want.Status = todolistpb.Status_DONE
+	want.CompletedAt = completedAt
+	want.Version = 2
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	// completing again keeps the first completion time
+	got, err = postgres.Complete(ctx, id, ptypes.TimestampNow())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want.Version = 3
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	postgres := &db.Postgres{DB: setupDB()}
+	defer postgres.Close()
+
+	ctx := context.Background()
+
+	id, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := postgres.Complete(ctx, id, ptypes.TimestampNow()); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := postgres.Reopen(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(id, "Test Todo")
+	want.Version = 3
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+}
+
+func TestListFilter(t *testing.T) {
+	postgres := &db.Postgres{DB: setupDB()}
+	defer postgres.Close()
+
+	ctx := context.Background()
+
+	todo := getTestTodo(0, "Buy milk")
+	todo.Tags = []string{"home", "shopping"}
+	id1, err := postgres.Insert(ctx, todo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todo = getTestTodo(0, "Buy a car")
+	todo.Tags = []string{"shopping"}
+	if _, err := postgres.Insert(ctx, todo); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := postgres.Insert(ctx, getTestTodo(0, "Fix the milk bottle")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _, err := postgres.List(ctx, &todolistpb.ListTodosRequest{
+		TitleContains: "MILK",
+		Tags:          []string{"shopping"},
+		Status:        []todolistpb.Status{todolistpb.Status_OPEN},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(id1, "Buy milk")
+	want.Tags = []string{"home", "shopping"}
+
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+}
+
+func TestListPaging(t *testing.T) {
+	postgres := &db.Postgres{DB: setupDB()}
+	defer postgres.Close()
+
+	ctx := context.Background()
+
+	for _, title := range []string{"b", "a", "c", "a"} {
+		if _, err := postgres.Insert(ctx, getTestTodo(0, title)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// ordered by title descending, the ID breaks the ties
+	want := []int32{3, 1, 4, 2}
+
+	req := &todolistpb.ListTodosRequest{
+		SortBy:     todolistpb.ListTodosRequest_TITLE,
+		Descending: true,
+		PageSize:   3,
+	}
+
+	var got []int32
+	for {
+		todos, next, err := postgres.List(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, todo := range todos {
+			got = append(got, todo.GetId())
+		}
+
+		if next == "" {
+			break
+		}
+		req.PageToken = next
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+}
+
+func getTestTodoList(id int32, name string) *todolistpb.TodoList {
+	ca, err := ptypes.TimestampProto(time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		log.Fatalf("Could not convert timestamp: %v", err)
+	}
+
+	return &todolistpb.TodoList{
+		Id:          id,
+		Name:        name,
+		Description: "This is a test",
+		Color:       "#ffffff",
+		CreatedAt:   ca,
+	}
+}
+
+func TestTodoList(t *testing.T) {
+	postgres := &db.Postgres{DB: setupDB()}
+	defer postgres.Close()
+
+	ctx := context.Background()
+
+	id, err := postgres.InsertTodoList(ctx, getTestTodoList(0, "Test List"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := postgres.GetTodoList(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodoList(id, "Test List")
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	want = getTestTodoList(id, "Update Test List")
+	got, err = postgres.UpdateTodoList(ctx, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	lists, err := postgres.ListTodoLists(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(lists, []*todolistpb.TodoList{want}) {
+		t.Fatalf("Want: %v, Got: %v\n", want, lists)
+	}
+}
+
+func TestDeleteTodoList(t *testing.T) {
+	postgres := &db.Postgres{DB: setupDB()}
+	defer postgres.Close()
+
+	ctx := context.Background()
+
+	for _, deleteTodos := range []bool{false, true} {
+		listID, err := postgres.InsertTodoList(ctx, getTestTodoList(0, "Test List"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		todo := getTestTodo(0, "Test Todo")
+		todo.ListId = listID
+		id, err := postgres.Insert(ctx, todo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		count, err := postgres.DeleteTodoList(ctx, listID, deleteTodos)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 1 {
+			t.Fatalf("Want: 1, Got: %v\n", count)
+		}
+
+		got, err := postgres.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the todo is deleted together with the list or moved to the inbox
+		want := getTestTodo(id, "Test Todo")
+		if deleteTodos {
+			want = &todolistpb.Todo{}
+		}
+
+		if got.GetId() != want.GetId() || got.GetListId() != 0 {
+			t.Fatalf("Want: %v, Got: %v\n", want, got)
+		}
+	}
+}
+
+func TestPruneEvents(t *testing.T) {
+	conn := setupDB()
+	postgres := &db.Postgres{DB: conn}
+	ctx := context.Background()
+
+	if _, err := postgres.Insert(ctx, getTestTodo(0, "Test Todo")); err != nil {
+		t.Fatal(err)
+	}
+
+	var id int64
+	if err := conn.QueryRowContext(ctx, "SELECT MAX(id) FROM todo_event;").Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+	token := strconv.FormatInt(id, 10)
+
+	p := &db.EventPruner{Log: postgres, Retention: time.Hour}
+
+	// the event is kept until the retention passes
+	for _, tt := range []struct {
+		now  time.Time
+		want int64
+	}{
+		{time.Now(), 0},
+		{time.Now().Add(2 * time.Hour), 1},
+	} {
+		got, err := p.Prune(ctx, tt.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("Want: %v, Got: %v\n", tt.want, got)
+		}
+	}
+
+	// the watch can not be resumed from the pruned event, it is reported as OUT_OF_RANGE by the server
+	err := postgres.Watch(ctx, token, func(*db.Event) error { return nil })
+	if !errors.Is(err, db.ErrResumeTokenExpired) {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrResumeTokenExpired, err)
+	}
+}
+
+// watchEvents is watching the events after the resume token until the context is canceled
+func watchEvents(ctx context.Context, t *testing.T, postgres *db.Postgres, token string) <-chan *db.Event {
+	events := make(chan *db.Event, 10)
+
+	go func() {
+		defer close(events)
+		err := postgres.Watch(ctx, token, func(e *db.Event) error {
+			events <- e
+			return nil
+		})
+		if err != nil && ctx.Err() == nil {
+			t.Error(err)
+		}
+	}()
+
+	return events
+}
+
+// checkTitles is checking the titles of the next events
+func checkTitles(t *testing.T, events <-chan *db.Event, titles ...string) {
+	t.Helper()
+
+	for _, title := range titles {
+		select {
+		case e := <-events:
+			if e.Todo.GetTitle() != title {
+				t.Fatalf("Want: %v, Got: %v\n", title, e.Todo)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Want: %v, Got: timeout\n", title)
+		}
+	}
+}
+
+func TestWatchGap(t *testing.T) {
+	conn := setupDB()
+	postgres := &db.Postgres{DB: conn}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, err := postgres.Insert(ctx, getTestTodo(0, "First"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := postgres.Insert(ctx, getTestTodo(0, "Second"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var id int64
+	if err := conn.QueryRowContext(ctx, "SELECT MAX(id) FROM todo_event;").Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+	events := watchEvents(ctx, t, postgres, strconv.FormatInt(id, 10))
+
+	// the event of the transaction in progress has a lower ID than the committed one after it,
+	// and the rolled back transaction leaves a gap
+	late, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer late.Rollback()
+	if _, err := late.ExecContext(ctx, "UPDATE todo SET title = 'Late' WHERE id = $1;", first); err != nil {
+		t.Fatal(err)
+	}
+
+	rolledBack, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rolledBack.ExecContext(ctx, "UPDATE todo SET title = 'Rolled back' WHERE id = $1;", second); err != nil {
+		t.Fatal(err)
+	}
+	if err := rolledBack.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := postgres.Insert(ctx, getTestTodo(0, "Third")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case e := <-events:
+		t.Fatalf("Want: no event before the commit, Got: %v\n", e.Todo)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := late.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	checkTitles(t, events, "Late", "Third")
+}
+
+func TestWatchStartInProgress(t *testing.T) {
+	conn := setupDB()
+	postgres := &db.Postgres{DB: conn}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, err := postgres.Insert(ctx, getTestTodo(0, "First"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	late, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer late.Rollback()
+	if _, err := late.ExecContext(ctx, "UPDATE todo SET title = 'Late' WHERE id = $1;", first); err != nil {
+		t.Fatal(err)
+	}
+
+	// the watch starts after the committed event, but the event of the transaction in progress
+	// has a lower ID and it is committed after the start
+	if _, err := postgres.Insert(ctx, getTestTodo(0, "Before")); err != nil {
+		t.Fatal(err)
+	}
+	events := watchEvents(ctx, t, postgres, "")
+
+	time.Sleep(100 * time.Millisecond)
+	if err := late.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := postgres.Insert(ctx, getTestTodo(0, "After")); err != nil {
+		t.Fatal(err)
+	}
+
+	checkTitles(t, events, "Late", "After")
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const defaultPruneInterval = time.Hour
+
+// EventLog is implemented by the watchers recording the changes of the todos in the database
+// to resume the watches
+type EventLog interface {
+	// PruneEvents is deleting the changes recorded before the time, it returns with the number
+	// of the deleted changes
+	PruneEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EventPruner is deleting the recorded changes of the todos when they are older than the retention,
+// the watches can not be resumed from the deleted changes. The changes are pruned by every instance
+// running a pruner.
+type EventPruner struct {
+	Log EventLog
+	// Retention is the time the changes are kept
+	Retention time.Duration
+
+	// Interval is the time between the prunes, 1h if 0
+	Interval time.Duration
+}
+
+// Run is pruning the changes on start and then periodically until the context is done
+func (p *EventPruner) Run(ctx context.Context) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = defaultPruneInterval
+	}
+
+	for {
+		count, err := p.Prune(ctx, time.Now())
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			log.Printf("Could not prune the todo events: %v", err)
+		} else if count > 0 {
+			log.Printf("Pruned %v todo events", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Prune is deleting the changes recorded before now minus the retention
+func (p *EventPruner) Prune(ctx context.Context, now time.Time) (int64, error) {
+	return p.Log.PruneEvents(ctx, now.Add(-p.Retention))
+}
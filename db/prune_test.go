@@ -0,0 +1,35 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/halimi/todo-list-service/db"
+)
+
+// eventLog is recording the times of the prunes
+type eventLog struct {
+	before []time.Time
+}
+
+func (l *eventLog) PruneEvents(ctx context.Context, before time.Time) (int64, error) {
+	l.before = append(l.before, before)
+	return 1, nil
+}
+
+func TestEventPruner(t *testing.T) {
+	log := &eventLog{}
+	p := &db.EventPruner{Log: log, Retention: time.Hour}
+	now := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, err := p.Prune(context.Background(), now); err != nil {
+		t.Fatal(err)
+	}
+
+	// the events recorded before the retention are pruned
+	if len(log.before) != 1 || !log.before[0].Equal(now.Add(-time.Hour)) {
+		t.Fatalf("Want: %v, Got: %v\n", now.Add(-time.Hour), log.before)
+	}
+
+}
@@ -2,58 +2,36 @@ package db
 
 import (
 	"context"
+	"errors"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/halimi/todo-list-service/todolistpb"
 )
 
-const keyRepository = "Repository"
+// ErrVersionMismatch is returned when the todo was not changed because it has a different version than expected
+var ErrVersionMismatch = errors.New("version mismatch")
 
-// Repository interface
+// Repository interface, the context of every call is cancelling the database operation
 type Repository interface {
 	Close() error
-	Insert(*todolistpb.Todo) (int32, error)
-	Get(int32) (*todolistpb.Todo, error)
-	Update(*todolistpb.Todo) (*todolistpb.Todo, error)
-	Delete(int32) (int64, error)
-	List() ([]*todolistpb.Todo, error)
-}
-
-// SetRepository sets the repository
-func SetRepository(ctx context.Context, repository Repository) context.Context {
-	return context.WithValue(ctx, keyRepository, repository)
-}
-
-// getRepository returns with the repository
-func getRepository(ctx context.Context) Repository {
-	return ctx.Value(keyRepository).(Repository)
-}
-
-// Close is closing the database connection
-func Close(ctx context.Context) error {
-	return getRepository(ctx).Close()
-}
-
-// Insert is inserting the data to the database
-func Insert(ctx context.Context, todo *todolistpb.Todo) (int32, error) {
-	return getRepository(ctx).Insert(todo)
-}
-
-// Get is getting the data from the database
-func Get(ctx context.Context, id int32) (*todolistpb.Todo, error) {
-	return getRepository(ctx).Get(id)
-}
-
-// Update is updating the data in the database
-func Update(ctx context.Context, todo *todolistpb.Todo) (*todolistpb.Todo, error) {
-	return getRepository(ctx).Update(todo)
-}
-
-// Delete is deleting the data from the database
-func Delete(ctx context.Context, id int32) (int64, error) {
-	return getRepository(ctx).Delete(id)
-}
-
-// List is listing the data
-func List(ctx context.Context) ([]*todolistpb.Todo, error) {
-	return getRepository(ctx).List()
+	Insert(context.Context, *todolistpb.Todo) (int32, error)
+	Get(context.Context, int32) (*todolistpb.Todo, error)
+	Update(context.Context, *todolistpb.Todo, []string, int64) (*todolistpb.Todo, error)
+	Delete(context.Context, int32, int64) (int64, error)
+	List(context.Context, *todolistpb.ListTodosRequest) ([]*todolistpb.Todo, string, error)
+	Complete(context.Context, int32, *timestamp.Timestamp) (*todolistpb.Todo, error)
+	Reopen(context.Context, int32) (*todolistpb.Todo, error)
+	Restore(context.Context, int32) (*todolistpb.Todo, error)
+	// GetTrashed returns an empty todo if it is not in the trash
+	GetTrashed(context.Context, int32) (*todolistpb.Todo, error)
+	ListTrash(context.Context) ([]*todolistpb.Todo, error)
+	Purge(context.Context, *timestamp.Timestamp) (int64, error)
+	// CountTodos returns with the number of the open and in progress todos and the number of them
+	// which are due before the time
+	CountTodos(ctx context.Context, dueBefore *timestamp.Timestamp) (open int64, overdue int64, err error)
+	InsertTodoList(context.Context, *todolistpb.TodoList) (int32, error)
+	GetTodoList(context.Context, int32) (*todolistpb.TodoList, error)
+	UpdateTodoList(context.Context, *todolistpb.TodoList) (*todolistpb.TodoList, error)
+	DeleteTodoList(context.Context, int32, bool) (int64, error)
+	ListTodoLists(context.Context) ([]*todolistpb.TodoList, error)
 }
@@ -0,0 +1,1537 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"modernc.org/sqlite"
+)
+
+func init() {
+	// the built-in lower function of SQLite only knows ASCII
+	sqlite.MustRegisterDeterministicScalarFunction("unicode_lower", 1, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		if s, ok := args[0].(string); ok {
+			return strings.ToLower(s), nil
+		}
+
+		return args[0], nil
+	})
+}
+
+// SQLiteConfig holds the configs
+type SQLiteConfig struct {
+	// Path is the database file or :memory:
+	Path string
+}
+
+// SQLite sql interface
+type SQLite struct {
+	DB *sql.DB
+}
+
+// Close is closing the database connection
+func (s *SQLite) Close() error {
+	return s.DB.Close()
+}
+
+// Insert is inserting the data to the database
+func (s *SQLite) Insert(ctx context.Context, todo *todolistpb.Todo) (int32, error) {
+	query := `
+	INSERT INTO todo (title, note, due_date, status, completed_at, tags, priority, created_at, list_id, owner)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING id;
+	`
+
+	ts, err := sqliteTime(todo.GetDueDate())
+	if err != nil {
+		return -1, err
+	}
+
+	completedAt, err := sqliteNullTime(todo.GetCompletedAt())
+	if err != nil {
+		return -1, err
+	}
+
+	createdAt := time.Now().Round(time.Microsecond).UnixMicro()
+	if todo.GetCreatedAt() != nil {
+		if createdAt, err = sqliteTime(todo.GetCreatedAt()); err != nil {
+			return -1, err
+		}
+	}
+
+	tags, err := tagsJSON(todo.GetTags())
+	if err != nil {
+		return -1, err
+	}
+
+	owner, _ := TenantFromContext(ctx)
+
+	var id int32
+	err = s.audited(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, query, todo.GetTitle(), todo.GetNote(), ts, todo.GetStatus().String(), completedAt,
+			tags, todo.GetPriority(), createdAt, nullID(todo.GetListId()), owner).Scan(&id)
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+// Get is getting the data from the database, the todos of the trash are not found
+func (s *SQLite) Get(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	query := `
+	SELECT id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner
+	FROM todo
+	WHERE id = $1 AND deleted_at IS NULL AND ($2 IS NULL OR owner = $2);
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, id, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneSQLiteTodo(rows)
+}
+
+// Update is updating the fields of the paths in the database, if the expected version is not 0
+// it returns ErrVersionMismatch when the todo has a different version
+func (s *SQLite) Update(ctx context.Context, todo *todolistpb.Todo, paths []string, expectedVersion int64) (*todolistpb.Todo, error) {
+	if len(paths) == 0 {
+		return s.Get(ctx, todo.GetId())
+	}
+
+	var set []string
+	var args queryArgs
+	for _, path := range paths {
+		switch path {
+		case "title":
+			set = append(set, "title = "+args.add(todo.GetTitle()))
+		case "note":
+			set = append(set, "note = "+args.add(todo.GetNote()))
+		case "due_date":
+			ts, err := sqliteTime(todo.GetDueDate())
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, "due_date = "+args.add(ts))
+		case "status":
+			completedAt, err := sqliteNullTime(todo.GetCompletedAt())
+			if err != nil {
+				return nil, err
+			}
+			// the completion time is kept if the todo was already done
+			st := args.add(todo.GetStatus().String())
+			set = append(set, "status = "+st,
+				"completed_at = CASE WHEN "+st+" = 'DONE' THEN COALESCE(completed_at, "+args.add(completedAt)+") END")
+		case "tags":
+			tags, err := tagsJSON(todo.GetTags())
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, "tags = "+args.add(tags))
+		case "priority":
+			set = append(set, "priority = "+args.add(todo.GetPriority()))
+		case "list_id":
+			set = append(set, "list_id = "+args.add(nullID(todo.GetListId())))
+		default:
+			return nil, fmt.Errorf("unknown update path: %v", path)
+		}
+	}
+
+	set = append(set, "version = version + 1")
+
+	where := "id = " + args.add(todo.GetId()) + " AND deleted_at IS NULL"
+	if owner := ownerArg(ctx); owner.Valid {
+		where += " AND owner = " + args.add(owner.String)
+	}
+	if expectedVersion != 0 {
+		where += " AND version = " + args.add(expectedVersion)
+	}
+
+	query := fmt.Sprintf(`
+	UPDATE todo
+	SET %v
+	WHERE %v
+	RETURNING id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner;
+	`, strings.Join(set, ", "), where)
+
+	t, err := s.queryTodo(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.GetId() == 0 && expectedVersion != 0 {
+		return t, s.checkVersion(ctx, todo.GetId())
+	}
+
+	return t, nil
+}
+
+// Complete is setting the todo to done, the completion time is kept if it was already done
+func (s *SQLite) Complete(ctx context.Context, id int32, completedAt *timestamp.Timestamp) (*todolistpb.Todo, error) {
+	query := `
+	UPDATE todo
+	SET status = 'DONE',
+		completed_at = CASE WHEN status = 'DONE' THEN completed_at ELSE $1 END,
+		version = version + 1
+	WHERE id = $2 AND deleted_at IS NULL AND ($3 IS NULL OR owner = $3)
+	RETURNING id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner;
+	`
+
+	ts, err := sqliteTime(completedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.queryTodo(ctx, query, ts, id, ownerArg(ctx))
+}
+
+// Reopen is setting the todo to open and clears the completion time
+func (s *SQLite) Reopen(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	query := `
+	UPDATE todo
+	SET status = 'OPEN', completed_at = NULL, version = version + 1
+	WHERE id = $1 AND deleted_at IS NULL AND ($2 IS NULL OR owner = $2)
+	RETURNING id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner;
+	`
+
+	return s.queryTodo(ctx, query, id, ownerArg(ctx))
+}
+
+// Delete is moving the todo to the trash, if the expected version is not 0
+// it returns ErrVersionMismatch when the todo has a different version
+func (s *SQLite) Delete(ctx context.Context, id int32, expectedVersion int64) (int64, error) {
+	query := `
+	UPDATE todo
+	SET deleted_at = $1
+	WHERE id = $2 AND deleted_at IS NULL AND ($3 = 0 OR version = $3) AND ($4 IS NULL OR owner = $4);
+	`
+
+	deletedAt := time.Now().Round(time.Microsecond).UnixMicro()
+	var count int64
+	err := s.audited(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, deletedAt, id, expectedVersion, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+
+		count, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	if count == 0 && expectedVersion != 0 {
+		return count, s.checkVersion(ctx, id)
+	}
+
+	return count, nil
+}
+
+// Restore is moving the todo back from the trash, it returns an empty todo if it is not in the trash
+func (s *SQLite) Restore(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	query := `
+	UPDATE todo
+	SET deleted_at = NULL
+	WHERE id = $1 AND deleted_at IS NOT NULL AND ($2 IS NULL OR owner = $2)
+	RETURNING id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner;
+	`
+
+	return s.queryTodo(ctx, query, id, ownerArg(ctx))
+}
+
+// GetTrashed is getting the todo from the trash, it returns an empty todo if it is not in the trash
+func (s *SQLite) GetTrashed(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	query := `
+	SELECT id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner
+	FROM todo
+	WHERE id = $1 AND deleted_at IS NOT NULL AND ($2 IS NULL OR owner = $2);
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, id, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneSQLiteTodo(rows)
+}
+
+// ListTrash is listing the todos of the trash, the last deleted first
+func (s *SQLite) ListTrash(ctx context.Context) ([]*todolistpb.Todo, error) {
+	query := `
+	SELECT id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner
+	FROM todo
+	WHERE deleted_at IS NOT NULL AND ($1 IS NULL OR owner = $1)
+	ORDER BY deleted_at DESC, id DESC;
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var todoList []*todolistpb.Todo
+	for rows.Next() {
+		t, err := scanSQLiteTodo(rows)
+		if err != nil {
+			return nil, err
+		}
+		todoList = append(todoList, t)
+	}
+
+	return todoList, rows.Err()
+}
+
+// Purge is deleting the todos of the trash permanently which were deleted before the time,
+// or all of them if the time is nil
+func (s *SQLite) Purge(ctx context.Context, deletedBefore *timestamp.Timestamp) (int64, error) {
+	query := `
+	DELETE FROM todo
+	WHERE deleted_at IS NOT NULL AND ($1 IS NULL OR deleted_at < $1) AND ($2 IS NULL OR owner = $2);
+	`
+
+	before, err := sqliteNullTime(deletedBefore)
+	if err != nil {
+		return -1, err
+	}
+
+	res, err := s.DB.ExecContext(ctx, query, before, ownerArg(ctx))
+	if err != nil {
+		return -1, err
+	}
+
+	return res.RowsAffected()
+}
+
+// CountTodos is counting the open and in progress todos and the ones due before the time
+func (s *SQLite) CountTodos(ctx context.Context, dueBefore *timestamp.Timestamp) (int64, int64, error) {
+	query := `
+	SELECT COUNT(*), COUNT(CASE WHEN due_date < $1 THEN 1 END)
+	FROM todo
+	WHERE status IN ('OPEN', 'IN_PROGRESS') AND deleted_at IS NULL AND ($2 IS NULL OR owner = $2);
+	`
+
+	before, err := sqliteTime(dueBefore)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	var open, overdue int64
+	if err := s.DB.QueryRowContext(ctx, query, before, ownerArg(ctx)).Scan(&open, &overdue); err != nil {
+		return -1, -1, err
+	}
+
+	return open, overdue, nil
+}
+
+// audited is running the function in a transaction, the audit of the context is recorded
+// in the history of the todos changed by it
+func (s *SQLite) audited(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// the audit_context row is read by the todo_history triggers, it is removed before the commit
+	audit := AuditFromContext(ctx)
+	query := "INSERT OR REPLACE INTO audit_context (id, actor, request_id) VALUES (1, $1, $2);"
+	if _, err := tx.ExecContext(ctx, query, audit.Actor, audit.RequestID); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM audit_context;"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// queryTodo is changing the todo in an audited transaction, it returns an empty todo if there was no row
+func (s *SQLite) queryTodo(ctx context.Context, query string, args ...interface{}) (*todolistpb.Todo, error) {
+	var t *todolistpb.Todo
+	err := s.audited(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		t, err = scanOneSQLiteTodo(rows)
+		return err
+	})
+
+	return t, err
+}
+
+// checkVersion is called when nothing was changed with an expected version,
+// it returns ErrVersionMismatch if the todo exists, so it must have a different version
+func (s *SQLite) checkVersion(ctx context.Context, id int32) error {
+	t, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if t.GetId() != 0 {
+		return ErrVersionMismatch
+	}
+
+	return nil
+}
+
+// sqliteSortColumns are the columns of the sort orders, the titles are compared byte-wise
+// by the default BINARY collation
+var sqliteSortColumns = map[todolistpb.ListTodosRequest_SortBy]string{
+	todolistpb.ListTodosRequest_ID:         "id",
+	todolistpb.ListTodosRequest_DUE_DATE:   "due_date",
+	todolistpb.ListTodosRequest_CREATED_AT: "created_at",
+	todolistpb.ListTodosRequest_TITLE:      "title",
+	todolistpb.ListTodosRequest_PRIORITY:   "priority",
+}
+
+// List is listing the data matching the filters in the sort order of the request,
+// it returns with one page of the data and the token of the next page
+func (s *SQLite) List(ctx context.Context, req *todolistpb.ListTodosRequest) ([]*todolistpb.Todo, string, error) {
+	token, err := parsePageToken(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the todos of the trash are not listed
+	where := []string{"deleted_at IS NULL"}
+	var args queryArgs
+
+	if owner := ownerArg(ctx); owner.Valid {
+		where = append(where, "owner = "+args.add(owner.String))
+	}
+
+	if len(req.GetStatus()) > 0 {
+		var statuses []string
+		for _, st := range req.GetStatus() {
+			statuses = append(statuses, st.String())
+		}
+		b, err := json.Marshal(statuses)
+		if err != nil {
+			return nil, "", err
+		}
+		where = append(where, "status IN (SELECT value FROM json_each("+args.add(string(b))+"))")
+	}
+
+	if req.GetDueBefore() != nil {
+		ts, err := sqliteTime(req.GetDueBefore())
+		if err != nil {
+			return nil, "", err
+		}
+		where = append(where, "due_date < "+args.add(ts))
+	}
+
+	if req.GetDueAfter() != nil {
+		ts, err := sqliteTime(req.GetDueAfter())
+		if err != nil {
+			return nil, "", err
+		}
+		where = append(where, "due_date > "+args.add(ts))
+	}
+
+	if req.GetTitleContains() != "" {
+		where = append(where, "instr(unicode_lower(title), unicode_lower("+args.add(req.GetTitleContains())+")) > 0")
+	}
+
+	if len(req.GetTags()) > 0 {
+		tags, err := tagsJSON(req.GetTags())
+		if err != nil {
+			return nil, "", err
+		}
+		where = append(where, "NOT EXISTS (SELECT 1 FROM json_each("+args.add(tags)+
+			") AS f WHERE f.value NOT IN (SELECT value FROM json_each(todo.tags)))")
+	}
+
+	if req.GetListId() != 0 {
+		where = append(where, "list_id = "+args.add(req.GetListId()))
+	}
+
+	if req.GetInbox() {
+		where = append(where, "list_id IS NULL")
+	}
+
+	column := sqliteSortColumns[req.GetSortBy()]
+	direction, cmp := "ASC", ">"
+	if req.GetDescending() {
+		direction, cmp = "DESC", "<"
+	}
+
+	if token != nil {
+		value := token.sortValue()
+		if t, ok := value.(time.Time); ok {
+			value = t.Round(time.Microsecond).UnixMicro()
+		}
+		where = append(where, fmt.Sprintf("(%v, id) %v (%v, %v)", column, cmp, args.add(value), args.add(token.ID)))
+	}
+
+	query := `
+	SELECT id, title, note, due_date, status, completed_at, tags, priority, created_at, list_id, version, deleted_at, owner
+	FROM todo
+	WHERE ` + strings.Join(where, " AND ") + "\n\t"
+	query += fmt.Sprintf("ORDER BY %v %v, id %v\n\tLIMIT %v;", column, direction, direction, args.add(PageSize(req)+1))
+
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var todoList []*todolistpb.Todo
+	for rows.Next() {
+		t, err := scanSQLiteTodo(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		todoList = append(todoList, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return nextPage(req, todoList)
+}
+
+// InsertTodoList is inserting the list to the database
+func (s *SQLite) InsertTodoList(ctx context.Context, list *todolistpb.TodoList) (int32, error) {
+	query := `
+	INSERT INTO todo_list (name, description, color, created_at, owner)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id;
+	`
+
+	createdAt := time.Now().Round(time.Microsecond).UnixMicro()
+	if list.GetCreatedAt() != nil {
+		var err error
+		if createdAt, err = sqliteTime(list.GetCreatedAt()); err != nil {
+			return -1, err
+		}
+	}
+
+	owner, _ := TenantFromContext(ctx)
+
+	rows, err := s.DB.QueryContext(ctx, query, list.GetName(), list.GetDescription(), list.GetColor(), createdAt, owner)
+	if err != nil {
+		return -1, err
+	}
+	defer rows.Close()
+
+	var id int32
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return -1, err
+		}
+	}
+
+	return id, rows.Err()
+}
+
+// GetTodoList is getting the list from the database
+func (s *SQLite) GetTodoList(ctx context.Context, id int32) (*todolistpb.TodoList, error) {
+	query := `
+	SELECT id, name, description, color, created_at, owner
+	FROM todo_list
+	WHERE id = $1 AND ($2 IS NULL OR owner = $2);
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, id, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	l := &todolistpb.TodoList{}
+	for rows.Next() {
+		if l, err = scanSQLiteTodoList(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, rows.Err()
+}
+
+// UpdateTodoList is updating the list in the database
+func (s *SQLite) UpdateTodoList(ctx context.Context, list *todolistpb.TodoList) (*todolistpb.TodoList, error) {
+	query := `
+	UPDATE todo_list
+	SET name = $1, description = $2, color = $3
+	WHERE id = $4 AND ($5 IS NULL OR owner = $5)
+	RETURNING id, name, description, color, created_at, owner;
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, list.GetName(), list.GetDescription(), list.GetColor(), list.GetId(), ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	l := &todolistpb.TodoList{}
+	for rows.Next() {
+		if l, err = scanSQLiteTodoList(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, rows.Err()
+}
+
+// DeleteTodoList is deleting the list from the database, its todos are moved to the trash
+// or to the inbox
+func (s *SQLite) DeleteTodoList(ctx context.Context, id int32, deleteTodos bool) (int64, error) {
+	var count int64
+	err := s.audited(ctx, func(tx *sql.Tx) error {
+		if deleteTodos {
+			query := `
+			UPDATE todo
+			SET deleted_at = $1
+			WHERE list_id = $2 AND deleted_at IS NULL AND ($3 IS NULL OR owner = $3);
+			`
+
+			deletedAt := time.Now().Round(time.Microsecond).UnixMicro()
+			if _, err := tx.ExecContext(ctx, query, deletedAt, id, ownerArg(ctx)); err != nil {
+				return err
+			}
+		}
+
+		// the todos, the trashed ones too, are moved to the inbox by the foreign key
+		query := `
+		DELETE FROM todo_list
+		WHERE id = $1 AND ($2 IS NULL OR owner = $2);
+		`
+
+		res, err := tx.ExecContext(ctx, query, id, ownerArg(ctx))
+		if err != nil {
+			return err
+		}
+
+		count, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return count, nil
+}
+
+// ListTodoLists is listing the lists
+func (s *SQLite) ListTodoLists(ctx context.Context) ([]*todolistpb.TodoList, error) {
+	query := `
+	SELECT id, name, description, color, created_at, owner
+	FROM todo_list
+	WHERE $1 IS NULL OR owner = $1
+	ORDER BY id;
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lists []*todolistpb.TodoList
+	for rows.Next() {
+		l, err := scanSQLiteTodoList(rows)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, l)
+	}
+
+	return lists, rows.Err()
+}
+
+// PutListMember is inserting the member to the database or changing its role
+func (s *SQLite) PutListMember(ctx context.Context, member *todolistpb.ListMember) (*todolistpb.ListMember, error) {
+	query := `
+	INSERT INTO list_member (list_id, subject, role, created_at)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (list_id, subject) DO UPDATE SET role = excluded.role
+	RETURNING list_id, subject, role, created_at;
+	`
+
+	createdAt := time.Now().Round(time.Microsecond).UnixMicro()
+	if member.GetCreatedAt() != nil {
+		var err error
+		if createdAt, err = sqliteTime(member.GetCreatedAt()); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := s.DB.QueryContext(ctx, query, member.GetListId(), member.GetSubject(), member.GetRole().String(), createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneSQLiteListMember(rows)
+}
+
+// GetListMember is getting the member of the list from the database
+func (s *SQLite) GetListMember(ctx context.Context, listID int32, subject string) (*todolistpb.ListMember, error) {
+	query := `
+	SELECT list_id, subject, role, created_at
+	FROM list_member
+	WHERE list_id = $1 AND subject = $2;
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, listID, subject)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneSQLiteListMember(rows)
+}
+
+// DeleteListMember is deleting the member of the list from the database
+func (s *SQLite) DeleteListMember(ctx context.Context, listID int32, subject string) (int64, error) {
+	query := `
+	DELETE FROM list_member
+	WHERE list_id = $1 AND subject = $2;
+	`
+
+	res, err := s.DB.ExecContext(ctx, query, listID, subject)
+	if err != nil {
+		return -1, err
+	}
+
+	return res.RowsAffected()
+}
+
+// ListListMembers is listing the members of the list
+func (s *SQLite) ListListMembers(ctx context.Context, listID int32) ([]*todolistpb.ListMember, error) {
+	query := `
+	SELECT list_id, subject, role, created_at
+	FROM list_member
+	WHERE list_id = $1
+	ORDER BY subject;
+	`
+
+	return s.queryListMembers(ctx, query, listID)
+}
+
+// ListMemberships is listing the memberships of the subject
+func (s *SQLite) ListMemberships(ctx context.Context, subject string) ([]*todolistpb.ListMember, error) {
+	query := `
+	SELECT list_id, subject, role, created_at
+	FROM list_member
+	WHERE subject = $1
+	ORDER BY list_id;
+	`
+
+	return s.queryListMembers(ctx, query, subject)
+}
+
+// queryListMembers is reading the members returned by the query
+func (s *SQLite) queryListMembers(ctx context.Context, query string, args ...interface{}) ([]*todolistpb.ListMember, error) {
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*todolistpb.ListMember
+	for rows.Next() {
+		m, err := scanSQLiteListMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, rows.Err()
+}
+
+// ProcessOutbox is calling fn with the oldest events of the outbox, the events are not locked
+// so only one relay can process the outbox of a database
+func (s *SQLite) ProcessOutbox(ctx context.Context, limit int, fn func(*OutboxEvent) error) (int, error) {
+	query := `
+	SELECT id, type, todo, created_at
+	FROM outbox
+	ORDER BY id
+	LIMIT $1;
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var events []*OutboxEvent
+	for rows.Next() {
+		var e OutboxEvent
+		var eventType, todo string
+		var occurredAt int64
+		if err := rows.Scan(&e.ID, &eventType, &todo, &occurredAt); err != nil {
+			return 0, err
+		}
+
+		if e.Todo, err = decodeSQLiteEventTodo(todo); err != nil {
+			return 0, err
+		}
+		e.Type = todolistpb.WatchTodosResponse_EventType(todolistpb.WatchTodosResponse_EventType_value[eventType])
+		e.OccurredAt = time.UnixMicro(occurredAt)
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	// the only connection is released before calling fn
+	rows.Close()
+
+	var ids []string
+	var args queryArgs
+	var fnErr error
+	for _, e := range events {
+		if fnErr = fn(e); fnErr != nil {
+			break
+		}
+		ids = append(ids, args.add(e.ID))
+	}
+
+	if len(ids) > 0 {
+		query := fmt.Sprintf("DELETE FROM outbox WHERE id IN (%v);", strings.Join(ids, ", "))
+		if _, err := s.DB.ExecContext(ctx, query, args...); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ids), fnErr
+}
+
+// sqliteEventTodo is the row of the todo table as it is recorded by the triggers
+type sqliteEventTodo struct {
+	ID          int32    `json:"id"`
+	Title       string   `json:"title"`
+	Note        string   `json:"note"`
+	DueDate     int64    `json:"due_date"`
+	Status      string   `json:"status"`
+	CompletedAt *int64   `json:"completed_at"`
+	Tags        []string `json:"tags"`
+	Priority    int32    `json:"priority"`
+	CreatedAt   int64    `json:"created_at"`
+	ListID      int32    `json:"list_id"`
+	Version     int64    `json:"version"`
+	DeletedAt   *int64   `json:"deleted_at"`
+	Owner       string   `json:"owner"`
+}
+
+// decodeSQLiteEventTodo is converting the JSON row of the todo to a todo
+func decodeSQLiteEventTodo(s string) (*todolistpb.Todo, error) {
+	var row sqliteEventTodo
+	if err := json.Unmarshal([]byte(s), &row); err != nil {
+		return nil, err
+	}
+
+	t := &todolistpb.Todo{
+		Id:       row.ID,
+		Title:    row.Title,
+		Note:     row.Note,
+		Status:   todolistpb.Status(todolistpb.Status_value[row.Status]),
+		Tags:     row.Tags,
+		Priority: row.Priority,
+		ListId:   row.ListID,
+		Version:  row.Version,
+		Owner:    row.Owner,
+	}
+
+	if len(t.Tags) == 0 {
+		t.Tags = nil
+	}
+
+	var err error
+	t.DueDate, err = sqliteTimestamp(row.DueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	t.CreatedAt, err = sqliteTimestamp(row.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if row.CompletedAt != nil {
+		t.CompletedAt, err = sqliteTimestamp(*row.CompletedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if row.DeletedAt != nil {
+		t.DeletedAt, err = sqliteTimestamp(*row.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// InsertWebhook is inserting the webhook of the owner of the context to the database
+func (s *SQLite) InsertWebhook(ctx context.Context, webhook *todolistpb.Webhook) (int32, error) {
+	query := `
+	INSERT INTO webhook (url, event_types, secret, disabled, created_at, owner)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id;
+	`
+
+	eventTypes, err := tagsJSON(webhook.GetEventTypes())
+	if err != nil {
+		return -1, err
+	}
+
+	createdAt := time.Now().Round(time.Microsecond).UnixMicro()
+	if webhook.GetCreatedAt() != nil {
+		if createdAt, err = sqliteTime(webhook.GetCreatedAt()); err != nil {
+			return -1, err
+		}
+	}
+
+	owner, _ := TenantFromContext(ctx)
+
+	rows, err := s.DB.QueryContext(ctx, query, webhook.GetUrl(), eventTypes, webhook.GetSecret(), webhook.GetDisabled(), createdAt, owner)
+	if err != nil {
+		return -1, err
+	}
+	defer rows.Close()
+
+	var id int32
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return -1, err
+		}
+	}
+
+	return id, rows.Err()
+}
+
+// GetWebhook is getting the webhook from the database
+func (s *SQLite) GetWebhook(ctx context.Context, id int32) (*todolistpb.Webhook, error) {
+	query := `
+	SELECT id, url, event_types, secret, disabled, failure_count, created_at, owner
+	FROM webhook
+	WHERE id = $1 AND ($2 IS NULL OR owner = $2);
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, id, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneSQLiteWebhook(rows)
+}
+
+// UpdateWebhook is updating the webhook in the database, the secret is kept if it is empty
+func (s *SQLite) UpdateWebhook(ctx context.Context, webhook *todolistpb.Webhook) (*todolistpb.Webhook, error) {
+	query := `
+	UPDATE webhook
+	SET url = $1, event_types = $2, secret = COALESCE(NULLIF($3, ''), secret), disabled = $4, failure_count = 0
+	WHERE id = $5 AND ($6 IS NULL OR owner = $6)
+	RETURNING id, url, event_types, secret, disabled, failure_count, created_at, owner;
+	`
+
+	eventTypes, err := tagsJSON(webhook.GetEventTypes())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.DB.QueryContext(ctx, query, webhook.GetUrl(), eventTypes, webhook.GetSecret(), webhook.GetDisabled(), webhook.GetId(), ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneSQLiteWebhook(rows)
+}
+
+// DeleteWebhook is deleting the webhook from the database, its deliveries are deleted by the foreign key
+func (s *SQLite) DeleteWebhook(ctx context.Context, id int32) (int64, error) {
+	query := `
+	DELETE FROM webhook
+	WHERE id = $1 AND ($2 IS NULL OR owner = $2);
+	`
+
+	res, err := s.DB.ExecContext(ctx, query, id, ownerArg(ctx))
+	if err != nil {
+		return -1, err
+	}
+
+	return res.RowsAffected()
+}
+
+// ListWebhooks is listing the webhooks of the owner of the context
+func (s *SQLite) ListWebhooks(ctx context.Context) ([]*todolistpb.Webhook, error) {
+	query := `
+	SELECT id, url, event_types, secret, disabled, failure_count, created_at, owner
+	FROM webhook
+	WHERE ($1 IS NULL OR owner = $1)
+	ORDER BY id;
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*todolistpb.Webhook
+	for rows.Next() {
+		w, err := scanSQLiteWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// InsertWebhookDelivery is inserting the attempt of a delivery to the database, it returns 0 if the webhook does not exist
+func (s *SQLite) InsertWebhookDelivery(ctx context.Context, delivery *todolistpb.WebhookDelivery) (int64, error) {
+	query := `
+	INSERT INTO webhook_delivery (webhook_id, event_id, event_type, attempt, status_code, error, success, attempted_at)
+	SELECT id, $2, $3, $4, $5, $6, $7, $8
+	FROM webhook
+	WHERE id = $1 AND ($9 IS NULL OR owner = $9)
+	RETURNING id;
+	`
+
+	attemptedAt := time.Now().Round(time.Microsecond).UnixMicro()
+	if delivery.GetAttemptedAt() != nil {
+		var err error
+		if attemptedAt, err = sqliteTime(delivery.GetAttemptedAt()); err != nil {
+			return -1, err
+		}
+	}
+
+	rows, err := s.DB.QueryContext(ctx, query, delivery.GetWebhookId(), delivery.GetEventId(), delivery.GetEventType(),
+		delivery.GetAttempt(), delivery.GetStatusCode(), delivery.GetError(), delivery.GetSuccess(), attemptedAt, ownerArg(ctx))
+	if err != nil {
+		return -1, err
+	}
+	defer rows.Close()
+
+	var id int64
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return -1, err
+		}
+	}
+
+	return id, rows.Err()
+}
+
+// ListWebhookDeliveries is listing the last deliveries of the webhook, the newest first
+func (s *SQLite) ListWebhookDeliveries(ctx context.Context, webhookID int32, limit int) ([]*todolistpb.WebhookDelivery, error) {
+	query := `
+	SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.attempt, d.status_code, d.error, d.success, d.attempted_at
+	FROM webhook_delivery d
+	JOIN webhook w ON w.id = d.webhook_id
+	WHERE d.webhook_id = $1 AND ($3 IS NULL OR w.owner = $3)
+	ORDER BY d.id DESC
+	LIMIT $2;
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, webhookID, limit, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*todolistpb.WebhookDelivery
+	for rows.Next() {
+		var d todolistpb.WebhookDelivery
+		var attemptedAt int64
+
+		if err := rows.Scan(&d.Id, &d.WebhookId, &d.EventId, &d.EventType, &d.Attempt, &d.StatusCode, &d.Error, &d.Success, &attemptedAt); err != nil {
+			return nil, err
+		}
+
+		if d.AttemptedAt, err = sqliteTimestamp(attemptedAt); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, &d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// RecordWebhookResult is updating the failure count of the webhook, it is disabled when the count reaches disableAfter
+func (s *SQLite) RecordWebhookResult(ctx context.Context, id int32, success bool, disableAfter int32) (*todolistpb.Webhook, error) {
+	query := `
+	UPDATE webhook
+	SET failure_count = CASE WHEN $1 THEN 0 ELSE failure_count + 1 END,
+		disabled = disabled OR (NOT $1 AND $2 > 0 AND failure_count + 1 >= $2)
+	WHERE id = $3 AND ($4 IS NULL OR owner = $4)
+	RETURNING id, url, event_types, secret, disabled, failure_count, created_at, owner;
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, success, disableAfter, id, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanOneSQLiteWebhook(rows)
+}
+
+// EnqueueWebhookDelivery is queueing the message to the webhook, it returns 0 if the webhook does not exist
+// or the event is already queued to it
+func (s *SQLite) EnqueueWebhookDelivery(ctx context.Context, delivery *QueuedDelivery) (int64, error) {
+	query := `
+	INSERT INTO webhook_queue (webhook_id, event_id, event_type, body, next_attempt_at)
+	SELECT id, $2, $3, $4, $5
+	FROM webhook
+	WHERE id = $1 AND ($6 IS NULL OR owner = $6)
+	ON CONFLICT (webhook_id, event_id) WHERE event_id <> 0 DO NOTHING
+	RETURNING id;
+	`
+
+	nextAttemptAt := time.Now().UnixMicro()
+	if !delivery.NextAttemptAt.IsZero() {
+		nextAttemptAt = delivery.NextAttemptAt.UnixMicro()
+	}
+
+	rows, err := s.DB.QueryContext(ctx, query, delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.Body, nextAttemptAt, ownerArg(ctx))
+	if err != nil {
+		return -1, err
+	}
+	defer rows.Close()
+
+	var id int64
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return -1, err
+		}
+	}
+
+	return id, rows.Err()
+}
+
+// ClaimWebhookDeliveries is claiming the oldest due delivery of the webhooks for the lease
+func (s *SQLite) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*QueuedDelivery, error) {
+	query := `
+	UPDATE webhook_queue
+	SET next_attempt_at = $2 + $3
+	WHERE id IN (
+		SELECT q.id
+		FROM webhook_queue q
+		WHERE q.id = (SELECT MIN(id) FROM webhook_queue WHERE webhook_id = q.webhook_id) AND q.next_attempt_at <= $2
+		ORDER BY q.id
+		LIMIT $1
+	)
+	RETURNING id, webhook_id, event_id, event_type, body, attempt, next_attempt_at;
+	`
+
+	now := time.Now().UnixMicro()
+
+	rows, err := s.DB.QueryContext(ctx, query, limit, now, lease.Microseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*QueuedDelivery
+	for rows.Next() {
+		var d QueuedDelivery
+		var nextAttemptAt int64
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Body, &d.Attempt, &nextAttemptAt); err != nil {
+			return nil, err
+		}
+		d.NextAttemptAt = time.UnixMicro(nextAttemptAt)
+		deliveries = append(deliveries, &d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// RetryWebhookDelivery is setting the failed attempts and the next attempt of the queued delivery
+func (s *SQLite) RetryWebhookDelivery(ctx context.Context, id int64, attempt int32, nextAttemptAt time.Time) error {
+	query := `
+	UPDATE webhook_queue
+	SET attempt = $2, next_attempt_at = $3
+	WHERE id = $1;
+	`
+
+	_, err := s.DB.ExecContext(ctx, query, id, attempt, nextAttemptAt.Round(time.Microsecond).UnixMicro())
+	return err
+}
+
+// DequeueWebhookDelivery is deleting the delivery from the queue
+func (s *SQLite) DequeueWebhookDelivery(ctx context.Context, id int64) error {
+	_, err := s.DB.ExecContext(ctx, "DELETE FROM webhook_queue WHERE id = $1;", id)
+	return err
+}
+
+// ListTodoHistory is listing one page of the revisions of the todo, the newest first
+func (s *SQLite) ListTodoHistory(ctx context.Context, req *todolistpb.GetTodoHistoryRequest) ([]*todolistpb.TodoRevision, string, error) {
+	token, err := parseHistoryPageToken(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var before int64
+	if token != nil {
+		before = token.ID
+	}
+
+	query := `
+	SELECT h.id, h.todo_id, h.action, h.todo_before, h.todo_after, h.actor, h.request_id, h.changed_at
+	FROM todo_history h
+	JOIN todo t ON t.id = h.todo_id
+	WHERE h.todo_id = $1 AND ($2 = 0 OR h.id < $2) AND ($4 IS NULL OR t.owner = $4)
+	ORDER BY h.id DESC
+	LIMIT $3;
+	`
+
+	// one more revision is read to know if there is a next page
+	rows, err := s.DB.QueryContext(ctx, query, req.GetTodoId(), before, HistoryPageSize(req)+1, ownerArg(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var revisions []*todolistpb.TodoRevision
+	for rows.Next() {
+		r, err := scanSQLiteRevision(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		revisions = append(revisions, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	return nextHistoryPage(req, revisions)
+}
+
+// GetTodoRevision is getting the revision from the database
+func (s *SQLite) GetTodoRevision(ctx context.Context, id int64) (*todolistpb.TodoRevision, error) {
+	query := `
+	SELECT h.id, h.todo_id, h.action, h.todo_before, h.todo_after, h.actor, h.request_id, h.changed_at
+	FROM todo_history h
+	JOIN todo t ON t.id = h.todo_id
+	WHERE h.id = $1 AND ($2 IS NULL OR t.owner = $2);
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, id, ownerArg(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	r := &todolistpb.TodoRevision{}
+	for rows.Next() {
+		if r, err = scanSQLiteRevision(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, rows.Err()
+}
+
+// scanSQLiteRevision is reading the revision from the current row
+func scanSQLiteRevision(rows *sql.Rows) (*todolistpb.TodoRevision, error) {
+	var r todolistpb.TodoRevision
+	var action, after string
+	var before sql.NullString
+	var changedAt int64
+
+	if err := rows.Scan(&r.Id, &r.TodoId, &action, &before, &after, &r.Actor, &r.RequestId, &changedAt); err != nil {
+		return nil, err
+	}
+
+	r.Action = todolistpb.TodoRevision_Action(todolistpb.TodoRevision_Action_value[action])
+
+	var err error
+	if before.Valid {
+		if r.Before, err = decodeSQLiteEventTodo(before.String); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.After, err = decodeSQLiteEventTodo(after); err != nil {
+		return nil, err
+	}
+
+	if r.ChangedAt, err = sqliteTimestamp(changedAt); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// scanSQLiteTodoList is reading the list from the current row
+func scanSQLiteTodoList(rows *sql.Rows) (*todolistpb.TodoList, error) {
+	var l todolistpb.TodoList
+	var createdAt int64
+
+	if err := rows.Scan(&l.Id, &l.Name, &l.Description, &l.Color, &createdAt, &l.Owner); err != nil {
+		return nil, err
+	}
+
+	var err error
+	l.CreatedAt, err = sqliteTimestamp(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+// scanOneSQLiteListMember is reading the member from the result, it returns an empty member if there was no row
+func scanOneSQLiteListMember(rows *sql.Rows) (*todolistpb.ListMember, error) {
+	m := &todolistpb.ListMember{}
+	for rows.Next() {
+		var err error
+		if m, err = scanSQLiteListMember(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, rows.Err()
+}
+
+// scanSQLiteListMember is reading the member from the current row
+func scanSQLiteListMember(rows *sql.Rows) (*todolistpb.ListMember, error) {
+	var m todolistpb.ListMember
+	var role string
+	var createdAt int64
+
+	if err := rows.Scan(&m.ListId, &m.Subject, &role, &createdAt); err != nil {
+		return nil, err
+	}
+
+	m.Role = todolistpb.ListMember_Role(todolistpb.ListMember_Role_value[role])
+
+	var err error
+	m.CreatedAt, err = sqliteTimestamp(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// scanOneSQLiteWebhook is reading the webhook from the result, it returns an empty webhook if there was no row
+func scanOneSQLiteWebhook(rows *sql.Rows) (*todolistpb.Webhook, error) {
+	w := &todolistpb.Webhook{}
+	for rows.Next() {
+		var err error
+		if w, err = scanSQLiteWebhook(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, rows.Err()
+}
+
+// scanSQLiteWebhook is reading the webhook from the current row
+func scanSQLiteWebhook(rows *sql.Rows) (*todolistpb.Webhook, error) {
+	var w todolistpb.Webhook
+	var eventTypes string
+	var createdAt int64
+
+	if err := rows.Scan(&w.Id, &w.Url, &eventTypes, &w.Secret, &w.Disabled, &w.FailureCount, &createdAt, &w.Owner); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(eventTypes), &w.EventTypes); err != nil {
+		return nil, err
+	}
+
+	if len(w.EventTypes) == 0 {
+		w.EventTypes = nil
+	}
+
+	var err error
+	w.CreatedAt, err = sqliteTimestamp(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// scanOneSQLiteTodo is reading the todo from the result, it returns an empty todo if there was no row
+func scanOneSQLiteTodo(rows *sql.Rows) (*todolistpb.Todo, error) {
+	t := &todolistpb.Todo{}
+	for rows.Next() {
+		var err error
+		if t, err = scanSQLiteTodo(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, rows.Err()
+}
+
+// scanSQLiteTodo is reading the todo from the current row
+func scanSQLiteTodo(rows *sql.Rows) (*todolistpb.Todo, error) {
+	var t todolistpb.Todo
+	var ts int64
+	var status string
+	var completedAt sql.NullInt64
+	var tags string
+	var createdAt int64
+	var listID sql.NullInt32
+	var deletedAt sql.NullInt64
+
+	if err := rows.Scan(&t.Id, &t.Title, &t.Note, &ts, &status, &completedAt, &tags, &t.Priority, &createdAt, &listID, &t.Version, &deletedAt, &t.Owner); err != nil {
+		return nil, err
+	}
+
+	t.ListId = listID.Int32
+
+	var err error
+	t.DueDate, err = sqliteTimestamp(ts)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Status = todolistpb.Status(todolistpb.Status_value[status])
+
+	if err := json.Unmarshal([]byte(tags), &t.Tags); err != nil {
+		return nil, err
+	}
+
+	if len(t.Tags) == 0 {
+		t.Tags = nil
+	}
+
+	t.CreatedAt, err = sqliteTimestamp(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		t.CompletedAt, err = sqliteTimestamp(completedAt.Int64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if deletedAt.Valid {
+		t.DeletedAt, err = sqliteTimestamp(deletedAt.Int64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &t, nil
+}
+
+// sqliteTime is converting the timestamp to microseconds since the Unix epoch,
+// the same precision as Postgres has
+func sqliteTime(ts *timestamp.Timestamp) (int64, error) {
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Round(time.Microsecond).UnixMicro(), nil
+}
+
+// sqliteNullTime is converting the optional timestamp to a nullable database value
+func sqliteNullTime(ts *timestamp.Timestamp) (sql.NullInt64, error) {
+	if ts == nil {
+		return sql.NullInt64{}, nil
+	}
+
+	t, err := sqliteTime(ts)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	return sql.NullInt64{Int64: t, Valid: true}, nil
+}
+
+// sqliteTimestamp is converting the microseconds since the Unix epoch to a timestamp
+func sqliteTimestamp(us int64) (*timestamp.Timestamp, error) {
+	return ptypes.TimestampProto(time.UnixMicro(us))
+}
+
+// tagsJSON is encoding the tags as a JSON array, it is an empty array if there are no tags
+func tagsJSON(tags []string) (string, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	b, err := json.Marshal(tags)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// SetupSQLite the databse
+func SetupSQLite(c *SQLiteConfig) *sql.DB {
+	db, err := ConnectSQLite(c)
+	if err != nil {
+		log.Fatalf("Could not connect to the database: %v", err)
+	}
+
+	m, err := NewSQLiteMigrator(db)
+	if err != nil {
+		log.Fatalf("Could not load the migrations: %v", err)
+	}
+
+	applied, err := m.Up(context.Background())
+	if err != nil {
+		log.Fatalf("Could not migrate the database: %v", err)
+	}
+
+	for _, mig := range applied {
+		log.Printf("Applied migration %v_%v", mig.Version, mig.Name)
+	}
+
+	return db
+}
+
+// ConnectSQLite is opening a SQLite database
+func ConnectSQLite(c *SQLiteConfig) (*sql.DB, error) {
+	connStr := fmt.Sprintf("%v?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", c.Path)
+	db, err := sql.Open("sqlite", connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite has a single writer and every connection of :memory: would be a new database
+	db.SetMaxOpenConns(1)
+
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
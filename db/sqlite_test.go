@@ -0,0 +1,368 @@
+package db_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+func setupSQLite() *db.SQLite {
+	return &db.SQLite{DB: db.SetupSQLite(&db.SQLiteConfig{Path: ":memory:"})}
+}
+
+func TestSQLiteInsertGet(t *testing.T) {
+	sqlite := setupSQLite()
+	defer sqlite.Close()
+
+	ctx := context.Background()
+
+	id, err := sqlite.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != 1 {
+		t.Fatalf("Want: 1, Got: %v\n", id)
+	}
+
+	got, err := sqlite.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(1, "Test Todo")
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	got, err = sqlite.Get(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.GetId() != 0 {
+		t.Fatalf("Want: empty todo, Got: %v\n", got)
+	}
+}
+
+func TestSQLiteUpdate(t *testing.T) {
+	sqlite := setupSQLite()
+	defer sqlite.Close()
+
+	ctx := context.Background()
+
+	id, err := sqlite.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(id, "Update Test Todo")
+	want.Tags = []string{"home"}
+	want.Status = todolistpb.Status_DONE
+	want.CompletedAt = want.GetDueDate()
+
+	got, err := sqlite.Update(ctx, want, db.UpdatePaths, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want.Version = 2
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	// only the title is updated, the rest of the fields are kept
+	got, err = sqlite.Update(ctx, &todolistpb.Todo{Id: id, Title: "Renamed Test Todo"}, []string{"title"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want.Title = "Renamed Test Todo"
+	want.Version = 3
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	if _, err := sqlite.Update(ctx, want, db.UpdatePaths, 2); err != db.ErrVersionMismatch {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrVersionMismatch, err)
+	}
+}
+
+func TestSQLiteDelete(t *testing.T) {
+	sqlite := setupSQLite()
+	defer sqlite.Close()
+
+	ctx := context.Background()
+
+	id, err := sqlite.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sqlite.Delete(ctx, id, 2); err != db.ErrVersionMismatch {
+		t.Fatalf("Want: %v, Got: %v\n", db.ErrVersionMismatch, err)
+	}
+
+	for _, want := range []int64{1, 0} {
+		got, err := sqlite.Delete(ctx, id, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Fatalf("Want: %v, Got: %v\n", want, got)
+		}
+	}
+}
+
+func TestSQLiteCompleteReopen(t *testing.T) {
+	sqlite := setupSQLite()
+	defer sqlite.Close()
+
+	ctx := context.Background()
+
+	id, err := sqlite.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	completedAt, err := ptypes.TimestampProto(time.Date(2000, 1, 2, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sqlite.Complete(ctx, id, completedAt); err != nil {
+		t.Fatal(err)
+	}
+
+	// completing again keeps the first completion time
+	got, err := sqlite.Complete(ctx, id, ptypes.TimestampNow())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(id, "Test Todo")
+	want.Status = todolistpb.Status_DONE
+	want.CompletedAt = completedAt
+	want.Version = 3
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	got, err = sqlite.Reopen(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = getTestTodo(id, "Test Todo")
+	want.Version = 4
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+}
+
+func TestSQLiteListFilter(t *testing.T) {
+	sqlite := setupSQLite()
+	defer sqlite.Close()
+
+	ctx := context.Background()
+
+	todo := getTestTodo(0, "Buy Ärpel milk")
+	todo.Tags = []string{"home", "shopping"}
+	id1, err := sqlite.Insert(ctx, todo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todo = getTestTodo(0, "Buy a car")
+	todo.Tags = []string{"shopping"}
+	if _, err := sqlite.Insert(ctx, todo); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sqlite.Insert(ctx, getTestTodo(0, "Fix the ärpel bottle")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _, err := sqlite.List(ctx, &todolistpb.ListTodosRequest{
+		TitleContains: "äRPEL",
+		Tags:          []string{"shopping"},
+		Status:        []todolistpb.Status{todolistpb.Status_OPEN},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := getTestTodo(id1, "Buy Ärpel milk")
+	want.Tags = []string{"home", "shopping"}
+
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+}
+
+func TestSQLiteListPaging(t *testing.T) {
+	sqlite := setupSQLite()
+	defer sqlite.Close()
+
+	ctx := context.Background()
+
+	for i, title := range []string{"b", "a", "c", "a"} {
+		todo := getTestTodo(0, title)
+		todo.DueDate.Seconds += int64(i % 2)
+		if _, err := sqlite.Insert(ctx, todo); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		req  *todolistpb.ListTodosRequest
+		want []int32
+	}{
+		// ordered by title descending, the ID breaks the ties
+		{&todolistpb.ListTodosRequest{SortBy: todolistpb.ListTodosRequest_TITLE, Descending: true, PageSize: 3}, []int32{3, 1, 4, 2}},
+		{&todolistpb.ListTodosRequest{SortBy: todolistpb.ListTodosRequest_DUE_DATE, PageSize: 1}, []int32{1, 3, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		var got []int32
+		for {
+			todos, next, err := sqlite.List(ctx, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, todo := range todos {
+				got = append(got, todo.GetId())
+			}
+
+			if next == "" {
+				break
+			}
+			tt.req.PageToken = next
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("Want: %v, Got: %v\n", tt.want, got)
+		}
+	}
+}
+
+func TestSQLiteTodoList(t *testing.T) {
+	sqlite := setupSQLite()
+	defer sqlite.Close()
+
+	ctx := context.Background()
+
+	for _, deleteTodos := range []bool{false, true} {
+		listID, err := sqlite.InsertTodoList(ctx, getTestTodoList(0, "Test List"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := getTestTodoList(listID, "Update Test List")
+		got, err := sqlite.UpdateTodoList(ctx, want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Want: %v, Got: %v\n", want, got)
+		}
+
+		todo := getTestTodo(0, "Test Todo")
+		todo.ListId = listID
+		id, err := sqlite.Insert(ctx, todo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		count, err := sqlite.DeleteTodoList(ctx, listID, deleteTodos)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 1 {
+			t.Fatalf("Want: 1, Got: %v\n", count)
+		}
+
+		todo, err = sqlite.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the todo is deleted together with the list or moved to the inbox
+		wantID := id
+		if deleteTodos {
+			wantID = 0
+		}
+
+		if todo.GetId() != wantID || todo.GetListId() != 0 {
+			t.Fatalf("Want: %v, Got: %v\n", wantID, todo)
+		}
+	}
+
+	lists, err := sqlite.ListTodoLists(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lists) != 0 {
+		t.Fatalf("Want: no lists, Got: %v\n", lists)
+	}
+}
+
+func TestSQLiteMigrateDownUp(t *testing.T) {
+	sqlite := setupSQLite()
+	defer sqlite.Close()
+
+	ctx := context.Background()
+	m, err := db.NewSQLiteMigrator(sqlite.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest := m.Migrations[len(m.Migrations)-1]
+
+	rolledBack, err := m.Down(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rolledBack.Version != latest.Version {
+		t.Fatalf("Want: %v, Got: %v\n", latest.Version, rolledBack.Version)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statuses[len(statuses)-1].Applied {
+		t.Fatalf("Want: pending, Got: applied\n")
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 || applied[0].Version != latest.Version {
+		t.Fatalf("Want: %v, Got: %v\n", latest.Version, applied)
+	}
+
+	statuses, err = m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !statuses[len(statuses)-1].Applied || statuses[len(statuses)-1].AppliedAt.IsZero() {
+		t.Fatalf("Want: applied, Got: %v\n", statuses[len(statuses)-1])
+	}
+}
@@ -0,0 +1,36 @@
+package db
+
+import "context"
+
+// tenant is the owner of the todos and the lists the queries are scoped to
+type tenant struct {
+	owner string
+	all   bool
+}
+
+type tenantKey struct{}
+
+// WithTenant returns with a context scoping the repository calls to the todos and the lists of
+// the owner, the todos and the lists created with it are owned by the owner
+func WithTenant(ctx context.Context, owner string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant{owner: owner})
+}
+
+// WithAllTenants returns with a context not scoping the repository calls, it is used by the
+// background jobs working with the todos of every owner
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant{all: true})
+}
+
+// TenantFromContext returns with the owner of the context, all is true if the calls are not scoped.
+// The owner is empty if it was not set, it is the owner of everything without authentication.
+func TenantFromContext(ctx context.Context) (owner string, all bool) {
+	t, _ := ctx.Value(tenantKey{}).(tenant)
+	return t.owner, t.all
+}
+
+// OwnedBy returns true if the todos and the lists of the owner are visible with the context
+func OwnedBy(ctx context.Context, owner string) bool {
+	tenantOwner, all := TenantFromContext(ctx)
+	return all || owner == tenantOwner
+}
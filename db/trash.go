@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+const defaultPurgeInterval = time.Hour
+
+// Purger is deleting the todos permanently when they are in the trash for longer than the
+// retention, the todos are purged by every instance running a purger.
+type Purger struct {
+	Repo Repository
+	// Retention is the time the todos are kept in the trash
+	Retention time.Duration
+
+	// Interval is the time between the purges, 1h if 0
+	Interval time.Duration
+}
+
+// Run is purging the trash on start and then periodically until the context is done
+func (p *Purger) Run(ctx context.Context) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = defaultPurgeInterval
+	}
+
+	for {
+		count, err := p.Purge(ctx, time.Now())
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			log.Printf("Could not purge the trash: %v", err)
+		} else if count > 0 {
+			log.Printf("Purged %v todos from the trash", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Purge is deleting the todos of every owner which were moved to the trash before now minus the retention
+func (p *Purger) Purge(ctx context.Context, now time.Time) (int64, error) {
+	deletedBefore, err := ptypes.TimestampProto(now.Add(-p.Retention))
+	if err != nil {
+		return -1, err
+	}
+
+	return p.Repo.Purge(WithAllTenants(ctx), deletedBefore)
+}
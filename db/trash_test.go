@@ -0,0 +1,43 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/halimi/todo-list-service/db"
+)
+
+func TestPurger(t *testing.T) {
+	memory := setupMemory(t)
+	ctx := context.Background()
+
+	id, err := memory.Insert(ctx, getTestTodo(0, "Test Todo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := memory.Delete(ctx, id, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &db.Purger{Repo: memory, Retention: time.Hour}
+
+	// the todo is kept until the retention passes
+	for _, tt := range []struct {
+		now  time.Time
+		want int64
+	}{
+		{time.Now(), 0},
+		{time.Now().Add(2 * time.Hour), 1},
+	} {
+		got, err := p.Purge(ctx, tt.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("Want: %v, Got: %v\n", tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// UpdatePaths are the todo fields which can be updated, the rest of the fields are set by the server
+var UpdatePaths = []string{"title", "note", "due_date", "status", "tags", "priority", "list_id"}
+
+// mergeTodo is copying the fields of the paths from the src todo to the dst todo
+func mergeTodo(dst, src *todolistpb.Todo, paths []string) {
+	d := dst.ProtoReflect()
+	s := src.ProtoReflect()
+	fields := d.Descriptor().Fields()
+
+	for _, path := range paths {
+		fd := fields.ByName(protoreflect.Name(path))
+		if fd == nil {
+			continue
+		}
+
+		if s.Has(fd) {
+			d.Set(fd, s.Get(fd))
+		} else {
+			d.Clear(fd)
+		}
+	}
+}
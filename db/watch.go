@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// ErrInvalidResumeToken is returned when the resume token can not be decoded
+var ErrInvalidResumeToken = errors.New("invalid resume token")
+
+// ErrResumeTokenExpired is returned when the events after the resume token are not kept anymore
+var ErrResumeTokenExpired = errors.New("resume token expired")
+
+// Event is a change of a todo, the resume token is the position of the event in the stream
+type Event struct {
+	Type        todolistpb.WatchTodosResponse_EventType
+	Todo        *todolistpb.Todo
+	ResumeToken string
+}
+
+// Watcher is implemented by the repositories which can stream the changes of the todos
+type Watcher interface {
+	// Watch is calling fn with the events after the resume token in the order of the changes,
+	// or with the events from now if the token is empty. It returns when the context is done
+	// or fn returns with an error.
+	Watch(ctx context.Context, resumeToken string, fn func(*Event) error) error
+}
+
+// notifier is waking up the watchers when there are new events
+type notifier struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+// wait returns with a channel which is closed on the next notify
+func (n *notifier) wait() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.ch == nil {
+		n.ch = make(chan struct{})
+	}
+
+	return n.ch
+}
+
+// notify is waking up the watchers
+func (n *notifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.ch != nil {
+		close(n.ch)
+		n.ch = nil
+	}
+}
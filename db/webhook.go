@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// WebhookStore is implemented by the repositories storing the webhook subscriptions
+// and the attempts of their deliveries, the webhooks are scoped to the owner of the context like the todos
+type WebhookStore interface {
+	InsertWebhook(context.Context, *todolistpb.Webhook) (int32, error)
+	// GetWebhook returns an empty webhook if it does not exist
+	GetWebhook(context.Context, int32) (*todolistpb.Webhook, error)
+	// UpdateWebhook is keeping the secret if it is empty and resetting the failure count,
+	// it returns an empty webhook if it does not exist
+	UpdateWebhook(context.Context, *todolistpb.Webhook) (*todolistpb.Webhook, error)
+	// DeleteWebhook is deleting the deliveries of the webhook too
+	DeleteWebhook(context.Context, int32) (int64, error)
+	ListWebhooks(context.Context) ([]*todolistpb.Webhook, error)
+	// InsertWebhookDelivery returns 0 if the webhook does not exist
+	InsertWebhookDelivery(context.Context, *todolistpb.WebhookDelivery) (int64, error)
+	// ListWebhookDeliveries returns with the last deliveries of the webhook, the newest first
+	ListWebhookDeliveries(ctx context.Context, webhookID int32, limit int) ([]*todolistpb.WebhookDelivery, error)
+	// RecordWebhookResult is resetting the failure count of the webhook after a successful delivery,
+	// otherwise it is incrementing it and the webhook is disabled when it reaches disableAfter,
+	// it is never disabled if disableAfter is 0. It returns an empty webhook if it does not exist.
+	RecordWebhookResult(ctx context.Context, id int32, success bool, disableAfter int32) (*todolistpb.Webhook, error)
+
+	// EnqueueWebhookDelivery is queueing the message to the webhook, it returns 0 if the webhook does not exist
+	// or the event is already queued to it. The events without an ID are never deduplicated.
+	EnqueueWebhookDelivery(context.Context, *QueuedDelivery) (int64, error)
+	// ClaimWebhookDeliveries returns with the oldest queued delivery of at most limit webhooks if its next
+	// attempt is due, the claimed deliveries are not returned again until the lease passes. The queue is
+	// processed by the dispatcher, so the claims are not scoped to the owner of the context.
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*QueuedDelivery, error)
+	// RetryWebhookDelivery is setting the number of the failed attempts and the time of the next attempt
+	// of the queued delivery
+	RetryWebhookDelivery(ctx context.Context, id int64, attempt int32, nextAttemptAt time.Time) error
+	// DequeueWebhookDelivery is removing the delivery from the queue
+	DequeueWebhookDelivery(ctx context.Context, id int64) error
+}
+
+// QueuedDelivery is a message waiting for its delivery to a webhook, the messages of a webhook
+// are delivered in the order of their IDs
+type QueuedDelivery struct {
+	ID        int64
+	WebhookID int32
+	EventID   int64
+	EventType string
+	Body      []byte
+	// Attempt is the number of the failed attempts
+	Attempt int32
+	// NextAttemptAt is the time of the next attempt, the delivery is due at once if it is zero
+	NextAttemptAt time.Time
+}
+
+// DeliveryPageSize returns with the number of deliveries listed by the request
+func DeliveryPageSize(req *todolistpb.ListWebhookDeliveriesRequest) int {
+	size := int(req.GetPageSize())
+	if size <= 0 {
+		return defaultPageSize
+	}
+
+	if size > maxPageSize {
+		return maxPageSize
+	}
+
+	return size
+}
@@ -0,0 +1,93 @@
+package events
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// sharedAddressSpace is the carrier-grade NAT network of RFC 6598, it is internal like the private networks
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// WebhookClient returns with the client of the webhook deliveries. The webhooks are registered by the callers,
+// so the client is refusing to connect to the loopback, private, link-local, unspecified and multicast
+// addresses, they could reach the internal network. The address is checked when the connection is made,
+// after the host is resolved, so the redirects and the hosts resolving to another address later are checked
+// too. The internal addresses of the allowed networks are accepted, e.g. 127.0.0.0/8 for the local webhooks.
+func WebhookClient(timeout time.Duration, allowed []netip.Prefix) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   addressFilter(allowed),
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// the proxy of the environment would connect to the webhooks, so the checked address would be the proxy
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// addressFilter returns with the control function of the dialer refusing the internal addresses
+// which are not in the allowed networks
+func addressFilter(allowed []netip.Prefix) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, c syscall.RawConn) error {
+		addrPort, err := netip.ParseAddrPort(address)
+		if err != nil {
+			return fmt.Errorf("invalid webhook address %v: %v", address, err)
+		}
+
+		addr := addrPort.Addr().Unmap()
+		for _, prefix := range allowed {
+			if prefix.Contains(addr) {
+				return nil
+			}
+		}
+
+		if internalAddress(addr) {
+			return fmt.Errorf("webhook address %v is not allowed", addr)
+		}
+
+		return nil
+	}
+}
+
+// internalAddress returns true if the address is not a public unicast address
+func internalAddress(addr netip.Addr) bool {
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr)
+}
+
+// ParseNetworks returns with the networks of a comma separated list of CIDRs or addresses,
+// e.g. 127.0.0.0/8,::1
+func ParseNetworks(s string) ([]netip.Prefix, error) {
+	var networks []netip.Prefix
+
+	for _, network := range strings.Split(s, ",") {
+		if network = strings.TrimSpace(network); network == "" {
+			continue
+		}
+
+		if !strings.Contains(network, "/") {
+			addr, err := netip.ParseAddr(network)
+			if err != nil {
+				return nil, fmt.Errorf("invalid network: %v", network)
+			}
+			networks = append(networks, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network: %v", network)
+		}
+		networks = append(networks, prefix.Masked())
+	}
+
+	return networks, nil
+}
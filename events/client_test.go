@@ -0,0 +1,68 @@
+package events_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/halimi/todo-list-service/events"
+)
+
+func TestWebhookClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	// the internal addresses are refused before connecting to them
+	client := events.WebhookClient(time.Second, nil)
+	for _, url := range []string{ts.URL, "http://10.0.0.1", "http://169.254.169.254/latest/meta-data", "http://[::1]:80", "http://0.0.0.0", "http://100.64.0.1"} {
+		res, err := client.Get(url)
+		if err == nil {
+			res.Body.Close()
+		}
+
+		if err == nil || !strings.Contains(err.Error(), "is not allowed") {
+			t.Fatalf("Want: not allowed %v, Got: %v\n", url, err)
+		}
+	}
+
+	// the allowed networks are accepted even if they are internal
+	client = events.WebhookClient(time.Second, []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")})
+	res, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res, err := client.Get("http://10.0.0.1"); err == nil || !strings.Contains(err.Error(), "is not allowed") {
+		if err == nil {
+			res.Body.Close()
+		}
+		t.Fatalf("Want: not allowed, Got: %v\n", err)
+	}
+}
+
+func TestParseNetworks(t *testing.T) {
+	got, err := events.ParseNetworks(" 127.0.0.1/8, ::1 ,,10.1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []netip.Prefix{
+		netip.MustParsePrefix("127.0.0.0/8"),
+		netip.MustParsePrefix("::1/128"),
+		netip.MustParsePrefix("10.1.2.3/32"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	for _, s := range []string{"localhost", "10.0.0.0/33"} {
+		if _, err := events.ParseNetworks(s); err == nil {
+			t.Fatalf("Want: error %v, Got: nil\n", s)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+const (
+	defaultDueInterval = time.Minute
+	// duePageSize is the largest page of the todo listing
+	duePageSize = 1000
+)
+
+// DueNotifier is queueing the todo.due events to the webhooks when the due date of an open or
+// in progress todo passes. The todos becoming due while the service is stopped are not notified,
+// and the events are sent by every instance running a notifier.
+type DueNotifier struct {
+	Repo       db.Repository
+	Dispatcher *WebhookDispatcher
+
+	// Interval is the time between the checks of the due dates, 1m if 0
+	Interval time.Duration
+}
+
+// Run is checking the due dates until the context is done
+func (n *DueNotifier) Run(ctx context.Context) error {
+	interval := n.Interval
+	if interval <= 0 {
+		interval = defaultDueInterval
+	}
+
+	from := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		// the failed checks are repeated from the same time, so no todo is missed
+		to := time.Now()
+		if err := n.Notify(ctx, from, to); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Printf("Could not notify the due todos: %v", err)
+			continue
+		}
+		from = to
+	}
+}
+
+// Notify is queueing the events of the todos of every owner due in [from, to)
+func (n *DueNotifier) Notify(ctx context.Context, from, to time.Time) error {
+	// the filters are exclusive, so the todos due at from are included by starting a nanosecond earlier
+	after, err := ptypes.TimestampProto(from.Add(-time.Nanosecond))
+	if err != nil {
+		return err
+	}
+
+	before, err := ptypes.TimestampProto(to)
+	if err != nil {
+		return err
+	}
+
+	req := &todolistpb.ListTodosRequest{
+		Status:    []todolistpb.Status{todolistpb.Status_OPEN, todolistpb.Status_IN_PROGRESS},
+		DueAfter:  after,
+		DueBefore: before,
+		SortBy:    todolistpb.ListTodosRequest_DUE_DATE,
+		PageSize:  duePageSize,
+	}
+
+	for {
+		todos, next, err := n.Repo.List(db.WithAllTenants(ctx), req)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range todos {
+			dueDate, err := ptypes.Timestamp(t.GetDueDate())
+			if err != nil {
+				return err
+			}
+
+			b, err := encodeMessage(0, TypeDue, dueDate, t)
+			if err != nil {
+				return err
+			}
+
+			if err := n.Dispatcher.Enqueue(ctx, 0, TypeDue, t, b); err != nil {
+				return err
+			}
+		}
+
+		if next == "" {
+			return nil
+		}
+		req.PageToken = next
+	}
+}
@@ -0,0 +1,215 @@
+// Package events is publishing the changes of the todos recorded in the outbox of the database
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// EventPublisher is publishing the change events to the downstream services, an event is
+// removed from the outbox only when Publish returns without error
+type EventPublisher interface {
+	Publish(context.Context, *db.OutboxEvent) error
+}
+
+// Message is the JSON message of a change event, the ID is unique so the consumers can
+// drop the events which are delivered more than once
+type Message struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Todo       json.RawMessage `json:"todo"`
+}
+
+// the types of the events in the messages
+const (
+	TypeCreated = "todo.created"
+	TypeUpdated = "todo.updated"
+	TypeDeleted = "todo.deleted"
+	// TypeDue is only sent to the webhooks when a todo becomes due, it is not recorded in the outbox
+	TypeDue = "todo.due"
+)
+
+// Types are the event types the webhooks can subscribe to
+var Types = []string{TypeCreated, TypeUpdated, TypeDeleted, TypeDue}
+
+// EventType returns with the type of the event in the messages: todo.created, todo.updated or todo.deleted
+func EventType(e *db.OutboxEvent) string {
+	return "todo." + strings.ToLower(e.Type.String())
+}
+
+// Encode returns with the JSON message of the event, the todo fields are named as in the proto file
+func Encode(e *db.OutboxEvent) ([]byte, error) {
+	return encodeMessage(e.ID, EventType(e), e.OccurredAt, e.Todo)
+}
+
+// encodeMessage returns with the JSON message of an event
+func encodeMessage(id int64, eventType string, occurredAt time.Time, todo *todolistpb.Todo) ([]byte, error) {
+	b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(todo)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(Message{
+		ID:         id,
+		Type:       eventType,
+		OccurredAt: occurredAt.UTC(),
+		Todo:       b,
+	})
+}
+
+// Discard is dropping the events
+var Discard EventPublisher = discard{}
+
+type discard struct{}
+
+func (discard) Publish(context.Context, *db.OutboxEvent) error {
+	return nil
+}
+
+// MultiPublisher returns with a publisher publishing the events to all the publishers in order,
+// it stops at the first error
+func MultiPublisher(publishers ...EventPublisher) EventPublisher {
+	return multiPublisher(publishers)
+}
+
+type multiPublisher []EventPublisher
+
+func (m multiPublisher) Publish(ctx context.Context, e *db.OutboxEvent) error {
+	for _, p := range m {
+		if err := p.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriterPublisher is writing the messages to W, one JSON message per line
+type WriterPublisher struct {
+	W io.Writer
+
+	mu sync.Mutex
+}
+
+// Publish is writing the message of the event
+func (p *WriterPublisher) Publish(ctx context.Context, e *db.OutboxEvent) error {
+	b, err := Encode(e)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err = p.W.Write(append(b, '\n'))
+	return err
+}
+
+// FilePublisher is appending the messages to a local file, one JSON message per line
+type FilePublisher struct {
+	f  *os.File
+	mu sync.Mutex
+}
+
+// NewFilePublisher returns with a publisher appending to the file, it is created if it does not exist
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FilePublisher{f: f}, nil
+}
+
+// Publish is appending the message of the event, it is synced to the disk before returning
+func (p *FilePublisher) Publish(ctx context.Context, e *db.OutboxEvent) error {
+	b, err := Encode(e)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	return p.f.Sync()
+}
+
+// Close is closing the file
+func (p *FilePublisher) Close() error {
+	return p.f.Close()
+}
+
+// WebhookPublisher is posting the messages to an HTTP endpoint, the event is published
+// when the endpoint responds with a 2xx status code
+type WebhookPublisher struct {
+	URL string
+	// Client is sending the requests, http.DefaultClient if nil
+	Client *http.Client
+}
+
+// Publish is posting the message of the event
+func (p *WebhookPublisher) Publish(ctx context.Context, e *db.OutboxEvent) error {
+	b, err := Encode(e)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("X-Event-Id", strconv.FormatInt(e.ID, 10))
+	header.Set("X-Event-Type", EventType(e))
+
+	_, err = post(ctx, p.Client, p.URL, header, b)
+	return err
+}
+
+// post is posting the JSON message to the URL, it returns with the status code of the response
+// and an error if it is not 2xx
+func post(ctx context.Context, client *http.Client, url string, header http.Header, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	// the connection is reused only if the body is read
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("webhook responded with %v", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
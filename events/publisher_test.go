@@ -0,0 +1,144 @@
+package events_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/events"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+func getTestEvent(id int64) *db.OutboxEvent {
+	return &db.OutboxEvent{
+		ID:         id,
+		Type:       todolistpb.WatchTodosResponse_UPDATED,
+		Todo:       &todolistpb.Todo{Id: 7, Title: "Test Todo", Version: 2},
+		OccurredAt: time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+// checkMessage is checking the JSON message of the test event
+func checkMessage(t *testing.T, id int64, b []byte) {
+	var msg events.Message
+	if err := json.Unmarshal(b, &msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.ID != id || msg.Type != "todo.updated" || !msg.OccurredAt.Equal(time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatalf("Want: %v todo.updated, Got: %v\n", id, msg)
+	}
+
+	var todo map[string]interface{}
+	if err := json.Unmarshal(msg.Todo, &todo); err != nil {
+		t.Fatal(err)
+	}
+
+	if todo["title"] != "Test Todo" || todo["version"] != "2" || todo["list_id"] != float64(0) {
+		t.Fatalf("Want: Test Todo, Got: %v\n", todo)
+	}
+}
+
+func TestWriterPublisher(t *testing.T) {
+	var buf bytes.Buffer
+	p := &events.WriterPublisher{W: &buf}
+
+	for _, id := range []int64{1, 2} {
+		if err := p.Publish(context.Background(), getTestEvent(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Want: 2 lines, Got: %q\n", buf.String())
+	}
+
+	checkMessage(t, 1, []byte(lines[0]))
+	checkMessage(t, 2, []byte(lines[1]))
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	// the messages are appended to the existing file
+	for _, id := range []int64{1, 2} {
+		p, err := events.NewFilePublisher(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := p.Publish(context.Background(), getTestEvent(id)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := p.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Want: 2 lines, Got: %q\n", b)
+	}
+
+	checkMessage(t, 1, []byte(lines[0]))
+	checkMessage(t, 2, []byte(lines[1]))
+}
+
+func TestWebhookPublisher(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    bool
+	}{
+		{"OK", http.StatusOK, false},
+		{"NoContent", http.StatusNoContent, false},
+		{"ServerError", http.StatusInternalServerError, true},
+		{"NotFound", http.StatusNotFound, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			var header http.Header
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Want: %v, Got: %v\n", http.MethodPost, r.Method)
+				}
+
+				body, _ = io.ReadAll(r.Body)
+				header = r.Header
+				w.WriteHeader(tt.status)
+			}))
+			defer ts.Close()
+
+			p := &events.WebhookPublisher{URL: ts.URL, Client: ts.Client()}
+			err := p.Publish(context.Background(), getTestEvent(3))
+
+			if (err != nil) != tt.err {
+				t.Fatalf("Want: error %v, Got: %v\n", tt.err, err)
+			}
+
+			if header.Get("Content-Type") != "application/json" || header.Get("X-Event-Id") != "3" || header.Get("X-Event-Type") != "todo.updated" {
+				t.Fatalf("Want: application/json 3 todo.updated, Got: %v\n", header)
+			}
+
+			checkMessage(t, 3, body)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/halimi/todo-list-service/db"
+)
+
+const (
+	defaultBatchSize  = 100
+	defaultInterval   = time.Second
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Minute
+)
+
+// Relay is publishing the events of the outbox in the order of the changes. An event is removed
+// from the outbox only after it was published, so every event is published at least once, and
+// the failed publishes are retried with exponential backoff.
+type Relay struct {
+	Outbox    db.Outbox
+	Publisher EventPublisher
+
+	// BatchSize is the maximum number of events processed at once, 100 if 0
+	BatchSize int
+	// Interval is the time between the checks of the empty outbox, 1s if 0
+	Interval time.Duration
+	// MinBackoff is the first wait after a failed publish, it is doubled on every failure
+	// up to MaxBackoff, 1s and 1m if 0
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Run is relaying the events until the context is done
+func (r *Relay) Run(ctx context.Context) error {
+	batchSize := r.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	interval := r.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	var backoff time.Duration
+	for {
+		count, err := r.Outbox.ProcessOutbox(ctx, batchSize, func(e *db.OutboxEvent) error {
+			return r.Publisher.Publish(ctx, e)
+		})
+
+		wait := interval
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			backoff = nextBackoff(backoff, r.MinBackoff, r.MaxBackoff)
+			wait = backoff
+			log.Printf("Could not publish the events, retrying in %v: %v", backoff, err)
+		case count == batchSize:
+			// there can be more events in the outbox
+			backoff = 0
+			continue
+		default:
+			backoff = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// nextBackoff returns with the doubled backoff between min and max, 1s and 1m if 0
+func nextBackoff(backoff, min, max time.Duration) time.Duration {
+	if min <= 0 {
+		min = defaultMinBackoff
+	}
+	if max <= 0 {
+		max = defaultMaxBackoff
+	}
+
+	backoff *= 2
+	if backoff < min {
+		return min
+	}
+	if backoff > max {
+		return max
+	}
+
+	return backoff
+}
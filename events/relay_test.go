@@ -0,0 +1,100 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/events"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// flakyPublisher is failing every second publish
+type flakyPublisher struct {
+	mu       sync.Mutex
+	calls    int
+	received []int64
+	done     chan struct{}
+	want     int
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, e *db.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls++
+	if p.calls%2 == 0 {
+		return errors.New("publish failed")
+	}
+
+	p.received = append(p.received, e.ID)
+	if len(p.received) == p.want {
+		close(p.done)
+	}
+
+	return nil
+}
+
+func TestRelay(t *testing.T) {
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer memory.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		todo := &todolistpb.Todo{Title: "Test Todo", DueDate: ptypes.TimestampNow()}
+		if _, err := memory.Insert(ctx, todo); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := &flakyPublisher{done: make(chan struct{}), want: 5}
+	relay := &events.Relay{
+		Outbox:     memory,
+		Publisher:  p,
+		BatchSize:  2,
+		Interval:   time.Millisecond,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- relay.Run(ctx)
+	}()
+
+	select {
+	case <-p.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Want: 5 published events, Got: timeout")
+	}
+
+	cancel()
+	if err := <-errc; err != context.Canceled {
+		t.Fatalf("Want: %v, Got: %v\n", context.Canceled, err)
+	}
+
+	// the failed events are retried in order
+	p.mu.Lock()
+	received := p.received
+	p.mu.Unlock()
+
+	for i, id := range received {
+		if id != int64(i+1) {
+			t.Fatalf("Want: 1 2 3 4 5, Got: %v\n", received)
+		}
+	}
+
+	count, err := memory.ProcessOutbox(context.Background(), 10, func(*db.OutboxEvent) error { return nil })
+	if err != nil || count != 0 {
+		t.Fatalf("Want: empty outbox, Got: %v %v\n", count, err)
+	}
+}
@@ -0,0 +1,336 @@
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+const (
+	defaultMaxAttempts  = 5
+	defaultDisableAfter = 10
+	defaultLease        = 5 * time.Minute
+)
+
+// the headers of the webhook requests
+const (
+	HeaderEventID   = "X-Event-Id"
+	HeaderEventType = "X-Event-Type"
+	// HeaderTimestamp is the Unix time of the attempt, it is signed together with the body
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature is the HMAC-SHA256 signature of the timestamp and the body: sha256=<hex>
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign returns with the signature of the message sent at the Unix time, it is the hex encoded
+// HMAC-SHA256 of "<timestamp>.<body>" with the secret of the webhook
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the signature of the message in constant time, the receivers should
+// reject the old timestamps too so the messages can not be replayed
+func VerifySignature(secret, signature string, timestamp int64, body []byte) bool {
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
+
+// WebhookDispatcher is delivering the events to the subscribed webhooks of the store. The events
+// are queued to every webhook when they are published and Run is delivering the queues outside of
+// the transactions of the outbox, the messages of a webhook are delivered in the order of the queue.
+// Every delivery is retried with exponential backoff and every attempt is recorded in the store,
+// a webhook is disabled after too many failed deliveries in a row. A webhook gets only the events
+// of the todos visible to its owner.
+type WebhookDispatcher struct {
+	Store db.WebhookStore
+	// Repo and Members are finding the lists shared with the owners of the webhooks, the webhooks
+	// get only the events of the todos of their owners if one of them is nil
+	Repo    db.Repository
+	Members db.MemberStore
+	// Client is sending the requests, http.DefaultClient if nil
+	Client *http.Client
+
+	// MaxAttempts is the number of the attempts of a delivery, 5 if 0
+	MaxAttempts int
+	// MinBackoff is the wait before the second attempt, it is doubled on every attempt
+	// up to MaxBackoff, 1s and 1m if 0
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// DisableAfter is the number of the failed deliveries in a row disabling a webhook, 10 if 0
+	DisableAfter int32
+
+	// BatchSize is the maximum number of the webhooks delivered at once, 100 if 0
+	BatchSize int
+	// Interval is the time between the checks of the empty queues, 1s if 0
+	Interval time.Duration
+	// Lease is the time of an attempt, it has to be longer than the timeout of the client.
+	// The attempt interrupted by a crash or by the shutdown is repeated after it, 5m if 0.
+	Lease time.Duration
+}
+
+// Publish is queueing the event of the outbox to the webhooks, the event is queued to a webhook
+// only once, so the event can be published again after a failure
+func (d *WebhookDispatcher) Publish(ctx context.Context, e *db.OutboxEvent) error {
+	b, err := Encode(e)
+	if err != nil {
+		return err
+	}
+
+	return d.Enqueue(ctx, e.ID, EventType(e), e.Todo, b)
+}
+
+// Enqueue is queueing the message of the todo to every enabled webhook subscribed to the event type
+func (d *WebhookDispatcher) Enqueue(ctx context.Context, eventID int64, eventType string, todo *todolistpb.Todo, body []byte) error {
+	// the webhooks of every owner are delivered by the dispatcher
+	ctx = db.WithAllTenants(ctx)
+
+	webhooks, err := d.Store.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range webhooks {
+		if w.GetDisabled() || !Subscribed(w, eventType) {
+			continue
+		}
+
+		visible, err := d.visible(ctx, w, todo)
+		if err != nil {
+			return err
+		}
+		if !visible {
+			continue
+		}
+
+		delivery := &db.QueuedDelivery{WebhookID: w.GetId(), EventID: eventID, EventType: eventType, Body: body}
+		if _, err := d.Store.EnqueueWebhookDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run is delivering the queued messages until the context is done
+func (d *WebhookDispatcher) Run(ctx context.Context) error {
+	interval := d.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	for {
+		count, err := d.Dispatch(ctx)
+
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			log.Printf("Could not deliver the webhooks: %v", err)
+		case count == d.batchSize():
+			// there can be more due deliveries
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Dispatch is making the next attempt of the oldest due message of the webhooks at the same time,
+// it returns with the number of the attempts when all of them are finished
+func (d *WebhookDispatcher) Dispatch(ctx context.Context) (int, error) {
+	ctx = db.WithAllTenants(ctx)
+
+	lease := d.Lease
+	if lease <= 0 {
+		lease = defaultLease
+	}
+
+	deliveries, err := d.Store.ClaimWebhookDeliveries(ctx, d.batchSize(), lease)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(deliveries))
+	for i, q := range deliveries {
+		wg.Add(1)
+		go func(i int, q *db.QueuedDelivery) {
+			defer wg.Done()
+			errs[i] = d.deliver(ctx, q)
+		}(i, q)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return len(deliveries), errors.Join(errs...)
+}
+
+// batchSize returns with the maximum number of the webhooks delivered at once
+func (d *WebhookDispatcher) batchSize() int {
+	if d.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+
+	return d.BatchSize
+}
+
+// deliver is posting the queued message to its webhook, the message is removed from the queue
+// when it succeeds or it runs out of attempts, otherwise its next attempt is scheduled
+func (d *WebhookDispatcher) deliver(ctx context.Context, q *db.QueuedDelivery) error {
+	w, err := d.Store.GetWebhook(ctx, q.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	// the messages queued to a disabled webhook are dropped
+	if w.GetId() == 0 || w.GetDisabled() {
+		return d.Store.DequeueWebhookDelivery(ctx, q.ID)
+	}
+
+	now := time.Now()
+	header := http.Header{}
+	header.Set(HeaderEventID, strconv.FormatInt(q.EventID, 10))
+	header.Set(HeaderEventType, q.EventType)
+	header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	header.Set(HeaderSignature, Sign(w.GetSecret(), now.Unix(), q.Body))
+
+	code, err := post(ctx, d.Client, w.GetUrl(), header, q.Body)
+	// the attempt interrupted by the shutdown is not recorded, it is repeated after the lease
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	attemptedAt, tsErr := ptypes.TimestampProto(now)
+	if tsErr != nil {
+		return tsErr
+	}
+
+	attempt := q.Attempt + 1
+	delivery := &todolistpb.WebhookDelivery{
+		WebhookId:   w.GetId(),
+		EventId:     q.EventID,
+		EventType:   q.EventType,
+		Attempt:     attempt,
+		StatusCode:  int32(code),
+		Success:     err == nil,
+		AttemptedAt: attemptedAt,
+	}
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+
+	if _, err := d.Store.InsertWebhookDelivery(ctx, delivery); err != nil {
+		return err
+	}
+
+	maxAttempts := d.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	success := err == nil
+	if !success && int(attempt) < maxAttempts {
+		return d.Store.RetryWebhookDelivery(ctx, q.ID, attempt, now.Add(d.backoff(attempt)))
+	}
+
+	if err := d.Store.DequeueWebhookDelivery(ctx, q.ID); err != nil {
+		return err
+	}
+
+	disableAfter := d.DisableAfter
+	if disableAfter <= 0 {
+		disableAfter = defaultDisableAfter
+	}
+
+	updated, err := d.Store.RecordWebhookResult(ctx, w.GetId(), success, disableAfter)
+	if err != nil {
+		return err
+	}
+
+	if !success && updated.GetDisabled() && updated.GetFailureCount() == disableAfter {
+		log.Printf("Webhook %v is disabled after %v failed deliveries", w.GetId(), disableAfter)
+	}
+
+	return nil
+}
+
+// backoff returns with the wait after the failed attempts
+func (d *WebhookDispatcher) backoff(attempts int32) time.Duration {
+	var backoff time.Duration
+	for i := int32(0); i < attempts; i++ {
+		backoff = nextBackoff(backoff, d.MinBackoff, d.MaxBackoff)
+	}
+
+	return backoff
+}
+
+// visible returns true if the todo is owned by the owner of the webhook or it is in a list owned by
+// or shared with the owner of the webhook
+func (d *WebhookDispatcher) visible(ctx context.Context, w *todolistpb.Webhook, todo *todolistpb.Todo) (bool, error) {
+	if w.GetOwner() == todo.GetOwner() {
+		return true, nil
+	}
+
+	if d.Repo == nil || d.Members == nil || todo.GetListId() == 0 {
+		return false, nil
+	}
+
+	list, err := d.Repo.GetTodoList(ctx, todo.GetListId())
+	if err != nil {
+		return false, err
+	}
+
+	if list.GetId() == 0 {
+		return false, nil
+	}
+
+	if w.GetOwner() == list.GetOwner() {
+		return true, nil
+	}
+
+	member, err := d.Members.GetListMember(ctx, list.GetId(), w.GetOwner())
+	if err != nil {
+		return false, err
+	}
+
+	return member.GetListId() != 0, nil
+}
+
+// Subscribed checks if the webhook is subscribed to the event type, it is subscribed to every
+// type if it has no event types
+func Subscribed(w *todolistpb.Webhook, eventType string) bool {
+	if len(w.GetEventTypes()) == 0 {
+		return true
+	}
+
+	for _, t := range w.GetEventTypes() {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
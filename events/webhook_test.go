@@ -0,0 +1,451 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/events"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// webhookServer is a webhook endpoint responding with the statuses in order, then with 200
+type webhookServer struct {
+	*httptest.Server
+
+	t        *testing.T
+	secret   string
+	mu       sync.Mutex
+	statuses []int
+	received []events.Message
+}
+
+func newWebhookServer(t *testing.T, secret string, statuses ...int) *webhookServer {
+	s := &webhookServer{t: t, secret: secret, statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *webhookServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.t.Error(err)
+	}
+
+	ts, err := strconv.ParseInt(r.Header.Get(events.HeaderTimestamp), 10, 64)
+	if err != nil {
+		s.t.Error(err)
+	}
+
+	if !events.VerifySignature(s.secret, r.Header.Get(events.HeaderSignature), ts, body) {
+		s.t.Errorf("Want: valid signature, Got: %v\n", r.Header.Get(events.HeaderSignature))
+	}
+
+	var msg events.Message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		s.t.Error(err)
+	}
+
+	if r.Header.Get(events.HeaderEventType) != msg.Type || r.Header.Get(events.HeaderEventID) != strconv.FormatInt(msg.ID, 10) {
+		s.t.Errorf("Want: %v %v, Got: %v\n", msg.ID, msg.Type, r.Header)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.received = append(s.received, msg)
+
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+// messages returns with the received messages
+func (s *webhookServer) messages() []events.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]events.Message(nil), s.received...)
+}
+
+func setupDispatcher(t *testing.T) (*events.WebhookDispatcher, *db.Memory) {
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &events.WebhookDispatcher{
+		Store:      memory,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+	}, memory
+}
+
+// dispatch is delivering the queued messages until the queues are empty, the retries are due
+// after the MaxBackoff of the dispatcher
+func dispatch(t *testing.T, d *events.WebhookDispatcher) {
+	t.Helper()
+
+	for idle := 0; idle < 2; {
+		count, err := d.Dispatch(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if count > 0 {
+			idle = 0
+			continue
+		}
+
+		idle++
+		time.Sleep(2 * d.MaxBackoff)
+	}
+}
+
+func insertWebhook(t *testing.T, memory *db.Memory, url string, eventTypes ...string) int32 {
+	id, err := memory.InsertWebhook(context.Background(), &todolistpb.Webhook{Url: url, Secret: "secret", EventTypes: eventTypes})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return id
+}
+
+func deliveries(t *testing.T, memory *db.Memory, webhookID int32) []*todolistpb.WebhookDelivery {
+	deliveries, err := memory.ListWebhookDeliveries(context.Background(), webhookID, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return deliveries
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	signature := events.Sign("secret", 100, body)
+
+	if !events.VerifySignature("secret", signature, 100, body) {
+		t.Fatalf("Want: valid signature, Got: %v\n", signature)
+	}
+
+	if events.VerifySignature("other", signature, 100, body) || events.VerifySignature("secret", signature, 101, body) ||
+		events.VerifySignature("secret", signature, 100, []byte(`{"id":2}`)) {
+		t.Fatalf("Want: invalid signature, Got: valid\n")
+	}
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	d, memory := setupDispatcher(t)
+
+	created := newWebhookServer(t, "secret")
+	all := newWebhookServer(t, "secret")
+	disabled := newWebhookServer(t, "secret")
+
+	createdID := insertWebhook(t, memory, created.URL, events.TypeCreated)
+	allID := insertWebhook(t, memory, all.URL)
+	disabledID := insertWebhook(t, memory, disabled.URL)
+	if _, err := memory.UpdateWebhook(context.Background(), &todolistpb.Webhook{Id: disabledID, Url: disabled.URL, Disabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []int64{1, 2} {
+		e := getTestEvent(id)
+		if id == 1 {
+			e.Type = todolistpb.WatchTodosResponse_CREATED
+		}
+
+		if err := d.Publish(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the events are only queued by the relay of the outbox
+	if got := all.messages(); len(got) != 0 {
+		t.Fatalf("Want: no messages, Got: %v\n", got)
+	}
+
+	// the events are queued once to a webhook
+	if err := d.Publish(context.Background(), getTestEvent(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	dispatch(t, d)
+
+	if got := created.messages(); len(got) != 1 || got[0].ID != 1 || got[0].Type != events.TypeCreated {
+		t.Fatalf("Want: todo.created, Got: %v\n", got)
+	}
+
+	if got := all.messages(); len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 {
+		t.Fatalf("Want: 1 2, Got: %v\n", got)
+	}
+
+	if got := disabled.messages(); len(got) != 0 {
+		t.Fatalf("Want: no messages, Got: %v\n", got)
+	}
+
+	got := deliveries(t, memory, allID)
+	if len(got) != 2 || !got[0].GetSuccess() || got[0].GetStatusCode() != http.StatusOK || got[0].GetEventId() != 2 || got[0].GetEventType() != events.TypeUpdated {
+		t.Fatalf("Want: 2 successful deliveries, Got: %v\n", got)
+	}
+
+	if got := deliveries(t, memory, createdID); len(got) != 1 {
+		t.Fatalf("Want: 1 delivery, Got: %v\n", got)
+	}
+}
+
+func TestWebhookDispatcherOwners(t *testing.T) {
+	d, memory := setupDispatcher(t)
+	d.Repo, d.Members = memory, memory
+
+	alice := db.WithTenant(context.Background(), "alice")
+	listID, err := memory.InsertTodoList(alice, &todolistpb.TodoList{Name: "Shared"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := memory.PutListMember(alice, &todolistpb.ListMember{ListId: listID, Subject: "carol", Role: todolistpb.ListMember_VIEWER}); err != nil {
+		t.Fatal(err)
+	}
+
+	servers := make(map[string]*webhookServer)
+	for _, owner := range []string{"alice", "bob", "carol"} {
+		servers[owner] = newWebhookServer(t, "secret")
+		if _, err := memory.InsertWebhook(db.WithTenant(context.Background(), owner), &todolistpb.Webhook{Url: servers[owner].URL, Secret: "secret"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the todo of alice, the todo of alice in the list shared with carol and the todo of bob
+	todos := []*todolistpb.Todo{
+		{Id: 1, Title: "Alice Todo", Owner: "alice"},
+		{Id: 2, Title: "Shared Todo", Owner: "alice", ListId: listID},
+		{Id: 3, Title: "Bob Todo", Owner: "bob"},
+	}
+
+	for i, todo := range todos {
+		e := getTestEvent(int64(i + 1))
+		e.Todo = todo
+
+		if err := d.Publish(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dispatch(t, d)
+
+	tests := []struct {
+		owner string
+		ids   []int64
+	}{
+		{"alice", []int64{1, 2}},
+		{"bob", []int64{3}},
+		{"carol", []int64{2}},
+	}
+
+	for _, tt := range tests {
+		got := servers[tt.owner].messages()
+
+		var ids []int64
+		for _, msg := range got {
+			ids = append(ids, msg.ID)
+		}
+
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Fatalf("Want: %v of %v, Got: %v\n", tt.ids, tt.owner, ids)
+		}
+	}
+}
+
+func TestWebhookDispatcherRetry(t *testing.T) {
+	d, memory := setupDispatcher(t)
+
+	ts := newWebhookServer(t, "secret", http.StatusInternalServerError, http.StatusServiceUnavailable)
+	id := insertWebhook(t, memory, ts.URL)
+
+	if err := d.Publish(context.Background(), getTestEvent(1)); err != nil {
+		t.Fatal(err)
+	}
+	dispatch(t, d)
+
+	if got := ts.messages(); len(got) != 3 {
+		t.Fatalf("Want: 3 attempts, Got: %v\n", got)
+	}
+
+	// the newest attempt is the first
+	got := deliveries(t, memory, id)
+	want := []struct {
+		attempt int32
+		status  int32
+		success bool
+	}{
+		{3, http.StatusOK, true},
+		{2, http.StatusServiceUnavailable, false},
+		{1, http.StatusInternalServerError, false},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	for i, w := range want {
+		if got[i].GetAttempt() != w.attempt || got[i].GetStatusCode() != w.status || got[i].GetSuccess() != w.success || (got[i].GetError() == "") == !w.success {
+			t.Fatalf("Want: %v, Got: %v\n", w, got[i])
+		}
+	}
+
+	webhook, err := memory.GetWebhook(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if webhook.GetFailureCount() != 0 {
+		t.Fatalf("Want: 0, Got: %v\n", webhook.GetFailureCount())
+	}
+}
+
+func TestWebhookDispatcherDisable(t *testing.T) {
+	d, memory := setupDispatcher(t)
+	d.MaxAttempts = 2
+	d.DisableAfter = 2
+
+	statuses := []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError}
+	ts := newWebhookServer(t, "secret", statuses...)
+	id := insertWebhook(t, memory, ts.URL)
+
+	for i := int64(1); i <= 3; i++ {
+		if err := d.Publish(context.Background(), getTestEvent(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dispatch(t, d)
+
+	// the third event is not delivered to the disabled webhook
+	if got := ts.messages(); len(got) != 4 {
+		t.Fatalf("Want: 4 attempts, Got: %v\n", got)
+	}
+
+	webhook, err := memory.GetWebhook(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !webhook.GetDisabled() || webhook.GetFailureCount() != 2 {
+		t.Fatalf("Want: disabled webhook, Got: %v\n", webhook)
+	}
+}
+
+func TestWebhookDispatcherCanceled(t *testing.T) {
+	d, memory := setupDispatcher(t)
+
+	// the webhook is responding only when the request is canceled, the canceled requests
+	// are noticed by the server after the body is read
+	received := make(chan struct{}, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		received <- struct{}{}
+		<-r.Context().Done()
+	}))
+	t.Cleanup(ts.Close)
+	id := insertWebhook(t, memory, ts.URL)
+
+	if err := d.Publish(context.Background(), getTestEvent(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-received
+		cancel()
+	}()
+
+	// the interrupted attempt is not recorded, it is repeated after the lease
+	if _, err := d.Dispatch(ctx); err != context.Canceled {
+		t.Fatalf("Want: %v, Got: %v\n", context.Canceled, err)
+	}
+
+	if got := deliveries(t, memory, id); len(got) != 0 {
+		t.Fatalf("Want: no deliveries, Got: %v\n", got)
+	}
+
+	webhook, err := memory.GetWebhook(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if webhook.GetFailureCount() != 0 {
+		t.Fatalf("Want: 0, Got: %v\n", webhook.GetFailureCount())
+	}
+}
+
+func TestDueNotifier(t *testing.T) {
+	d, memory := setupDispatcher(t)
+	ctx := context.Background()
+
+	ts := newWebhookServer(t, "secret")
+	insertWebhook(t, memory, ts.URL, events.TypeDue)
+
+	from := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	tests := []struct {
+		title   string
+		dueDate time.Time
+		status  todolistpb.Status
+	}{
+		{"Due at from", from, todolistpb.Status_OPEN},
+		{"Due in progress", from.Add(time.Minute), todolistpb.Status_IN_PROGRESS},
+		{"Done", from.Add(time.Minute), todolistpb.Status_DONE},
+		{"Due before", from.Add(-time.Second), todolistpb.Status_OPEN},
+		{"Due at to", to, todolistpb.Status_OPEN},
+	}
+
+	for _, tt := range tests {
+		dueDate, err := ptypes.TimestampProto(tt.dueDate)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := memory.Insert(ctx, &todolistpb.Todo{Title: tt.title, DueDate: dueDate, Status: tt.status}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n := &events.DueNotifier{Repo: memory, Dispatcher: d}
+	if err := n.Notify(ctx, from, to); err != nil {
+		t.Fatal(err)
+	}
+	dispatch(t, d)
+
+	got := ts.messages()
+	if len(got) != 2 {
+		t.Fatalf("Want: 2 messages, Got: %v\n", got)
+	}
+
+	for i, title := range []string{"Due at from", "Due in progress"} {
+		var todo map[string]interface{}
+		if err := json.Unmarshal(got[i].Todo, &todo); err != nil {
+			t.Fatal(err)
+		}
+
+		if got[i].Type != events.TypeDue || got[i].ID != 0 || todo["title"] != title {
+			t.Fatalf("Want: %v %v, Got: %v %v\n", events.TypeDue, title, got[i], todo)
+		}
+	}
+
+	if !got[0].OccurredAt.Equal(from) {
+		t.Fatalf("Want: %v, Got: %v\n", from, got[0].OccurredAt)
+	}
+}
@@ -0,0 +1,259 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+
+	"connectrpc.com/connect"
+	connectcors "connectrpc.com/cors"
+	"github.com/rs/cors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/halimi/todo-list-service/todolistpb"
+	"github.com/halimi/todo-list-service/todolistpb/todolistpbconnect"
+)
+
+// newConnectHandler returns with the path and the HTTP handler of the TodoListService for the
+// Connect, gRPC-Web and gRPC protocols, so browsers can call the service with the generated
+// TypeScript clients. The requests are forwarded to the gRPC server of the connection.
+func newConnectHandler(conn *grpc.ClientConn) (string, http.Handler) {
+	return todolistpbconnect.NewTodoListServiceHandler(&connectService{client: todolistpb.NewTodoListServiceClient(conn)})
+}
+
+// WithCORS returns with a handler allowing the cross-origin requests of the REST/JSON, Connect
+// and gRPC-Web clients from the given origins, "*" allows every origin. Without origins the
+// handler is returned as it is.
+func WithCORS(h http.Handler, origins []string) http.Handler {
+	if len(origins) == 0 {
+		return h
+	}
+
+	return cors.New(cors.Options{
+		AllowedOrigins: origins,
+		AllowedMethods: append(connectcors.AllowedMethods(), http.MethodPut, http.MethodPatch, http.MethodDelete),
+		AllowedHeaders: append(connectcors.AllowedHeaders(), "Authorization", requestIDHeader, "Traceparent", "Tracestate"),
+		ExposedHeaders: append(connectcors.ExposedHeaders(), requestIDHeader),
+		MaxAge:         7200,
+	}).Handler(h)
+}
+
+// connectService is implementing the Connect handler by calling the gRPC server
+type connectService struct {
+	client todolistpb.TodoListServiceClient
+}
+
+// requestIDHeader is the header of the request id, it is generated by the gRPC server
+// if the client did not send it
+const requestIDHeader = "X-Request-Id"
+
+// outgoingContext is forwarding the authorization and the request id headers of the request
+// and the address of the client as gRPC metadata
+func outgoingContext(ctx context.Context, req connect.AnyRequest) context.Context {
+	if auth := req.Header().Get("Authorization"); auth != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth)
+	}
+
+	if id := req.Header().Get(requestIDHeader); id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-request-id", id)
+	}
+
+	// the client is added to the addresses forwarded by the proxies in front of the gateway
+	forwarded := req.Header().Get("X-Forwarded-For")
+	if host, _, err := net.SplitHostPort(req.Peer().Addr); err == nil {
+		if forwarded != "" {
+			forwarded += ", "
+		}
+		forwarded += host
+	}
+	if forwarded != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-forwarded-for", forwarded)
+	}
+
+	return ctx
+}
+
+// setRequestID is copying the request id of the gRPC response header to the HTTP header
+func setRequestID(header http.Header, md metadata.MD) {
+	if ids := md.Get("x-request-id"); len(ids) > 0 {
+		header.Set(requestIDHeader, ids[0])
+	}
+}
+
+// connectError is converting the gRPC status errors to Connect errors with the same code
+func connectError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		return connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	}
+
+	return err
+}
+
+// unary is calling a unary method of the gRPC server
+func unary[Req, Res any](ctx context.Context, req *connect.Request[Req], call func(context.Context, *Req, ...grpc.CallOption) (*Res, error)) (*connect.Response[Res], error) {
+	var header metadata.MD
+	res, err := call(outgoingContext(ctx, req), req.Msg, grpc.Header(&header))
+	if err != nil {
+		return nil, connectError(err)
+	}
+
+	out := connect.NewResponse(res)
+	setRequestID(out.Header(), header)
+
+	return out, nil
+}
+
+// receiver is the client side of a server streaming gRPC method
+type receiver[Res any] interface {
+	Header() (metadata.MD, error)
+	Recv() (*Res, error)
+}
+
+// forward is sending the messages of a server streaming gRPC method to the Connect stream
+func forward[Res any](stream receiver[Res], err error, out *connect.ServerStream[Res]) error {
+	if err != nil {
+		return connectError(err)
+	}
+
+	// the headers are sent as soon as the server sent them, the stream can wait long for the first message
+	if header, err := stream.Header(); err == nil {
+		setRequestID(out.ResponseHeader(), header)
+		if err := out.Send(nil); err != nil {
+			return err
+		}
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return connectError(err)
+		}
+
+		if err := out.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *connectService) CreateTodo(ctx context.Context, req *connect.Request[todolistpb.CreateTodoRequest]) (*connect.Response[todolistpb.CreateTodoResponse], error) {
+	return unary(ctx, req, s.client.CreateTodo)
+}
+
+func (s *connectService) ReadTodo(ctx context.Context, req *connect.Request[todolistpb.ReadTodoRequest]) (*connect.Response[todolistpb.ReadTodoResponse], error) {
+	return unary(ctx, req, s.client.ReadTodo)
+}
+
+func (s *connectService) UpdateTodo(ctx context.Context, req *connect.Request[todolistpb.UpdateTodoRequest]) (*connect.Response[todolistpb.UpdateTodoResponse], error) {
+	return unary(ctx, req, s.client.UpdateTodo)
+}
+
+func (s *connectService) DeleteTodo(ctx context.Context, req *connect.Request[todolistpb.DeleteTodoRequest]) (*connect.Response[todolistpb.DeleteTodoResponse], error) {
+	return unary(ctx, req, s.client.DeleteTodo)
+}
+
+func (s *connectService) ListTodos(ctx context.Context, req *connect.Request[todolistpb.ListTodosRequest], out *connect.ServerStream[todolistpb.ListTodosResponse]) error {
+	stream, err := s.client.ListTodos(outgoingContext(ctx, req), req.Msg)
+	return forward[todolistpb.ListTodosResponse](stream, err, out)
+}
+
+func (s *connectService) WatchTodos(ctx context.Context, req *connect.Request[todolistpb.WatchTodosRequest], out *connect.ServerStream[todolistpb.WatchTodosResponse]) error {
+	stream, err := s.client.WatchTodos(outgoingContext(ctx, req), req.Msg)
+	return forward[todolistpb.WatchTodosResponse](stream, err, out)
+}
+
+func (s *connectService) CompleteTodo(ctx context.Context, req *connect.Request[todolistpb.CompleteTodoRequest]) (*connect.Response[todolistpb.CompleteTodoResponse], error) {
+	return unary(ctx, req, s.client.CompleteTodo)
+}
+
+func (s *connectService) ReopenTodo(ctx context.Context, req *connect.Request[todolistpb.ReopenTodoRequest]) (*connect.Response[todolistpb.ReopenTodoResponse], error) {
+	return unary(ctx, req, s.client.ReopenTodo)
+}
+
+func (s *connectService) RestoreTodo(ctx context.Context, req *connect.Request[todolistpb.RestoreTodoRequest]) (*connect.Response[todolistpb.RestoreTodoResponse], error) {
+	return unary(ctx, req, s.client.RestoreTodo)
+}
+
+func (s *connectService) ListTrash(ctx context.Context, req *connect.Request[todolistpb.ListTrashRequest], out *connect.ServerStream[todolistpb.ListTrashResponse]) error {
+	stream, err := s.client.ListTrash(outgoingContext(ctx, req), req.Msg)
+	return forward[todolistpb.ListTrashResponse](stream, err, out)
+}
+
+func (s *connectService) EmptyTrash(ctx context.Context, req *connect.Request[todolistpb.EmptyTrashRequest]) (*connect.Response[todolistpb.EmptyTrashResponse], error) {
+	return unary(ctx, req, s.client.EmptyTrash)
+}
+
+func (s *connectService) CreateTodoList(ctx context.Context, req *connect.Request[todolistpb.CreateTodoListRequest]) (*connect.Response[todolistpb.CreateTodoListResponse], error) {
+	return unary(ctx, req, s.client.CreateTodoList)
+}
+
+func (s *connectService) ReadTodoList(ctx context.Context, req *connect.Request[todolistpb.ReadTodoListRequest]) (*connect.Response[todolistpb.ReadTodoListResponse], error) {
+	return unary(ctx, req, s.client.ReadTodoList)
+}
+
+func (s *connectService) UpdateTodoList(ctx context.Context, req *connect.Request[todolistpb.UpdateTodoListRequest]) (*connect.Response[todolistpb.UpdateTodoListResponse], error) {
+	return unary(ctx, req, s.client.UpdateTodoList)
+}
+
+func (s *connectService) DeleteTodoList(ctx context.Context, req *connect.Request[todolistpb.DeleteTodoListRequest]) (*connect.Response[todolistpb.DeleteTodoListResponse], error) {
+	return unary(ctx, req, s.client.DeleteTodoList)
+}
+
+func (s *connectService) ListTodoLists(ctx context.Context, req *connect.Request[todolistpb.ListTodoListsRequest], out *connect.ServerStream[todolistpb.ListTodoListsResponse]) error {
+	stream, err := s.client.ListTodoLists(outgoingContext(ctx, req), req.Msg)
+	return forward[todolistpb.ListTodoListsResponse](stream, err, out)
+}
+
+func (s *connectService) ShareList(ctx context.Context, req *connect.Request[todolistpb.ShareListRequest]) (*connect.Response[todolistpb.ShareListResponse], error) {
+	return unary(ctx, req, s.client.ShareList)
+}
+
+func (s *connectService) UnshareList(ctx context.Context, req *connect.Request[todolistpb.UnshareListRequest]) (*connect.Response[todolistpb.UnshareListResponse], error) {
+	return unary(ctx, req, s.client.UnshareList)
+}
+
+func (s *connectService) ListMembers(ctx context.Context, req *connect.Request[todolistpb.ListMembersRequest], out *connect.ServerStream[todolistpb.ListMembersResponse]) error {
+	stream, err := s.client.ListMembers(outgoingContext(ctx, req), req.Msg)
+	return forward[todolistpb.ListMembersResponse](stream, err, out)
+}
+
+func (s *connectService) CreateWebhook(ctx context.Context, req *connect.Request[todolistpb.CreateWebhookRequest]) (*connect.Response[todolistpb.CreateWebhookResponse], error) {
+	return unary(ctx, req, s.client.CreateWebhook)
+}
+
+func (s *connectService) ReadWebhook(ctx context.Context, req *connect.Request[todolistpb.ReadWebhookRequest]) (*connect.Response[todolistpb.ReadWebhookResponse], error) {
+	return unary(ctx, req, s.client.ReadWebhook)
+}
+
+func (s *connectService) UpdateWebhook(ctx context.Context, req *connect.Request[todolistpb.UpdateWebhookRequest]) (*connect.Response[todolistpb.UpdateWebhookResponse], error) {
+	return unary(ctx, req, s.client.UpdateWebhook)
+}
+
+func (s *connectService) DeleteWebhook(ctx context.Context, req *connect.Request[todolistpb.DeleteWebhookRequest]) (*connect.Response[todolistpb.DeleteWebhookResponse], error) {
+	return unary(ctx, req, s.client.DeleteWebhook)
+}
+
+func (s *connectService) ListWebhooks(ctx context.Context, req *connect.Request[todolistpb.ListWebhooksRequest], out *connect.ServerStream[todolistpb.ListWebhooksResponse]) error {
+	stream, err := s.client.ListWebhooks(outgoingContext(ctx, req), req.Msg)
+	return forward[todolistpb.ListWebhooksResponse](stream, err, out)
+}
+
+func (s *connectService) ListWebhookDeliveries(ctx context.Context, req *connect.Request[todolistpb.ListWebhookDeliveriesRequest], out *connect.ServerStream[todolistpb.ListWebhookDeliveriesResponse]) error {
+	stream, err := s.client.ListWebhookDeliveries(outgoingContext(ctx, req), req.Msg)
+	return forward[todolistpb.ListWebhookDeliveriesResponse](stream, err, out)
+}
+
+func (s *connectService) GetTodoHistory(ctx context.Context, req *connect.Request[todolistpb.GetTodoHistoryRequest], out *connect.ServerStream[todolistpb.GetTodoHistoryResponse]) error {
+	stream, err := s.client.GetTodoHistory(outgoingContext(ctx, req), req.Msg)
+	return forward[todolistpb.GetTodoHistoryResponse](stream, err, out)
+}
+
+func (s *connectService) RevertTodo(ctx context.Context, req *connect.Request[todolistpb.RevertTodoRequest]) (*connect.Response[todolistpb.RevertTodoResponse], error) {
+	return unary(ctx, req, s.client.RevertTodo)
+}
@@ -0,0 +1,149 @@
+package gateway_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/halimi/todo-list-service/gateway"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"github.com/halimi/todo-list-service/todolistpb/todolistpbconnect"
+)
+
+func TestConnect(t *testing.T) {
+	ts := httptest.NewServer(setupGateway(t))
+	t.Cleanup(ts.Close)
+
+	ctx := context.Background()
+
+	clients := map[string]todolistpbconnect.TodoListServiceClient{
+		"connect":  todolistpbconnect.NewTodoListServiceClient(ts.Client(), ts.URL),
+		"grpc-web": todolistpbconnect.NewTodoListServiceClient(ts.Client(), ts.URL, connect.WithGRPCWeb()),
+	}
+
+	for name, client := range clients {
+		for _, title := range []string{"b", "a"} {
+			req := connect.NewRequest(&todolistpb.CreateTodoRequest{Todo: &todolistpb.Todo{Title: title, DueDate: ptypes.TimestampNow()}})
+			req.Header().Set("X-Request-Id", "request-"+title)
+			res, err := client.CreateTodo(ctx, req)
+			if err != nil {
+				t.Fatalf("%v: %v", name, err)
+			}
+
+			if got := res.Header().Get("X-Request-Id"); got != "request-"+title {
+				t.Fatalf("%v: Want: %v, Got: %v\n", name, "request-"+title, got)
+			}
+		}
+
+		_, err := client.ReadTodo(ctx, connect.NewRequest(&todolistpb.ReadTodoRequest{TodoId: 100}))
+		if connect.CodeOf(err) != connect.CodeNotFound {
+			t.Fatalf("%v: Want: %v, Got: %v\n", name, connect.CodeNotFound, err)
+		}
+
+		// the server stream is supported by both protocols
+		stream, err := client.ListTodos(ctx, connect.NewRequest(&todolistpb.ListTodosRequest{SortBy: todolistpb.ListTodosRequest_TITLE, PageSize: 1}))
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+
+		var titles []string
+		for stream.Receive() {
+			titles = append(titles, stream.Msg().GetTodo().GetTitle())
+		}
+		if err := stream.Err(); err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+
+		if len(titles) != 1 || titles[0] != "a" {
+			t.Fatalf("%v: Want: [a], Got: %v\n", name, titles)
+		}
+	}
+}
+
+func TestCORS(t *testing.T) {
+	h := gateway.WithCORS(setupGateway(t), []string{"https://app.example.com"})
+
+	tests := []struct {
+		origin     string
+		method     string
+		path       string
+		wantOrigin string
+	}{
+		{"https://app.example.com", "POST", todolistpbconnect.TodoListServiceCreateTodoProcedure, "https://app.example.com"},
+		{"https://app.example.com", "PATCH", "/v1/todos/1", "https://app.example.com"},
+		{"https://evil.example.com", "POST", todolistpbconnect.TodoListServiceCreateTodoProcedure, ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodOptions, tt.path, nil)
+		req.Header.Set("Origin", tt.origin)
+		req.Header.Set("Access-Control-Request-Method", tt.method)
+		req.Header.Set("Access-Control-Request-Headers", "connect-protocol-version,content-type,x-grpc-web")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+			t.Fatalf("%v %v: Want: %q, Got: %q\n", tt.origin, tt.method, tt.wantOrigin, got)
+		}
+	}
+}
+
+func TestConnectWatchTodos(t *testing.T) {
+	ts := httptest.NewServer(setupGateway(t))
+	t.Cleanup(ts.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := todolistpbconnect.NewTodoListServiceClient(ts.Client(), ts.URL, connect.WithGRPCWeb())
+
+	stream, err := client.WatchTodos(ctx, connect.NewRequest(&todolistpb.WatchTodosRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	events := make(chan *todolistpb.WatchTodosResponse, 100)
+	go func() {
+		for stream.Receive() {
+			events <- stream.Msg()
+		}
+		close(events)
+	}()
+
+	// the todos are created until the watch is started
+	var created *todolistpb.WatchTodosResponse
+	for created == nil {
+		req := connect.NewRequest(&todolistpb.CreateTodoRequest{Todo: &todolistpb.Todo{Title: "Watched", DueDate: ptypes.TimestampNow()}})
+		if _, err := client.CreateTodo(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case created = <-events:
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	id := created.GetTodo().GetId()
+	if _, err := client.DeleteTodo(ctx, connect.NewRequest(&todolistpb.DeleteTodoRequest{TodoId: id})); err != nil {
+		t.Fatal(err)
+	}
+
+	// the events of the todos created before the deleted one are skipped
+	for res := range events {
+		if res.GetTodo().GetId() == id {
+			if res.GetType() != todolistpb.WatchTodosResponse_DELETED || res.GetResumeToken() == "" {
+				t.Fatalf("Want: %v, Got: %v\n", todolistpb.WatchTodosResponse_DELETED, res)
+			}
+			return
+		}
+	}
+
+	t.Fatalf("Want: %v event, Got: %v\n", todolistpb.WatchTodosResponse_DELETED, stream.Err())
+}
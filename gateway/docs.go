@@ -0,0 +1,43 @@
+package gateway
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec is the OpenAPI v3 specification generated from the proto file by make gen
+//
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// docsPage is the Swagger UI page exploring the OpenAPI specification, the UI is loaded from a CDN
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>Todo List Service API</title>
+	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui.css">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js" crossorigin></script>
+	<script>
+		window.onload = function() {
+			window.ui = SwaggerUIBundle({url: "/openapi.yaml", dom_id: "#swagger-ui"});
+		};
+	</script>
+</body>
+</html>
+`
+
+// serveSpec is serving the OpenAPI specification
+func serveSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(openAPISpec)
+}
+
+// serveDocs is serving the interactive API documentation
+func serveDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(docsPage))
+}
@@ -0,0 +1,72 @@
+// Package gateway is serving the TodoListService as a REST/JSON API and for the browsers
+// over the Connect and gRPC-Web protocols
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// NewHandler returns with the HTTP handler of the REST/JSON API, the requests are forwarded
+// to the gRPC server of the connection. The gRPC status codes are mapped to HTTP status codes
+// and the errors are returned as JSON bodies. The OpenAPI specification of the API is served
+// on /openapi.yaml and its interactive documentation on /docs. The Connect and gRPC-Web
+// protocols are served on /todolist.TodoListService/. The trace context of the W3C traceparent
+// header is propagated to the gRPC server by the tracing of the connection.
+func NewHandler(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	gw := runtime.NewServeMux(
+		// the fields are named as in the proto file and the default values are not omitted
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}),
+		// the request id is passed in both directions without the Grpc-Metadata- prefix
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+	)
+
+	if err := todolistpb.RegisterTodoListServiceHandler(ctx, gw, conn); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.yaml", serveSpec)
+	mux.HandleFunc("/docs", serveDocs)
+	mux.Handle(newConnectHandler(conn))
+	mux.Handle("/", gw)
+
+	return withTraceContext(mux), nil
+}
+
+// withTraceContext is setting the trace context of the headers of the request in its context,
+// so the gRPC calls of the request are in the trace of the caller
+func withTraceContext(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// incomingHeader is forwarding the request id header to the gRPC server as metadata
+func incomingHeader(key string) (string, bool) {
+	if strings.EqualFold(key, requestIDHeader) {
+		return strings.ToLower(key), true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeader is returning the request id metadata of the gRPC server as a header
+func outgoingHeader(key string) (string, bool) {
+	if key == strings.ToLower(requestIDHeader) {
+		return requestIDHeader, true
+	}
+
+	return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
+}
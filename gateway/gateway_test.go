@@ -0,0 +1,205 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/gateway"
+	"github.com/halimi/todo-list-service/server"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// setupGateway returns with a gateway calling a gRPC server with an in-memory database,
+// the changes of the todos are broadcasted to the watchers
+func setupGateway(t *testing.T) http.Handler {
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	audit := &server.Audit{TrustForwarded: true}
+	s := grpc.NewServer(grpc.UnaryInterceptor(audit.UnaryServerInterceptor()), grpc.StreamInterceptor(audit.StreamServerInterceptor()))
+	todolistpb.RegisterTodoListServiceServer(s, &server.Server{Repo: db.NewBroadcaster(memory, 100), Webhooks: memory, History: memory})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.Dial()
+	}
+
+	conn, err := grpc.Dial("bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	h, err := gateway.NewHandler(context.Background(), conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return h
+}
+
+func do(t *testing.T, h http.Handler, method, path, body string) (int, string) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec.Code, rec.Body.String()
+}
+
+func decode(t *testing.T, body string) map[string]interface{} {
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &m); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", body, err)
+	}
+
+	return m
+}
+
+func TestGateway(t *testing.T) {
+	h := setupGateway(t)
+
+	tests := []struct {
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"POST", "/v1/todos", `{"title": "Buy milk", "due_date": "2000-01-01T00:00:00Z", "tags": ["home"]}`, http.StatusOK, `"title":"Buy milk"`},
+		{"GET", "/v1/todos/1", "", http.StatusOK, `"tags":["home"]`},
+		{"PATCH", "/v1/todos/1", `{"title": "Buy oat milk"}`, http.StatusOK, `"title":"Buy oat milk","note":""`},
+		{"PATCH", "/v1/todos/1?expected_version=1", `{"note": "Lost update"}`, http.StatusConflict, `"code":10`},
+		{"POST", "/v1/todos/1:complete", "", http.StatusOK, `"status":"DONE"`},
+		{"POST", "/v1/todos/1:reopen", "", http.StatusOK, `"status":"OPEN"`},
+		{"GET", "/v1/todos/1/history?page_size=1", "", http.StatusOK, `"action":"UPDATED"`},
+		{"POST", "/v1/todos/1:revert", `{"revision_id": "1"}`, http.StatusOK, `"title":"Buy milk"`},
+		{"POST", "/v1/todos/1:revert", `{"revision_id": "100"}`, http.StatusNotFound, `"code":5`},
+		{"GET", "/v1/todos/2", "", http.StatusNotFound, `"code":5`},
+		{"POST", "/v1/todos", `{"title": 1}`, http.StatusBadRequest, `"code":3`},
+		{"POST", "/v1/lists", `{"name": "Home"}`, http.StatusOK, `"name":"Home"`},
+		{"GET", "/v1/lists/1", "", http.StatusOK, `"name":"Home"`},
+		{"PUT", "/v1/lists/1", `{"name": "House"}`, http.StatusOK, `"name":"House"`},
+		{"DELETE", "/v1/lists/1", "", http.StatusOK, `{}`},
+		{"DELETE", "/v1/todos/1", "", http.StatusOK, `{}`},
+		{"DELETE", "/v1/todos/1", "", http.StatusNotFound, `"code":5`},
+		{"GET", "/v1/trash", "", http.StatusOK, `"deleted_at":"`},
+		{"POST", "/v1/trash/1:restore", "", http.StatusOK, `"title":"Buy milk"`},
+		{"POST", "/v1/trash/1:restore", "", http.StatusNotFound, `"code":5`},
+		{"DELETE", "/v1/todos/1", "", http.StatusOK, `{}`},
+		{"DELETE", "/v1/trash", "", http.StatusOK, `"count":"1"`},
+		{"GET", "/v1/todos/1", "", http.StatusNotFound, `"code":5`},
+		{"GET", "/v1/todos:watch?resume_token=invalid", "", http.StatusBadRequest, `"grpc_code":3`},
+		{"POST", "/v1/webhooks", `{"url": "https://example.com/hook", "secret": "s3cret", "event_types": ["todo.due"]}`, http.StatusOK, `"secret":""`},
+		{"PUT", "/v1/webhooks/1", `{"url": "https://example.com/new"}`, http.StatusOK, `"url":"https://example.com/new"`},
+		{"POST", "/v1/webhooks", `{"url": "https://example.com/hook", "secret": "s3cret", "event_types": ["todo.moved"]}`, http.StatusBadRequest, `"code":3`},
+		{"DELETE", "/v1/webhooks/1", "", http.StatusOK, `{}`},
+		{"GET", "/v1/webhooks/1", "", http.StatusNotFound, `"code":5`},
+	}
+
+	for _, tt := range tests {
+		status, body := do(t, h, tt.method, tt.path, tt.body)
+
+		if status != tt.wantStatus || !strings.Contains(body, tt.wantBody) {
+			t.Fatalf("%v %v: Want: %v %v, Got: %v %v\n", tt.method, tt.path, tt.wantStatus, tt.wantBody, status, body)
+		}
+
+		// every response and error is a JSON object
+		decode(t, body)
+	}
+}
+
+func TestGatewayListTodos(t *testing.T) {
+	h := setupGateway(t)
+
+	for _, title := range []string{"b", "a", "c"} {
+		if status, body := do(t, h, "POST", "/v1/todos", `{"title": "`+title+`", "due_date": "2000-01-01T00:00:00Z"}`); status != http.StatusOK {
+			t.Fatalf("Want: %v, Got: %v %v\n", http.StatusOK, status, body)
+		}
+	}
+
+	// the stream is returned as one JSON object per line
+	status, body := do(t, h, "GET", "/v1/todos?sort_by=TITLE&page_size=2", "")
+	if status != http.StatusOK {
+		t.Fatalf("Want: %v, Got: %v %v\n", http.StatusOK, status, body)
+	}
+
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Want: 2 todos, Got: %v\n", body)
+	}
+
+	var titles []string
+	var next string
+	for _, line := range lines {
+		res := decode(t, line)["result"].(map[string]interface{})
+		titles = append(titles, res["todo"].(map[string]interface{})["title"].(string))
+		next = res["next_page_token"].(string)
+	}
+
+	if strings.Join(titles, ",") != "a,b" || next == "" {
+		t.Fatalf("Want: a,b and the next page token, Got: %v %v\n", titles, next)
+	}
+
+	status, body = do(t, h, "GET", "/v1/todos?sort_by=TITLE&page_size=2&page_token="+next, "")
+	if status != http.StatusOK || !strings.Contains(body, `"title":"c"`) {
+		t.Fatalf("Want: %v c, Got: %v %v\n", http.StatusOK, status, body)
+	}
+
+	status, body = do(t, h, "GET", "/v1/todos?page_token=invalid", "")
+	if status != http.StatusBadRequest {
+		t.Fatalf("Want: %v, Got: %v %v\n", http.StatusBadRequest, status, body)
+	}
+}
+
+func TestGatewayRequestID(t *testing.T) {
+	h := setupGateway(t)
+
+	req := httptest.NewRequest("POST", "/v1/todos", strings.NewReader(`{"title": "Buy milk", "due_date": "2000-01-01T00:00:00Z"}`))
+	req.Header.Set("X-Request-Id", "request-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Header().Get("X-Request-Id") != "request-1" {
+		t.Fatalf("Want: %v request-1, Got: %v %v\n", http.StatusOK, rec.Code, rec.Header())
+	}
+
+	// the request id is generated if the client did not send it
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/todos/1", nil))
+	if rec.Header().Get("X-Request-Id") == "" {
+		t.Fatalf("Want: a request id, Got: %v\n", rec.Header())
+	}
+
+	// the client address of the gateway is the actor
+	status, body := do(t, h, "GET", "/v1/todos/1/history", "")
+	if status != http.StatusOK || !strings.Contains(body, `"actor":"192.0.2.1"`) || !strings.Contains(body, `"request_id":"request-1"`) {
+		t.Fatalf("Want: %v 192.0.2.1 request-1, Got: %v %v\n", http.StatusOK, status, body)
+	}
+}
+
+func TestGatewayDocs(t *testing.T) {
+	h := setupGateway(t)
+
+	status, body := do(t, h, "GET", "/openapi.yaml", "")
+	if status != http.StatusOK || !strings.Contains(body, "openapi: 3.") || !strings.Contains(body, "/v1/todos/{todo_id}:") {
+		t.Fatalf("Want: %v OpenAPI v3 spec, Got: %v %v\n", http.StatusOK, status, body)
+	}
+
+	status, body = do(t, h, "GET", "/docs", "")
+	if status != http.StatusOK || !strings.Contains(body, `url: "/openapi.yaml"`) {
+		t.Fatalf("Want: %v docs page, Got: %v %v\n", http.StatusOK, status, body)
+	}
+}
@@ -1,34 +1,297 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 
+	"github.com/halimi/todo-list-service/auth"
 	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/events"
+	"github.com/halimi/todo-list-service/gateway"
+	"github.com/halimi/todo-list-service/metrics"
 	"github.com/halimi/todo-list-service/server"
+	"github.com/halimi/todo-list-service/tlsconfig"
 	"github.com/halimi/todo-list-service/todolistpb"
+	"github.com/halimi/todo-list-service/tracing"
 
 	"github.com/kouhin/envflag"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 )
 
-func main() {
-	// set the flags to get the file name and line number
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
+// migrate is running the schema migration command and exits
+func migrate(driver string, config *db.PostgresConfig, sqliteConfig *db.SQLiteConfig, cmd string) {
+	var conn *sql.DB
+	var newMigrator func(*sql.DB) (*db.Migrator, error)
+	var err error
+	switch driver {
+	case "postgres":
+		conn, err = db.ConnectPostgres(config)
+		newMigrator = db.NewPostgresMigrator
+	case "sqlite":
+		conn, err = db.ConnectSQLite(sqliteConfig)
+		newMigrator = db.NewSQLiteMigrator
+	default:
+		log.Fatalf("Unknown database driver %q, use one of: postgres, sqlite", driver)
+	}
+	if err != nil {
+		log.Fatalf("Could not connect to the database: %v", err)
+	}
+	defer conn.Close()
+
+	m, err := newMigrator(conn)
+	if err != nil {
+		log.Fatalf("Could not load the migrations: %v", err)
+	}
+
+	ctx := context.Background()
+
+	switch cmd {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			log.Fatalf("Could not migrate up: %v", err)
+		}
+		for _, mig := range applied {
+			fmt.Printf("Applied %v_%v\n", mig.Version, mig.Name)
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+	case "down":
+		mig, err := m.Down(ctx)
+		if err != nil {
+			log.Fatalf("Could not migrate down: %v", err)
+		}
+		if mig == nil {
+			fmt.Println("No applied migrations")
+		} else {
+			fmt.Printf("Rolled back %v_%v\n", mig.Version, mig.Name)
+		}
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			log.Fatalf("Could not get the migration status: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied at " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%v_%v\t%v\n", s.Version, s.Name, state)
+		}
+	case "version":
+		version, err := m.Version(ctx)
+		if err != nil {
+			log.Fatalf("Could not get the migration version: %v", err)
+		}
+		fmt.Println(version)
+	default:
+		log.Fatalf("Unknown migrate command %q, use one of: up, down, status, version", cmd)
+	}
+}
+
+// newPublisher returns with the publisher of the change events
+func newPublisher(kind, file, webhookURL string) (events.EventPublisher, func() error) {
+	noClose := func() error { return nil }
+
+	switch kind {
+	case "none":
+		return events.Discard, noClose
+	case "stdout":
+		return &events.WriterPublisher{W: os.Stdout}, noClose
+	case "file":
+		p, err := events.NewFilePublisher(file)
+		if err != nil {
+			log.Fatalf("Could not open the event file: %v", err)
+		}
+		return p, p.Close
+	case "webhook":
+		if webhookURL == "" {
+			log.Fatalf("The webhook publisher needs the -event-webhook-url flag")
+		}
+		return &events.WebhookPublisher{URL: webhookURL, Client: &http.Client{Timeout: 10 * time.Second}}, noClose
+	default:
+		log.Fatalf("Unknown event publisher %q, use one of: none, stdout, file, webhook", kind)
+	}
+
+	return nil, nil
+}
+
+// newVerifier returns with the verifier of the bearer tokens, it is nil if no keys are configured
+func newVerifier(secretFile, jwksFile, issuer, audience string, leeway time.Duration) auth.Verifier {
+	keys := &auth.KeySet{}
+
+	if secretFile != "" {
+		secret, err := os.ReadFile(secretFile)
+		if err != nil {
+			log.Fatalf("Could not read the JWT secret: %v", err)
+		}
+
+		secret = []byte(strings.TrimSpace(string(secret)))
+		if len(secret) == 0 {
+			log.Fatalf("The JWT secret file %v is empty", secretFile)
+		}
+		keys.AddSecret("", secret)
+	}
+
+	if jwksFile != "" {
+		if err := keys.LoadJWKS(jwksFile); err != nil {
+			log.Fatalf("Could not load the JWKS: %v", err)
+		}
+	}
 
+	if keys.Len() == 0 {
+		return nil
+	}
+
+	return &auth.JWTVerifier{Keys: keys, Issuer: issuer, Audience: audience, Leeway: leeway}
+}
+
+// newTLSServer returns with the TLS configuration of the gRPC listener, it is nil if no certificate is configured
+func newTLSServer(certFile, keyFile, clientCAFile string, optionalClientCert bool, interval time.Duration) *tlsconfig.Server {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			log.Fatalf("The client certificates need the -tls-cert-file and the -tls-key-file flags")
+		}
+		return nil
+	}
+
+	if certFile == "" || keyFile == "" {
+		log.Fatalf("TLS needs both the -tls-cert-file and the -tls-key-file flags")
+	}
+
+	tlsServer := &tlsconfig.Server{
+		CertFile:           certFile,
+		KeyFile:            keyFile,
+		ClientCAFile:       clientCAFile,
+		OptionalClientCert: optionalClientCert,
+		Interval:           interval,
+	}
+	if err := tlsServer.Load(); err != nil {
+		log.Fatalf("Could not load the TLS certificates: %v", err)
+	}
+
+	return tlsServer
+}
+
+// newCertificateMapper returns with the mapper of the client certificates to the principals,
+// it is nil if the client certificates are not used for the authentication
+func newCertificateMapper(field, clientCAFile string) auth.CertificateMapper {
+	if field == "" {
+		return nil
+	}
+
+	if clientCAFile == "" {
+		log.Fatalf("The -tls-client-principal flag needs the -tls-client-ca-file flag")
+	}
+
+	mapper, err := auth.NewCertificateMapper(field)
+	if err != nil {
+		log.Fatalf("Could not map the client certificates: %v", err)
+	}
+
+	return mapper
+}
+
+// newLogger returns with the logger of the service writing to the standard error
+func newLogger(format, level string) *slog.Logger {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		log.Fatalf("Unknown log level %q, use one of: debug, info, warn, error", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: l}
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, opts))
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts))
+	default:
+		log.Fatalf("Unknown log format %q, use one of: text, json", format)
+	}
+
+	return nil
+}
+
+func main() {
+	dbDriver := flag.String("db-driver", "postgres", "DB driver: postgres, sqlite or memory")
+	dbPath := flag.String("db-path", "todo.db", "SQLite database file or :memory:")
+	dbSnapshot := flag.String("db-snapshot", "", "JSON snapshot file of the memory DB, it is loaded on start and saved on exit")
 	dbUser := flag.String("db-user", "postgres", "DB user name")
 	dbPass := flag.String("db-pass", "postgres", "DB password")
 	dbHost := flag.String("db-host", "localhost", "DB host name")
 	dbPort := flag.String("db-port", "5432", "DB port number")
+	dbRowLevelSecurity := flag.Bool("db-row-level-security", false, "Enforce the owners of the todos with the row-level security policies of Postgres")
+	watchHistory := flag.Int("watch-history", 1000, "Number of the last todo changes kept in memory to resume the watches, not used by postgres")
+	watchRetention := flag.Duration("watch-retention", 7*24*time.Hour, "Time the todo changes are kept by postgres to resume the watches, 0 keeps them forever")
+	httpPort := flag.String("http-port", "8080", "HTTP port number of the REST/JSON gateway, the gateway is not served if empty")
+	corsOrigins := flag.String("cors-origins", "", "Comma separated list of the origins allowed to call the HTTP gateway from browsers, * allows every origin")
+	eventPublisher := flag.String("event-publisher", "none", "Publisher of the todo change events: none, stdout, file or webhook")
+	eventFile := flag.String("event-file", "events.jsonl", "File of the file event publisher")
+	eventWebhookURL := flag.String("event-webhook-url", "", "URL of the webhook event publisher")
+	webhookAttempts := flag.Int("webhook-attempts", 5, "Number of the attempts of a webhook delivery")
+	webhookDisableAfter := flag.Int("webhook-disable-after", 10, "Number of the failed deliveries in a row disabling a webhook")
+	webhookTimeout := flag.Duration("webhook-timeout", 10*time.Second, "Timeout of a webhook request")
+	webhookAllowedNetworks := flag.String("webhook-allowed-networks", "", "Comma separated CIDRs of the loopback and private networks the webhooks can be delivered to, e.g. 127.0.0.0/8")
+	webhookDueInterval := flag.Duration("webhook-due-interval", time.Minute, "Interval of checking the due todos for the webhooks, 0 disables the todo.due events")
+	trashRetention := flag.Duration("trash-retention", 30*24*time.Hour, "Time the deleted todos are kept in the trash before they are purged, 0 disables the purge")
+	trashPurgeInterval := flag.Duration("trash-purge-interval", time.Hour, "Interval of purging the expired todos from the trash")
+	authSecretFile := flag.String("auth-jwt-secret-file", "", "File of the HS256 secret of the bearer tokens")
+	authJWKSFile := flag.String("auth-jwks-file", "", "JSON Web Key Set file of the RS256 public keys and the HS256 secrets of the bearer tokens")
+	authIssuer := flag.String("auth-issuer", "", "Required issuer (iss) of the bearer tokens, not checked if empty")
+	authAudience := flag.String("auth-audience", "", "Required audience (aud) of the bearer tokens, not checked if empty")
+	authLeeway := flag.Duration("auth-leeway", time.Minute, "Allowed clock skew when the expiry of the bearer tokens is checked")
+	tlsCertFile := flag.String("tls-cert-file", "", "PEM file of the certificate of the gRPC server, the server is not using TLS if empty")
+	tlsKeyFile := flag.String("tls-key-file", "", "PEM file of the private key of the gRPC server")
+	tlsClientCAFile := flag.String("tls-client-ca-file", "", "PEM file of the CAs of the client certificates, the client certificates are required if set")
+	tlsClientCertOptional := flag.Bool("tls-client-cert-optional", false, "Accept the connections without a client certificate, the sent certificates are verified anyway")
+	tlsClientPrincipal := flag.String("tls-client-principal", "", "Field of the client certificates authenticating the callers: common-name, uri or email, not used if empty")
+	tlsReloadInterval := flag.Duration("tls-reload-interval", time.Minute, "Interval of checking the certificate files for changes")
+	logFormat := flag.String("log-format", "text", "Format of the logs: text or json")
+	logLevel := flag.String("log-level", "info", "Level of the logs: debug, info, warn or error")
+	metricsPort := flag.String("metrics-port", "2112", "HTTP port number of the Prometheus metrics, the metrics are not served if empty")
+	metricsCountInterval := flag.Duration("metrics-count-interval", time.Minute, "Interval of counting the open and the overdue todos for the metrics")
+	traceExporter := flag.String("trace-exporter", "none", "Exporter of the OpenTelemetry traces: none, otlp, stdout or file")
+	traceOTLPEndpoint := flag.String("trace-otlp-endpoint", "", "host:port of the OTLP/HTTP collector, OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4318 if empty")
+	traceOTLPInsecure := flag.Bool("trace-otlp-insecure", false, "Send the traces to the OTLP collector without TLS")
+	traceFile := flag.String("trace-file", "traces.jsonl", "File of the file trace exporter")
+	traceSampleRatio := flag.Float64("trace-sample-ratio", 1, "Ratio of the sampled new traces, the sampling of the callers is followed")
+	logMethodLevels := flag.String("log-method-levels", "", "Comma separated list of method=level pairs overriding the level of the request logs, e.g. WatchTodos=debug")
 
 	envflag.Parse()
 
+	// the standard logger is writing through the logger too
+	logger := newLogger(*logFormat, *logLevel)
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName:  "todo-list-service",
+		Exporter:     *traceExporter,
+		OTLPEndpoint: *traceOTLPEndpoint,
+		OTLPInsecure: *traceOTLPInsecure,
+		File:         *traceFile,
+		SampleRatio:  *traceSampleRatio,
+	})
+	if err != nil {
+		log.Fatalf("Could not set up the tracing: %v", err)
+	}
+
 	config := &db.PostgresConfig{
 		User:     *dbUser,
 		Password: *dbPass,
@@ -36,10 +299,163 @@ func main() {
 		Port:     *dbPort,
 	}
 
-	postgres := &db.Postgres{db.Setup(config)}
+	sqliteConfig := &db.SQLiteConfig{
+		Path: *dbPath,
+	}
+
+	if flag.Arg(0) == "migrate" {
+		migrate(*dbDriver, config, sqliteConfig, flag.Arg(1))
+		return
+	}
+
+	var repo db.Repository
+	// the outbox, the webhooks, the history and the members are implemented by the databases, the broadcaster is not forwarding them
+	var outbox db.Outbox
+	var webhooks db.WebhookStore
+	var history db.History
+	var members db.MemberStore
+	// the changes of the todos are recorded for the watches only by postgres
+	var eventLog db.EventLog
+	// the connection pool of the SQL databases is reported in the metrics
+	var sqlDB *sql.DB
+	switch *dbDriver {
+	case "postgres":
+		pg := db.Setup(config)
+		listener, err := db.ListenPostgres(config)
+		if err != nil {
+			log.Fatalf("Could not listen on the database: %v", err)
+		}
+		if *dbRowLevelSecurity {
+			if err := db.EnforceRowLevelSecurity(context.Background(), pg); err != nil {
+				log.Fatalf("Could not enable the row-level security: %v", err)
+			}
+		}
+		postgres := &db.Postgres{DB: pg, Listener: listener, RowLevelSecurity: *dbRowLevelSecurity}
+		repo, outbox, webhooks, history, members = postgres, postgres, postgres, postgres, postgres
+		eventLog = postgres
+		sqlDB = pg
+	case "sqlite":
+		// the changes are broadcasted in the process, as SQLite has no notifications
+		sqlite := &db.SQLite{DB: db.SetupSQLite(sqliteConfig)}
+		repo, outbox, webhooks, history, members = db.NewBroadcaster(sqlite, *watchHistory), sqlite, sqlite, sqlite, sqlite
+		sqlDB = sqlite.DB
+	case "memory":
+		memory, err := db.NewMemory(*dbSnapshot)
+		if err != nil {
+			log.Fatalf("Could not create the memory database: %v", err)
+		}
+		repo, outbox, webhooks, history, members = db.NewBroadcaster(memory, *watchHistory), memory, memory, memory, memory
+	default:
+		log.Fatalf("Unknown database driver %q, use one of: postgres, sqlite, memory", *dbDriver)
+	}
+
+	// the repository calls are traced and observed by the metrics, the calls of the workers too,
+	// the calls of the other stores are traced
+	metric := metrics.New()
+	if sqlDB != nil {
+		if err := metric.RegisterDB(sqlDB, *dbDriver); err != nil {
+			log.Fatalf("Could not register the database metrics: %v", err)
+		}
+	}
+	repo = metric.Repository(tracing.Repository(repo, *dbDriver))
+	outbox = tracing.Outbox(outbox, *dbDriver)
+	webhooks = tracing.Webhooks(webhooks, *dbDriver)
+	history = tracing.History(history, *dbDriver)
+	members = tracing.Members(members, *dbDriver)
+
+	publisher, closePublisher := newPublisher(*eventPublisher, *eventFile, *eventWebhookURL)
+
+	// the webhooks are registered by the callers, so they are not delivered to the internal networks
+	allowedNetworks, err := events.ParseNetworks(*webhookAllowedNetworks)
+	if err != nil {
+		log.Fatalf("Could not parse the -webhook-allowed-networks flag: %v", err)
+	}
+
+	dispatcher := &events.WebhookDispatcher{
+		Store:        webhooks,
+		Repo:         repo,
+		Members:      members,
+		Client:       events.WebhookClient(*webhookTimeout, allowedNetworks),
+		MaxAttempts:  *webhookAttempts,
+		DisableAfter: int32(*webhookDisableAfter),
+	}
+
+	// the background workers are stopped before the database is closed
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		slog.Info("Starting the event relay")
+		relay := &events.Relay{Outbox: outbox, Publisher: events.MultiPublisher(publisher, dispatcher)}
+		if err := relay.Run(workerCtx); err != context.Canceled {
+			log.Fatalf("Failed to relay the events: %v", err)
+		}
+	}()
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		slog.Info("Starting the webhook dispatcher")
+		if err := dispatcher.Run(workerCtx); err != context.Canceled {
+			log.Fatalf("Failed to deliver the webhooks: %v", err)
+		}
+	}()
+
+	if *webhookDueInterval > 0 {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			notifier := &events.DueNotifier{Repo: repo, Dispatcher: dispatcher, Interval: *webhookDueInterval}
+			if err := notifier.Run(workerCtx); err != context.Canceled {
+				log.Fatalf("Failed to notify the due todos: %v", err)
+			}
+		}()
+	}
+
+	if *trashRetention > 0 {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			purger := &db.Purger{Repo: repo, Retention: *trashRetention, Interval: *trashPurgeInterval}
+			if err := purger.Run(workerCtx); err != context.Canceled {
+				log.Fatalf("Failed to purge the trash: %v", err)
+			}
+		}()
+	}
+
+	if eventLog != nil && *watchRetention > 0 {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			pruner := &db.EventPruner{Log: eventLog, Retention: *watchRetention}
+			if err := pruner.Run(workerCtx); err != context.Canceled {
+				log.Fatalf("Failed to prune the todo events: %v", err)
+			}
+		}()
+	}
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		counter := &metrics.TodoCounter{Repo: repo, Metrics: metric, Interval: *metricsCountInterval}
+		if err := counter.Run(workerCtx); err != context.Canceled {
+			log.Fatalf("Failed to count the todos: %v", err)
+		}
+	}()
 
-	if postgres == nil {
-		panic("postgres is nil")
+	tlsServer := newTLSServer(*tlsCertFile, *tlsKeyFile, *tlsClientCAFile, *tlsClientCertOptional, *tlsReloadInterval)
+	if tlsServer != nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			if err := tlsServer.Run(workerCtx); err != context.Canceled {
+				log.Fatalf("Failed to reload the TLS certificates: %v", err)
+			}
+		}()
+	} else {
+		log.Println("No TLS certificate is configured, the gRPC server is not using TLS")
 	}
 
 	lis, err := net.Listen("tcp", "0.0.0.0:5000")
@@ -47,19 +463,121 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	opts := []grpc.ServerOption{}
-	s := grpc.NewServer(opts...)
+	methodLevels, err := server.ParseMethodLevels(*logMethodLevels)
+	if err != nil {
+		log.Fatalf("Could not parse the log levels of the methods: %v", err)
+	}
+	requestLogger := &server.RequestLogger{Logger: logger, Levels: methodLevels}
 
-	todolistpb.RegisterTodoListServiceServer(s, &server.Server{postgres})
+	// the requests are logged and measured first, so the rejected requests are logged and counted too,
+	// the callers are authenticated before the actor and the request id of the changes are set for
+	// the history of the todos, and the repository calls are scoped to their todos
+	unary := []grpc.UnaryServerInterceptor{requestLogger.UnaryServerInterceptor(), metric.UnaryServerInterceptor()}
+	stream := []grpc.StreamServerInterceptor{requestLogger.StreamServerInterceptor(), metric.StreamServerInterceptor()}
+	verifier := newVerifier(*authSecretFile, *authJWKSFile, *authIssuer, *authAudience, *authLeeway)
+	mapper := newCertificateMapper(*tlsClientPrincipal, *tlsClientCAFile)
+	if verifier != nil || mapper != nil {
+		authenticator := &auth.Authenticator{Tokens: verifier, Certificates: mapper}
+		unary = append(unary, authenticator.UnaryServerInterceptor())
+		stream = append(stream, authenticator.StreamServerInterceptor())
+	} else {
+		log.Println("No JWT keys or client certificate mapping are configured, the requests are not authenticated")
+	}
+
+	// only the server of the gateway is taking the client address of the history from the forwarded
+	// addresses, the clients of the gRPC listener could send any address
+	todoServer := &server.Server{Repo: repo, Webhooks: webhooks, History: history, Members: members}
+	newServer := func(audit *server.Audit, opts ...grpc.ServerOption) *grpc.Server {
+		opts = append(opts,
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
+			grpc.ChainUnaryInterceptor(unary...),
+			grpc.ChainUnaryInterceptor(audit.UnaryServerInterceptor(), server.TenantUnaryInterceptor),
+			grpc.ChainStreamInterceptor(stream...),
+			grpc.ChainStreamInterceptor(audit.StreamServerInterceptor(), server.TenantStreamInterceptor),
+		)
+		s := grpc.NewServer(opts...)
+		todolistpb.RegisterTodoListServiceServer(s, todoServer)
+		return s
+	}
+
+	var opts []grpc.ServerOption
+	if tlsServer != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsServer.Config())))
+	}
+	s := newServer(&server.Audit{}, opts...)
 	reflection.Register(s)
 
 	go func() {
-		fmt.Println("Starting server...")
+		slog.Info("Starting server")
 		if err := s.Serve(lis); err != nil {
 			log.Fatalf("Failed to serve: %v", err)
 		}
 	}()
 
+	// the gateway is calling an in-process gRPC server without TLS, so it does not need a client certificate,
+	// the callers of the gateway are authenticated by their bearer tokens. When the client certificates are
+	// required the gateway would be an unauthenticated way around them, so it needs the bearer tokens.
+	var local *grpc.Server
+	var httpServer *http.Server
+	if *httpPort != "" {
+		if *tlsClientCAFile != "" && !*tlsClientCertOptional && verifier == nil {
+			log.Fatalf("The client certificates are required, the HTTP gateway needs the -auth-jwt-secret-file or the -auth-jwks-file flag, or it can be disabled by an empty -http-port")
+		}
+
+		localLis := bufconn.Listen(1024 * 1024)
+		local = newServer(&server.Audit{TrustForwarded: true})
+		go func() {
+			if err := local.Serve(localLis); err != nil {
+				log.Fatalf("Failed to serve the gateway: %v", err)
+			}
+		}()
+
+		dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+			return localLis.Dial()
+		}
+
+		conn, err := grpc.Dial("bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure(), grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+		if err != nil {
+			log.Fatalf("Could not connect to the gRPC server: %v", err)
+		}
+		defer conn.Close()
+
+		handler, err := gateway.NewHandler(context.Background(), conn)
+		if err != nil {
+			log.Fatalf("Could not create the gateway: %v", err)
+		}
+
+		var origins []string
+		for _, origin := range strings.Split(*corsOrigins, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				origins = append(origins, origin)
+			}
+		}
+
+		httpServer = &http.Server{Addr: "0.0.0.0:" + *httpPort, Handler: gateway.WithCORS(handler, origins)}
+
+		go func() {
+			slog.Info("Starting HTTP gateway")
+			if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
+				log.Fatalf("Failed to serve HTTP: %v", err)
+			}
+		}()
+	}
+
+	var metricsServer *http.Server
+	if *metricsPort != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metric.Handler())
+		metricsServer = &http.Server{Addr: "0.0.0.0:" + *metricsPort, Handler: mux}
+
+		go func() {
+			slog.Info("Starting the metrics server")
+			if err := metricsServer.ListenAndServe(); err != http.ErrServerClosed {
+				log.Fatalf("Failed to serve the metrics: %v", err)
+			}
+		}()
+	}
+
 	// Wait for Control C to exit
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt)
@@ -67,16 +585,41 @@ func main() {
 	// Block until a signal is received
 	<-ch
 
-	fmt.Println("Closing Postgres connection")
-	if err := postgres.Close(); err != nil {
-		log.Fatalf("Error on closing the database: %v", err)
+	if httpServer != nil {
+		slog.Info("Stopping the HTTP gateway")
+		if err := httpServer.Shutdown(context.Background()); err != nil {
+			log.Fatalf("Error on stopping the HTTP gateway: %v", err)
+		}
 	}
 
-	fmt.Println("Closing the listener")
-	if err := lis.Close(); err != nil {
-		log.Fatalf("Error on closing the listener: %v", err)
+	if metricsServer != nil {
+		slog.Info("Stopping the metrics server")
+		if err := metricsServer.Shutdown(context.Background()); err != nil {
+			log.Fatalf("Error on stopping the metrics server: %v", err)
+		}
 	}
 
-	fmt.Println("Stopping the server")
+	slog.Info("Stopping the event relay")
+	stopWorkers()
+	workers.Wait()
+	if err := closePublisher(); err != nil {
+		log.Fatalf("Error on closing the event publisher: %v", err)
+	}
+
+	slog.Info("Closing the database connection")
+	if err := repo.Close(); err != nil {
+		log.Fatalf("Error on closing the database: %v", err)
+	}
+
+	// the server is closing the listener, so Serve returns without an error and the traces are flushed
+	slog.Info("Stopping the server")
+	if local != nil {
+		local.Stop()
+	}
 	s.Stop()
+
+	slog.Info("Flushing the traces")
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Fatalf("Error on flushing the traces: %v", err)
+	}
 }
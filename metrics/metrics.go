@@ -0,0 +1,135 @@
+// Package metrics is collecting the Prometheus metrics of the gRPC requests, the repository calls,
+// the database connections and the todos
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics is the collectors of the metrics of the service
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	repoDuration    *prometheus.HistogramVec
+	openTodos       prometheus.Gauge
+	overdueTodos    prometheus.Gauge
+}
+
+// New returns with the metrics of the service in a new registry, the Go runtime and the process metrics
+// are collected too
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Number of the gRPC requests completed on the server by their status code.",
+		}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Duration of the gRPC requests on the server.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		repoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "todolist_repository_duration_seconds",
+			Help:    "Duration of the repository calls by their operation and result.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation", "result"}),
+		openTodos: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "todolist_open_todos",
+			Help: "Number of the open and in progress todos of every owner.",
+		}),
+		overdueTodos: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "todolist_overdue_todos",
+			Help: "Number of the open and in progress todos of every owner with a past due date.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.repoDuration,
+		m.openTodos,
+		m.overdueTodos,
+	)
+
+	return m
+}
+
+// Handler returns with the HTTP handler of the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDB is collecting the connection pool statistics of the database, the open connections,
+// the connections in use and the waits for a connection
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// UnaryServerInterceptor returns with an interceptor counting the requests by their status code
+// and observing their duration
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		m.observeRequest("unary", info.FullMethod, start, err)
+
+		return res, err
+	}
+}
+
+// StreamServerInterceptor returns with an interceptor counting the streams by their status code
+// and observing their duration
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observeRequest(streamType(info), info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// observeRequest is counting the request and observing its duration
+func (m *Metrics) observeRequest(kind, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+
+	m.requests.WithLabelValues(kind, service, method, status.Code(err).String()).Inc()
+	m.requestDuration.WithLabelValues(kind, service, method).Observe(time.Since(start).Seconds())
+}
+
+// streamType returns with the type of the stream as it is labelled
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	default:
+		return "server_stream"
+	}
+}
+
+// splitMethod returns with the service and the method of the full method name
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+
+	return "unknown", fullMethod
+}
@@ -0,0 +1,146 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/metrics"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// scrape returns with the metrics in the Prometheus exposition format
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if rec.Code != 200 {
+		t.Fatalf("Want: 200, Got: %v\n", rec.Code)
+	}
+
+	return rec.Body.String()
+}
+
+// checkMetrics is checking that the lines are in the metrics
+func checkMetrics(t *testing.T, m *metrics.Metrics, lines ...string) {
+	t.Helper()
+
+	got := scrape(t, m)
+	for _, line := range lines {
+		if !strings.Contains(got, line+"\n") {
+			t.Fatalf("Want: %v, Got: %v\n", line, got)
+		}
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	m := metrics.New()
+
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	notFound := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Errorf(codes.NotFound, "Not found")
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/todolist.TodoListService/ReadTodo"}
+	for _, handler := range []grpc.UnaryHandler{ok, ok, notFound} {
+		m.UnaryServerInterceptor()(context.Background(), nil, info, handler)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream) error {
+		return nil
+	}
+	m.StreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{FullMethod: "/todolist.TodoListService/ListTodos", IsServerStream: true}, stream)
+
+	checkMetrics(t, m,
+		`grpc_server_handled_total{grpc_code="OK",grpc_method="ReadTodo",grpc_service="todolist.TodoListService",grpc_type="unary"} 2`,
+		`grpc_server_handled_total{grpc_code="NotFound",grpc_method="ReadTodo",grpc_service="todolist.TodoListService",grpc_type="unary"} 1`,
+		`grpc_server_handled_total{grpc_code="OK",grpc_method="ListTodos",grpc_service="todolist.TodoListService",grpc_type="server_stream"} 1`,
+		`grpc_server_handling_seconds_count{grpc_method="ReadTodo",grpc_service="todolist.TodoListService",grpc_type="unary"} 3`,
+	)
+}
+
+func TestRepository(t *testing.T) {
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := metrics.New()
+
+	// the repository is a watcher only if the wrapped one is a watcher
+	if _, ok := m.Repository(memory).(db.Watcher); ok {
+		t.Fatalf("Want: not a db.Watcher, Got: db.Watcher\n")
+	}
+
+	repo := m.Repository(db.NewBroadcaster(memory, 10))
+	if _, ok := repo.(db.Watcher); !ok {
+		t.Fatalf("Want: db.Watcher, Got: %T\n", repo)
+	}
+
+	id, err := repo.Insert(context.Background(), &todolistpb.Todo{Title: "Metrics", DueDate: ptypes.TimestampNow()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.Get(context.Background(), id); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := repo.Get(ctx, id); err == nil {
+		t.Fatalf("Want: error, Got: nil\n")
+	}
+
+	checkMetrics(t, m,
+		`todolist_repository_duration_seconds_count{operation="insert",result="ok"} 1`,
+		`todolist_repository_duration_seconds_count{operation="get",result="ok"} 1`,
+		`todolist_repository_duration_seconds_count{operation="get",result="error"} 1`,
+	)
+}
+
+func TestTodoCounter(t *testing.T) {
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := metrics.New()
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, d := range []time.Duration{-time.Hour, -time.Minute, time.Hour} {
+		dueDate, err := ptypes.TimestampProto(now.Add(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := memory.Insert(db.WithTenant(ctx, "alice"), &todolistpb.Todo{Title: "Counted", DueDate: dueDate}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the done todos are not counted even if they are overdue
+	done, err := memory.Insert(db.WithTenant(ctx, "bob"), &todolistpb.Todo{Title: "Done", DueDate: ptypes.TimestampNow()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := memory.Complete(db.WithTenant(ctx, "bob"), done, ptypes.TimestampNow()); err != nil {
+		t.Fatal(err)
+	}
+
+	counter := &metrics.TodoCounter{Repo: memory, Metrics: m}
+	if err := counter.Count(ctx, now); err != nil {
+		t.Fatal(err)
+	}
+
+	checkMetrics(t, m, "todolist_open_todos 3", "todolist_overdue_todos 2")
+}
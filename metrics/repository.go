@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/halimi/todo-list-service/db"
+)
+
+// Repository returns with the repository observing the duration of the calls of repo by their operation,
+// the returned repository is a db.Watcher if repo is a watcher
+func (m *Metrics) Repository(repo db.Repository) db.Repository {
+	return db.Instrument(repo, m.observeRepository)
+}
+
+// observeRepository is observing the duration of the repository call
+func (m *Metrics) observeRepository(ctx context.Context, operation string) (context.Context, func(error)) {
+	start := time.Now()
+
+	return ctx, func(err error) {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		m.repoDuration.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/halimi/todo-list-service/db"
+)
+
+const defaultCountInterval = time.Minute
+
+// TodoCounter is setting the gauges of the open and the overdue todos of every owner, the todos are
+// counted periodically by the database instead of on every scrape
+type TodoCounter struct {
+	Repo    db.Repository
+	Metrics *Metrics
+
+	// Interval is the time between the counts, 1m if 0
+	Interval time.Duration
+}
+
+// Run is counting the todos until the context is done
+func (c *TodoCounter) Run(ctx context.Context) error {
+	interval := c.Interval
+	if interval <= 0 {
+		interval = defaultCountInterval
+	}
+
+	for {
+		if err := c.Count(ctx, time.Now()); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Printf("Could not count the todos: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Count is setting the gauges of the open todos and the todos which were due before now
+func (c *TodoCounter) Count(ctx context.Context, now time.Time) error {
+	dueBefore, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return err
+	}
+
+	open, overdue, err := c.Repo.CountTodos(db.WithAllTenants(ctx), dueBefore)
+	if err != nil {
+		return err
+	}
+
+	c.Metrics.openTodos.Set(float64(open))
+	c.Metrics.overdueTodos.Set(float64(overdue))
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/halimi/todo-list-service/auth"
+	"github.com/halimi/todo-list-service/db"
+)
+
+// RequestIDHeader is the metadata of the request id, it is generated if the client did not send it
+// and it is returned in the response header
+const RequestIDHeader = "x-request-id"
+
+// maxRequestIDLength is the length of the longest request id accepted from the clients
+const maxRequestIDLength = 128
+
+// Audit is setting the audit of the requests in their context, so the changes of the todos
+// are recorded with the actor and the request id in their history
+type Audit struct {
+	// TrustForwarded is taking the client address from the X-Forwarded-For metadata of the requests, it is set
+	// only on the server of the in-process gateway connection. The last forwarded address is the client of the
+	// gateway, the addresses before it are sent by the client, so they are never trusted.
+	TrustForwarded bool
+}
+
+// UnaryServerInterceptor returns with an interceptor setting the audit of the request in the context
+func (a *Audit) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		audit := a.requestAudit(ctx)
+		if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, audit.RequestID)); err != nil {
+			return nil, err
+		}
+
+		return handler(db.WithAudit(ctx, audit), req)
+	}
+}
+
+// StreamServerInterceptor returns with an interceptor setting the audit of the request in the context of the stream
+func (a *Audit) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		audit := a.requestAudit(ss.Context())
+		if err := ss.SetHeader(metadata.Pairs(RequestIDHeader, audit.RequestID)); err != nil {
+			return err
+		}
+
+		return handler(srv, &contextStream{ServerStream: ss, ctx: db.WithAudit(ss.Context(), audit)})
+	}
+}
+
+// contextStream is a server stream with the context set by an interceptor
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// requestAudit returns with the audit of the request, the actor is the owner of the authenticated principal,
+// which is namespaced by its issuer the same way as the todos, or without authentication the peer address, which is the last X-Forwarded-For address
+// for the requests coming through the gateway
+func (a *Audit) requestAudit(ctx context.Context) db.Audit {
+	var audit db.Audit
+
+	audit.RequestID = requestID(ctx)
+
+	if p, ok := auth.FromContext(ctx); ok {
+		audit.Actor = p.Owner()
+		return audit
+	}
+
+	if a.TrustForwarded {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+			addrs := strings.Split(forwarded[len(forwarded)-1], ",")
+			audit.Actor = strings.TrimSpace(addrs[len(addrs)-1])
+		}
+	}
+
+	if audit.Actor == "" {
+		audit.Actor = peerAddress(ctx)
+	}
+
+	return audit
+}
+
+type requestIDKey struct{}
+
+// withRequestID returns with a context of the request id, so the interceptors later in the chain
+// are using the same request id
+func withRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// requestID returns with the request id of the context, or the request id sent by the client
+// which is generated if the client did not send it
+func requestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(RequestIDHeader); len(ids) > 0 && ids[0] != "" && len(ids[0]) <= maxRequestIDLength {
+		return ids[0]
+	}
+
+	return newRequestID()
+}
+
+// newRequestID returns with a random request id
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// GetTodoHistory request handler
+func (s *Server) GetTodoHistory(req *todolistpb.GetTodoHistoryRequest, stream todolistpb.TodoListService_GetTodoHistoryServer) error {
+	ctx := stream.Context()
+
+	history, err := s.history()
+	if err != nil {
+		return err
+	}
+
+	if req.GetTodoId() == 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	if req.GetPageSize() < 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Page size can not be negative: %v", req.GetPageSize()),
+		)
+	}
+
+	todoCtx, err := s.todoContext(ctx, req.GetTodoId(), todolistpb.ListMember_VIEWER)
+	if err != nil {
+		return err
+	}
+
+	revisions, next, err := history.ListTodoHistory(todoCtx, req)
+	if errors.Is(err, db.ErrInvalidPageToken) {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Invalid page token: %v", req.GetPageToken()),
+		)
+	}
+	if err != nil {
+		return repositoryError(ctx, err)
+	}
+
+	for i, revision := range revisions {
+		res := &todolistpb.GetTodoHistoryResponse{Revision: revision}
+		if i == len(revisions)-1 {
+			res.NextPageToken = next
+		}
+
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RevertTodo request handler, the fields of the todo are set back to the state after the revision,
+// the todo stays in the trash or out of it
+func (s *Server) RevertTodo(ctx context.Context, req *todolistpb.RevertTodoRequest) (*todolistpb.RevertTodoResponse, error) {
+	history, err := s.history()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetTodoId() == 0 || req.GetRevisionId() == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	todoCtx, err := s.todoContext(ctx, req.GetTodoId(), todolistpb.ListMember_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := history.GetTodoRevision(todoCtx, req.GetRevisionId())
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if revision.GetId() == 0 || revision.GetTodoId() != req.GetTodoId() {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Could not found the revision of the Todo with the specified ID: %v", req.GetRevisionId()),
+		)
+	}
+
+	todo := revision.GetAfter()
+	todo.Id = req.GetTodoId()
+
+	if err := s.checkTodoList(ctx, todo.GetListId()); err != nil {
+		return nil, err
+	}
+
+	todoNew, err := s.Repo.Update(todoCtx, todo, db.UpdatePaths, req.GetExpectedVersion())
+	if errors.Is(err, db.ErrVersionMismatch) {
+		return nil, status.Errorf(
+			codes.Aborted,
+			fmt.Sprintf("Todo was modified, it does not have the expected version: %v", req.GetExpectedVersion()),
+		)
+	}
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if todoNew.GetId() == 0 {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Could not found Todo with the specified ID: %v", req.GetTodoId()),
+		)
+	}
+
+	return &todolistpb.RevertTodoResponse{
+		Todo: todoNew,
+	}, nil
+}
+
+// history returns with UNIMPLEMENTED error if the database does not record the history of the todos
+func (s *Server) history() (db.History, error) {
+	if s.History == nil {
+		return nil, status.Errorf(
+			codes.Unimplemented,
+			fmt.Sprintf("The history of the todos is not supported by the database"),
+		)
+	}
+
+	return s.History, nil
+}
@@ -0,0 +1,241 @@
+package server_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/halimi/todo-list-service/auth"
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/server"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type historyStream struct {
+	todolistpb.TodoListService_GetTodoHistoryServer
+	res []*todolistpb.GetTodoHistoryResponse
+}
+
+func (s *historyStream) Send(res *todolistpb.GetTodoHistoryResponse) error {
+	s.res = append(s.res, res)
+	return nil
+}
+
+func (s *historyStream) Context() context.Context {
+	return context.Background()
+}
+
+// transportStream is recording the header set by the interceptors
+type transportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func setupHistoryServer(t *testing.T) (*server.Server, int32) {
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &server.Server{Repo: memory, History: memory}
+
+	created, err := s.CreateTodo(context.Background(), &todolistpb.CreateTodoRequest{Todo: &todolistpb.Todo{Title: "History test", DueDate: ptypes.TimestampNow()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s, created.GetTodo().GetId()
+}
+
+func TestGetTodoHistory(t *testing.T) {
+	s, todoID := setupHistoryServer(t)
+	ctx := context.Background()
+
+	for _, title := range []string{"First", "Second"} {
+		req := &todolistpb.UpdateTodoRequest{Todo: &todolistpb.Todo{Id: todoID, Title: title}, UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}}}
+		if _, err := s.UpdateTodo(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stream := &historyStream{}
+	if err := s.GetTodoHistory(&todolistpb.GetTodoHistoryRequest{TodoId: todoID, PageSize: 2}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.res) != 2 || stream.res[0].GetRevision().GetAfter().GetTitle() != "Second" || stream.res[1].GetRevision().GetBefore().GetTitle() != "History test" {
+		t.Fatalf("Want: Second First, Got: %v\n", stream.res)
+	}
+
+	// the token of the next page is sent with the last revision
+	next := stream.res[1].GetNextPageToken()
+	if stream.res[0].GetNextPageToken() != "" || next == "" {
+		t.Fatalf("Want: next page token on the last revision, Got: %v\n", stream.res)
+	}
+
+	stream = &historyStream{}
+	if err := s.GetTodoHistory(&todolistpb.GetTodoHistoryRequest{TodoId: todoID, PageSize: 2, PageToken: next}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.res) != 1 || stream.res[0].GetRevision().GetAction() != todolistpb.TodoRevision_CREATED || stream.res[0].GetNextPageToken() != "" {
+		t.Fatalf("Want: the created revision, Got: %v\n", stream.res)
+	}
+
+	tests := []*todolistpb.GetTodoHistoryRequest{
+		{},
+		{TodoId: todoID, PageSize: -1},
+		{TodoId: todoID, PageToken: "invalid"},
+	}
+
+	for _, req := range tests {
+		if err := s.GetTodoHistory(req, &historyStream{}); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Want: %v, Got: %v\n", codes.InvalidArgument, err)
+		}
+	}
+}
+
+func TestRevertTodo(t *testing.T) {
+	s, todoID := setupHistoryServer(t)
+	ctx := context.Background()
+
+	req := &todolistpb.UpdateTodoRequest{Todo: &todolistpb.Todo{Id: todoID, Title: "Updated", Priority: 3}, UpdateMask: &field_mask.FieldMask{Paths: []string{"title", "priority"}}}
+	if _, err := s.UpdateTodo(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	stream := &historyStream{}
+	if err := s.GetTodoHistory(&todolistpb.GetTodoHistoryRequest{TodoId: todoID}, stream); err != nil {
+		t.Fatal(err)
+	}
+	created := stream.res[len(stream.res)-1].GetRevision()
+
+	res, err := s.RevertTodo(ctx, &todolistpb.RevertTodoRequest{TodoId: todoID, RevisionId: created.GetId(), ExpectedVersion: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := res.GetTodo()
+	if got.GetTitle() != "History test" || got.GetPriority() != 0 || got.GetVersion() != 3 {
+		t.Fatalf("Want: %v, Got: %v\n", created.GetAfter(), got)
+	}
+
+	// the revert is recorded in the history too
+	stream = &historyStream{}
+	if err := s.GetTodoHistory(&todolistpb.GetTodoHistoryRequest{TodoId: todoID}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.res) != 3 || stream.res[0].GetRevision().GetBefore().GetTitle() != "Updated" {
+		t.Fatalf("Want: 3 revisions, Got: %v\n", stream.res)
+	}
+
+	_, err = s.RevertTodo(ctx, &todolistpb.RevertTodoRequest{TodoId: todoID, RevisionId: created.GetId(), ExpectedVersion: 2})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("Want: %v, Got: %v\n", codes.Aborted, err)
+	}
+}
+
+func TestRevertTodoNotFound(t *testing.T) {
+	s, todoID := setupHistoryServer(t)
+	ctx := context.Background()
+
+	other, err := s.CreateTodo(ctx, &todolistpb.CreateTodoRequest{Todo: &todolistpb.Todo{Title: "Other", DueDate: ptypes.TimestampNow()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := &historyStream{}
+	if err := s.GetTodoHistory(&todolistpb.GetTodoHistoryRequest{TodoId: other.GetTodo().GetId()}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	// the revision of an other todo can not be reverted
+	tests := []*todolistpb.RevertTodoRequest{
+		{TodoId: todoID, RevisionId: 100},
+		{TodoId: todoID, RevisionId: stream.res[0].GetRevision().GetId()},
+	}
+
+	for _, req := range tests {
+		if _, err := s.RevertTodo(ctx, req); status.Code(err) != codes.NotFound {
+			t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+		}
+	}
+
+	if _, err := s.RevertTodo(ctx, &todolistpb.RevertTodoRequest{TodoId: todoID}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Want: %v, Got: %v\n", codes.InvalidArgument, err)
+	}
+}
+
+func TestHistoryUnimplemented(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	_, err := s.RevertTodo(context.Background(), &todolistpb.RevertTodoRequest{TodoId: 1, RevisionId: 1})
+
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("Want: %v, Got: %v\n", codes.Unimplemented, err)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}
+
+	tests := []struct {
+		name      string
+		md        metadata.MD
+		principal *auth.Principal
+		gateway   bool
+		actor     string
+		requestID string
+	}{
+		{"Peer", metadata.MD{}, nil, false, "192.0.2.1", ""},
+		{"RequestID", metadata.Pairs("x-request-id", "request-1"), nil, false, "192.0.2.1", "request-1"},
+		{"Forwarded", metadata.Pairs("x-forwarded-for", "198.51.100.1"), nil, false, "192.0.2.1", ""},
+		{"Gateway", metadata.Pairs("x-forwarded-for", "198.51.100.1, 203.0.113.1"), nil, true, "203.0.113.1", ""},
+		{"Principal", metadata.Pairs("x-forwarded-for", "198.51.100.1"), &auth.Principal{Subject: "alice", Issuer: "https://issuer.example.com"}, true, "jwt:https%3A//issuer.example.com:alice", ""},
+		{"OtherIssuer", metadata.MD{}, &auth.Principal{Subject: "alice", Issuer: "https://other.example.com"}, false, "jwt:https%3A//other.example.com:alice", ""},
+		{"Certificate", metadata.MD{}, &auth.Principal{Subject: "alice", Issuer: "CN=Client CA", Certificate: true}, false, "cert:CN=Client CA:alice", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &transportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), ts)
+			ctx = peer.NewContext(metadata.NewIncomingContext(ctx, tt.md), &peer.Peer{Addr: addr})
+			if tt.principal != nil {
+				ctx = auth.NewContext(ctx, tt.principal)
+			}
+
+			var got db.Audit
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				got = db.AuditFromContext(ctx)
+				return nil, nil
+			}
+
+			audit := &server.Audit{TrustForwarded: tt.gateway}
+			if _, err := audit.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Actor != tt.actor || got.RequestID == "" || (tt.requestID != "" && got.RequestID != tt.requestID) {
+				t.Fatalf("Want: %v %v, Got: %v\n", tt.actor, tt.requestID, got)
+			}
+
+			// the request id is returned to the client
+			if ids := ts.header.Get("x-request-id"); len(ids) != 1 || ids[0] != got.RequestID {
+				t.Fatalf("Want: %v, Got: %v\n", got.RequestID, ts.header)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxLoggedStringLength is the length of the longest string field of the requests in the logs,
+// the longer strings are truncated
+const maxLoggedStringLength = 64
+
+// redactedFields are the fields of the requests which are never logged
+var redactedFields = map[protoreflect.Name]bool{
+	"secret": true,
+}
+
+// RequestLogger is logging the requests with their method, peer, request id, duration, status code,
+// trace and their fields, the secrets of the requests are redacted and the long strings are truncated
+type RequestLogger struct {
+	// Logger is the logger of the requests, slog.Default() if nil
+	Logger *slog.Logger
+	// Level is the level of the requests, the failed requests with a server error are logged on the error level
+	Level slog.Level
+	// Levels is the level of the requests by their methods, it overrides Level
+	Levels map[string]slog.Level
+}
+
+// UnaryServerInterceptor returns with an interceptor logging the requests, it is the first interceptor
+// of the chain, so the rejected requests are logged too
+func (l *RequestLogger) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := requestID(ctx)
+		ctx = withRequestID(ctx, id)
+
+		start := time.Now()
+		res, err := handler(ctx, req)
+		l.log(ctx, info.FullMethod, id, start, req, err)
+
+		return res, err
+	}
+}
+
+// StreamServerInterceptor returns with an interceptor logging the streams when they end,
+// the fields of the first received message are logged
+func (l *RequestLogger) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := requestID(ss.Context())
+		stream := &loggedStream{ServerStream: ss, ctx: withRequestID(ss.Context(), id)}
+
+		start := time.Now()
+		err := handler(srv, stream)
+		l.log(stream.ctx, info.FullMethod, id, start, stream.req, err)
+
+		return err
+	}
+}
+
+// loggedStream is a server stream with the request id in its context, it keeps the first received message
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req interface{}
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *loggedStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.req == nil {
+		s.req = m
+	}
+
+	return err
+}
+
+// log is logging the request on the level of the method
+func (l *RequestLogger) log(ctx context.Context, method, id string, start time.Time, req interface{}, err error) {
+	logger := l.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	code := status.Code(err)
+	level := l.methodLevel(method)
+	if serverError(code) && level < slog.LevelError {
+		level = slog.LevelError
+	}
+
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("peer", peerAddress(ctx)),
+		slog.String("request_id", id),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("code", code.String()),
+	}
+
+	// the logs of the traced requests can be found by their trace
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+
+	if m, ok := req.(protoreflect.ProtoMessage); ok {
+		attrs = append(attrs, slog.Any("request", slog.GroupValue(messageAttrs(m.ProtoReflect())...)))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+
+	logger.LogAttrs(ctx, level, "gRPC request", attrs...)
+}
+
+// methodLevel returns with the level of the method, the levels can be set by the full
+// or by the short name of the methods
+func (l *RequestLogger) methodLevel(method string) slog.Level {
+	if level, ok := l.Levels[method]; ok {
+		return level
+	}
+
+	if level, ok := l.Levels[method[strings.LastIndex(method, "/")+1:]]; ok {
+		return level
+	}
+
+	return l.Level
+}
+
+// ParseMethodLevels returns with the levels of the methods of a comma separated list of method=level pairs,
+// e.g. WatchTodos=debug,/todolist.TodoListService/CreateTodo=warn
+func ParseMethodLevels(s string) (map[string]slog.Level, error) {
+	levels := make(map[string]slog.Level)
+
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		method, name, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(method) == "" {
+			return nil, fmt.Errorf("invalid method level: %v", pair)
+		}
+
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+			return nil, fmt.Errorf("invalid level of the method %v: %v", method, err)
+		}
+
+		levels[strings.TrimSpace(method)] = level
+	}
+
+	return levels, nil
+}
+
+// serverError returns true if the status code is reporting an error of the server
+func serverError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// peerAddress returns with the host of the client address
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+
+	return p.Addr.String()
+}
+
+// messageAttrs returns with the set fields of the message
+func messageAttrs(m protoreflect.Message) []slog.Attr {
+	var attrs []slog.Attr
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+
+		switch {
+		case redactedFields[fd.Name()]:
+			attrs = append(attrs, slog.String(name, "REDACTED"))
+		case fd.IsList():
+			list := v.List()
+			values := make([]interface{}, list.Len())
+			for i := range values {
+				values[i] = fieldValue(fd, list.Get(i)).Any()
+			}
+			attrs = append(attrs, slog.Any(name, values))
+		case fd.IsMap():
+			attrs = append(attrs, slog.Int(name, v.Map().Len()))
+		default:
+			attrs = append(attrs, slog.Attr{Key: name, Value: fieldValue(fd, v)})
+		}
+
+		return true
+	})
+
+	return attrs
+}
+
+// fieldValue returns with the value of a field for the logs
+func fieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) slog.Value {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		m := v.Message()
+		if m.Descriptor().FullName() == "google.protobuf.Timestamp" {
+			seconds := m.Get(m.Descriptor().Fields().ByName("seconds")).Int()
+			nanos := m.Get(m.Descriptor().Fields().ByName("nanos")).Int()
+			return slog.TimeValue(time.Unix(seconds, nanos).UTC())
+		}
+		return slog.GroupValue(messageAttrs(m)...)
+	case protoreflect.StringKind:
+		return slog.StringValue(truncate(v.String()))
+	case protoreflect.BytesKind:
+		return slog.StringValue(fmt.Sprintf("%d bytes", len(v.Bytes())))
+	case protoreflect.EnumKind:
+		if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return slog.StringValue(string(value.Name()))
+		}
+		return slog.Int64Value(int64(v.Enum()))
+	default:
+		return slog.AnyValue(v.Interface())
+	}
+}
+
+// truncate returns with the string truncated to maxLoggedStringLength characters
+func truncate(s string) string {
+	if utf8.RuneCountInString(s) <= maxLoggedStringLength {
+		return s
+	}
+
+	return string([]rune(s)[:maxLoggedStringLength]) + "..."
+}
@@ -0,0 +1,137 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/server"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// logRecord is a request log of the JSON handler
+type logRecord struct {
+	Level     string                 `json:"level"`
+	Method    string                 `json:"method"`
+	Peer      string                 `json:"peer"`
+	RequestID string                 `json:"request_id"`
+	Code      string                 `json:"code"`
+	Error     string                 `json:"error"`
+	Request   map[string]interface{} `json:"request"`
+}
+
+// readLogs returns with the request logs of the buffer
+func readLogs(t *testing.T, buf *bytes.Buffer) []logRecord {
+	var records []logRecord
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var r logRecord
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, r)
+	}
+
+	return records
+}
+
+func TestRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := &server.RequestLogger{
+		Logger: slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		Levels: map[string]slog.Level{"ReadTodo": slog.LevelDebug},
+	}
+
+	ts := &transportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), ts)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-request-id", "request-1"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}})
+
+	// the request id of the logs is the request id of the history
+	var audit db.Audit
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return (&server.Audit{}).UnaryServerInterceptor()(ctx, req, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			audit = db.AuditFromContext(ctx)
+			return nil, nil
+		})
+	}
+
+	req := &todolistpb.CreateWebhookRequest{Webhook: &todolistpb.Webhook{Url: "https://example.com/" + strings.Repeat("a", 100), Secret: "secret"}}
+	if _, err := l.UnaryServerInterceptor()(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/todolist.TodoListService/CreateWebhook"}, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	failing := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Errorf(codes.Internal, "Internal error")
+	}
+
+	_, err := l.UnaryServerInterceptor()(ctx, &todolistpb.ReadTodoRequest{TodoId: 1}, &grpc.UnaryServerInfo{FullMethod: "/todolist.TodoListService/ReadTodo"}, failing)
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("Want: %v, Got: %v\n", codes.Internal, err)
+	}
+
+	// the successful requests of a method on the debug level are not logged
+	_, err = l.UnaryServerInterceptor()(ctx, &todolistpb.ReadTodoRequest{TodoId: 1}, &grpc.UnaryServerInfo{FullMethod: "/todolist.TodoListService/ReadTodo"}, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := readLogs(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("Want: 2 logs, Got: %v\n", buf.String())
+	}
+
+	created := records[0]
+	if created.Level != "INFO" || created.Method != "/todolist.TodoListService/CreateWebhook" || created.Peer != "192.0.2.1" || created.Code != "OK" {
+		t.Fatalf("Want: CreateWebhook log, Got: %v\n", created)
+	}
+
+	if created.RequestID != "request-1" || audit.RequestID != "request-1" {
+		t.Fatalf("Want: request-1, Got: %v %v\n", created.RequestID, audit.RequestID)
+	}
+
+	webhook, _ := created.Request["webhook"].(map[string]interface{})
+	if webhook["secret"] != "REDACTED" {
+		t.Fatalf("Want: REDACTED, Got: %v\n", webhook["secret"])
+	}
+
+	if url, _ := webhook["url"].(string); len(url) != 67 || !strings.HasSuffix(url, "...") {
+		t.Fatalf("Want: truncated url, Got: %v\n", url)
+	}
+
+	failed := records[1]
+	if failed.Level != "ERROR" || failed.Code != "Internal" || failed.Error != "Internal error" || failed.Request["todo_id"] != float64(1) {
+		t.Fatalf("Want: ReadTodo error log, Got: %v\n", failed)
+	}
+}
+
+func TestParseMethodLevels(t *testing.T) {
+	levels, err := server.ParseMethodLevels("WatchTodos=debug, /todolist.TodoListService/CreateTodo=WARN")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(levels) != 2 || levels["WatchTodos"] != slog.LevelDebug || levels["/todolist.TodoListService/CreateTodo"] != slog.LevelWarn {
+		t.Fatalf("Want: WatchTodos=debug CreateTodo=warn, Got: %v\n", levels)
+	}
+
+	for _, s := range []string{"WatchTodos", "=debug", "WatchTodos=loud"} {
+		if _, err := server.ParseMethodLevels(s); err == nil {
+			t.Fatalf("Want: error for %v, Got: nil\n", s)
+		}
+	}
+}
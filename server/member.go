@@ -0,0 +1,295 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// ShareList request handler
+func (s *Server) ShareList(ctx context.Context, req *todolistpb.ShareListRequest) (*todolistpb.ShareListResponse, error) {
+	store, err := s.memberStore()
+	if err != nil {
+		return nil, err
+	}
+
+	member := req.GetMember()
+
+	if member.GetListId() == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	if member.GetSubject() == "" {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get subject"),
+		)
+	}
+
+	if _, ok := todolistpb.ListMember_Role_name[int32(member.GetRole())]; !ok {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Invalid role: %v", member.GetRole()),
+		)
+	}
+
+	list, err := s.authorizeList(ctx, member.GetListId(), todolistpb.ListMember_OWNER)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.GetSubject() == list.GetOwner() {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("The owner of the TodoList can not be a member: %v", member.GetSubject()),
+		)
+	}
+
+	member.CreatedAt = ptypes.TimestampNow()
+
+	memberNew, err := store.PutListMember(ctx, member)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	return &todolistpb.ShareListResponse{
+		Member: memberNew,
+	}, nil
+}
+
+// UnshareList request handler, the members can remove themselves from the list
+func (s *Server) UnshareList(ctx context.Context, req *todolistpb.UnshareListRequest) (*todolistpb.UnshareListResponse, error) {
+	store, err := s.memberStore()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetListId() == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	if req.GetSubject() == "" {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get subject"),
+		)
+	}
+
+	role := todolistpb.ListMember_OWNER
+	if subject, _ := db.TenantFromContext(ctx); subject == req.GetSubject() {
+		role = todolistpb.ListMember_VIEWER
+	}
+
+	if _, err := s.authorizeList(ctx, req.GetListId(), role); err != nil {
+		return nil, err
+	}
+
+	count, err := store.DeleteListMember(ctx, req.GetListId(), req.GetSubject())
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if count == 0 {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Could not found the member of the TodoList with the specified subject: %v", req.GetSubject()),
+		)
+	}
+
+	return &todolistpb.UnshareListResponse{}, nil
+}
+
+// ListMembers request handler, the owner of the list is sent first
+func (s *Server) ListMembers(req *todolistpb.ListMembersRequest, stream todolistpb.TodoListService_ListMembersServer) error {
+	ctx := stream.Context()
+
+	store, err := s.memberStore()
+	if err != nil {
+		return err
+	}
+
+	if req.GetListId() == 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	list, err := s.authorizeList(ctx, req.GetListId(), todolistpb.ListMember_VIEWER)
+	if err != nil {
+		return err
+	}
+
+	members, err := store.ListListMembers(ctx, req.GetListId())
+	if err != nil {
+		return repositoryError(ctx, err)
+	}
+
+	owner := &todolistpb.ListMember{
+		ListId:    list.GetId(),
+		Subject:   list.GetOwner(),
+		Role:      todolistpb.ListMember_OWNER,
+		CreatedAt: list.GetCreatedAt(),
+	}
+
+	for _, member := range append([]*todolistpb.ListMember{owner}, members...) {
+		if err := stream.Send(&todolistpb.ListMembersResponse{Member: member}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// memberStore returns with UNIMPLEMENTED error if the database does not store the members of the lists
+func (s *Server) memberStore() (db.MemberStore, error) {
+	if s.Members == nil {
+		return nil, status.Errorf(
+			codes.Unimplemented,
+			fmt.Sprintf("Sharing the lists is not supported by the database"),
+		)
+	}
+
+	return s.Members, nil
+}
+
+// listRole returns with the role of the caller on the list, false if the list is not shared with the caller.
+// The owner of the list has the OWNER role.
+func (s *Server) listRole(ctx context.Context, list *todolistpb.TodoList) (todolistpb.ListMember_Role, bool, error) {
+	if db.OwnedBy(ctx, list.GetOwner()) {
+		return todolistpb.ListMember_OWNER, true, nil
+	}
+
+	if s.Members == nil {
+		return todolistpb.ListMember_VIEWER, false, nil
+	}
+
+	subject, _ := db.TenantFromContext(ctx)
+	member, err := s.Members.GetListMember(ctx, list.GetId(), subject)
+	if err != nil {
+		return todolistpb.ListMember_VIEWER, false, err
+	}
+
+	return member.GetRole(), member.GetListId() != 0, nil
+}
+
+// authorizeList returns with the list if the caller has at least the role on it, NOT_FOUND error if the list
+// does not exist or it is not shared with the caller and PERMISSION_DENIED if the role of the caller is lower.
+// The repository calls of an authorized list are made with db.WithAllTenants, so they see the todos
+// of every member in the list.
+func (s *Server) authorizeList(ctx context.Context, listID int32, role todolistpb.ListMember_Role) (*todolistpb.TodoList, error) {
+	list, err := s.Repo.GetTodoList(db.WithAllTenants(ctx), listID)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	callerRole, ok := todolistpb.ListMember_VIEWER, false
+	if list.GetId() != 0 {
+		if callerRole, ok, err = s.listRole(ctx, list); err != nil {
+			return nil, repositoryError(ctx, err)
+		}
+	}
+
+	if !ok {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Could not found TodoList with the specified ID: %v", listID),
+		)
+	}
+
+	if callerRole < role {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			fmt.Sprintf("The %v role is required on the TodoList with the specified ID: %v", role, listID),
+		)
+	}
+
+	return list, nil
+}
+
+// todoContext returns with the context of the repository calls of the todo. If the todo is in a list shared
+// with the caller the calls are scoped to the owner of the todo, PERMISSION_DENIED error is returned if the role
+// of the caller is lower. Otherwise the context is not changed, so the todos of the other owners are not found.
+// The todos of the trash are found too, so they can be restored by the editors of the list.
+func (s *Server) todoContext(ctx context.Context, todoID int32, role todolistpb.ListMember_Role) (context.Context, error) {
+	if s.Members == nil {
+		return ctx, nil
+	}
+
+	todo, err := s.Repo.Get(db.WithAllTenants(ctx), todoID)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if todo.GetId() == 0 {
+		if todo, err = s.Repo.GetTrashed(db.WithAllTenants(ctx), todoID); err != nil {
+			return nil, repositoryError(ctx, err)
+		}
+	}
+
+	if todo.GetId() == 0 || todo.GetListId() == 0 || db.OwnedBy(ctx, todo.GetOwner()) {
+		return ctx, nil
+	}
+
+	list, err := s.Repo.GetTodoList(db.WithAllTenants(ctx), todo.GetListId())
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if list.GetId() == 0 {
+		return ctx, nil
+	}
+
+	callerRole, ok, err := s.listRole(ctx, list)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if !ok {
+		return ctx, nil
+	}
+
+	if callerRole < role {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			fmt.Sprintf("The %v role is required on the TodoList of the Todo with the specified ID: %v", role, todoID),
+		)
+	}
+
+	return db.WithTenant(ctx, todo.GetOwner()), nil
+}
+
+// todoVisible returns true if the todo is owned by the caller or it is in a list shared with the caller
+func (s *Server) todoVisible(ctx context.Context, todo *todolistpb.Todo) (bool, error) {
+	if db.OwnedBy(ctx, todo.GetOwner()) {
+		return true, nil
+	}
+
+	if s.Members == nil || todo.GetListId() == 0 {
+		return false, nil
+	}
+
+	list, err := s.Repo.GetTodoList(db.WithAllTenants(ctx), todo.GetListId())
+	if err != nil {
+		return false, err
+	}
+
+	if list.GetId() == 0 {
+		return false, nil
+	}
+
+	_, ok, err := s.listRole(ctx, list)
+	return ok, err
+}
@@ -0,0 +1,270 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/server"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// membersStream is collecting the responses of the ListMembers stream
+type membersStream struct {
+	todolistpb.TodoListService_ListMembersServer
+	ctx context.Context
+	res []*todolistpb.ListMembersResponse
+}
+
+func (s *membersStream) Send(res *todolistpb.ListMembersResponse) error {
+	s.res = append(s.res, res)
+	return nil
+}
+
+func (s *membersStream) Context() context.Context {
+	return s.ctx
+}
+
+// tenantListStream is collecting the responses of the ListTodos stream of a tenant
+type tenantListStream struct {
+	todolistpb.TodoListService_ListTodosServer
+	ctx context.Context
+	res []*todolistpb.ListTodosResponse
+}
+
+func (s *tenantListStream) Send(res *todolistpb.ListTodosResponse) error {
+	s.res = append(s.res, res)
+	return nil
+}
+
+func (s *tenantListStream) Context() context.Context {
+	return s.ctx
+}
+
+// todoListsStream is collecting the responses of the ListTodoLists stream
+type todoListsStream struct {
+	todolistpb.TodoListService_ListTodoListsServer
+	ctx context.Context
+	res []*todolistpb.ListTodoListsResponse
+}
+
+func (s *todoListsStream) Send(res *todolistpb.ListTodoListsResponse) error {
+	s.res = append(s.res, res)
+	return nil
+}
+
+func (s *todoListsStream) Context() context.Context {
+	return s.ctx
+}
+
+// setupSharedList returns with a server and a list of alice shared with bob as a viewer and with carol as an editor
+func setupSharedList(t *testing.T) (*server.Server, int32, int32) {
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &server.Server{Repo: memory, History: memory, Members: memory}
+	alice := db.WithTenant(context.Background(), "alice")
+
+	list, err := s.CreateTodoList(alice, &todolistpb.CreateTodoListRequest{TodoList: &todolistpb.TodoList{Name: "Shared list"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listID := list.GetTodoList().GetId()
+
+	todo, err := s.CreateTodo(alice, &todolistpb.CreateTodoRequest{Todo: &todolistpb.Todo{Title: "Alice todo", DueDate: ptypes.TimestampNow(), ListId: listID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	members := []*todolistpb.ListMember{
+		{ListId: listID, Subject: "bob", Role: todolistpb.ListMember_VIEWER},
+		{ListId: listID, Subject: "carol", Role: todolistpb.ListMember_EDITOR},
+	}
+	for _, member := range members {
+		if _, err := s.ShareList(alice, &todolistpb.ShareListRequest{Member: member}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s, listID, todo.GetTodo().GetId()
+}
+
+func TestSharedListRoles(t *testing.T) {
+	s, listID, todoID := setupSharedList(t)
+	bob := db.WithTenant(context.Background(), "bob")
+	carol := db.WithTenant(context.Background(), "carol")
+	dave := db.WithTenant(context.Background(), "dave")
+
+	update := func(ctx context.Context) error {
+		req := &todolistpb.UpdateTodoRequest{Todo: &todolistpb.Todo{Id: todoID, Title: "Changed todo"}, UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}}}
+		_, err := s.UpdateTodo(ctx, req)
+		return err
+	}
+	create := func(ctx context.Context) error {
+		_, err := s.CreateTodo(ctx, &todolistpb.CreateTodoRequest{Todo: &todolistpb.Todo{Title: "Member todo", DueDate: ptypes.TimestampNow(), ListId: listID}})
+		return err
+	}
+	read := func(ctx context.Context) error {
+		_, err := s.ReadTodo(ctx, &todolistpb.ReadTodoRequest{TodoId: todoID})
+		return err
+	}
+	readList := func(ctx context.Context) error {
+		_, err := s.ReadTodoList(ctx, &todolistpb.ReadTodoListRequest{ListId: listID})
+		return err
+	}
+	updateList := func(ctx context.Context) error {
+		_, err := s.UpdateTodoList(ctx, &todolistpb.UpdateTodoListRequest{TodoList: &todolistpb.TodoList{Id: listID, Name: "Changed list"}})
+		return err
+	}
+	share := func(ctx context.Context) error {
+		_, err := s.ShareList(ctx, &todolistpb.ShareListRequest{Member: &todolistpb.ListMember{ListId: listID, Subject: "erin"}})
+		return err
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		fn       func(context.Context) error
+		wantCode codes.Code
+	}{
+		{"ViewerReadTodo", bob, read, codes.OK},
+		{"ViewerReadTodoList", bob, readList, codes.OK},
+		{"ViewerUpdateTodo", bob, update, codes.PermissionDenied},
+		{"ViewerCreateTodo", bob, create, codes.PermissionDenied},
+		{"ViewerShareList", bob, share, codes.PermissionDenied},
+		{"EditorUpdateTodo", carol, update, codes.OK},
+		{"EditorCreateTodo", carol, create, codes.OK},
+		{"EditorUpdateTodoList", carol, updateList, codes.PermissionDenied},
+		{"OtherReadTodo", dave, read, codes.NotFound},
+		{"OtherReadTodoList", dave, readList, codes.NotFound},
+		{"OtherUpdateTodo", dave, update, codes.NotFound},
+		{"OtherShareList", dave, share, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(tt.ctx); status.Code(err) != tt.wantCode {
+				t.Fatalf("Want: %v, Got: %v\n", tt.wantCode, err)
+			}
+		})
+	}
+
+	// the todos of the list are listed for every member, the todo of carol too
+	stream := &tenantListStream{ctx: bob}
+	if err := s.ListTodos(&todolistpb.ListTodosRequest{ListId: listID}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.res) != 2 || stream.res[1].GetTodo().GetOwner() != "carol" {
+		t.Fatalf("Want: 2 todos, Got: %v\n", stream.res)
+	}
+
+	lists := &todoListsStream{ctx: bob}
+	if err := s.ListTodoLists(&todolistpb.ListTodoListsRequest{}, lists); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lists.res) != 1 || lists.res[0].GetTodoList().GetId() != listID {
+		t.Fatalf("Want: %v, Got: %v\n", listID, lists.res)
+	}
+}
+
+func TestRestoreSharedTodo(t *testing.T) {
+	s, _, todoID := setupSharedList(t)
+	bob := db.WithTenant(context.Background(), "bob")
+	carol := db.WithTenant(context.Background(), "carol")
+	dave := db.WithTenant(context.Background(), "dave")
+
+	if _, err := s.DeleteTodo(carol, &todolistpb.DeleteTodoRequest{TodoId: todoID}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{"Other", dave, codes.NotFound},
+		{"Viewer", bob, codes.PermissionDenied},
+		{"Editor", carol, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.RestoreTodo(tt.ctx, &todolistpb.RestoreTodoRequest{TodoId: todoID})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("Want: %v, Got: %v\n", tt.wantCode, err)
+			}
+		})
+	}
+
+	res, err := s.ReadTodo(bob, &todolistpb.ReadTodoRequest{TodoId: todoID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.GetTodo().GetOwner() != "alice" || res.GetTodo().GetDeletedAt() != nil {
+		t.Fatalf("Want: restored todo of alice, Got: %v\n", res.GetTodo())
+	}
+}
+
+func TestListMembers(t *testing.T) {
+	s, listID, _ := setupSharedList(t)
+	alice := db.WithTenant(context.Background(), "alice")
+	bob := db.WithTenant(context.Background(), "bob")
+
+	stream := &membersStream{ctx: bob}
+	if err := s.ListMembers(&todolistpb.ListMembersRequest{ListId: listID}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, res := range stream.res {
+		got = append(got, res.GetMember().GetSubject()+":"+res.GetMember().GetRole().String())
+	}
+
+	want := []string{"alice:OWNER", "bob:VIEWER", "carol:EDITOR"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Fatalf("Want: %v, Got: %v\n", want, got)
+	}
+
+	// the owner can not be a member
+	_, err := s.ShareList(alice, &todolistpb.ShareListRequest{Member: &todolistpb.ListMember{ListId: listID, Subject: "alice"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Want: %v, Got: %v\n", codes.InvalidArgument, err)
+	}
+
+	// the members can remove themselves, but not the others
+	_, err = s.UnshareList(bob, &todolistpb.UnshareListRequest{ListId: listID, Subject: "carol"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Want: %v, Got: %v\n", codes.PermissionDenied, err)
+	}
+
+	if _, err := s.UnshareList(bob, &todolistpb.UnshareListRequest{ListId: listID, Subject: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.ReadTodoList(bob, &todolistpb.ReadTodoListRequest{ListId: listID})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+
+	_, err = s.UnshareList(alice, &todolistpb.UnshareListRequest{ListId: listID, Subject: "bob"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+}
+
+func TestShareListUnimplemented(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	_, err := s.ShareList(context.Background(), &todolistpb.ShareListRequest{Member: &todolistpb.ListMember{ListId: 1, Subject: "bob"}})
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("Want: %v, Got: %v\n", codes.Unimplemented, err)
+	}
+}
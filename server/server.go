@@ -2,10 +2,16 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/halimi/todo-list-service/db"
 	"github.com/halimi/todo-list-service/todolistpb"
@@ -14,36 +20,60 @@ import (
 // Server is implementing TodoListServiceServer interface
 type Server struct {
 	Repo db.Repository
+	// Webhooks is storing the webhook subscriptions, the webhook requests are UNIMPLEMENTED if nil
+	Webhooks db.WebhookStore
+	// History is reading the history of the todos, the history requests are UNIMPLEMENTED if nil
+	History db.History
+	// Members is storing the members of the shared lists, the sharing requests are UNIMPLEMENTED if nil
+	// and only the owners can access the lists
+	Members db.MemberStore
 }
 
 // CreateTodo request handler
 func (s *Server) CreateTodo(ctx context.Context, req *todolistpb.CreateTodoRequest) (*todolistpb.CreateTodoResponse, error) {
-	fmt.Println("Create Todo request")
-	ctx = db.SetRepository(ctx, s.Repo)
 	todo := req.GetTodo()
 
-	id, err := db.Insert(ctx, todo)
-	if err != nil {
+	if todo == nil {
 		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get Todo"),
 		)
 	}
 
+	if err := s.checkTodoList(ctx, todo.GetListId()); err != nil {
+		return nil, err
+	}
+
+	todo.CreatedAt = ptypes.TimestampNow()
+	todo.CompletedAt = completionTime(todo.GetStatus())
+
+	id, err := s.Repo.Insert(ctx, todo)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	owner, _ := db.TenantFromContext(ctx)
+
 	return &todolistpb.CreateTodoResponse{
 		Todo: &todolistpb.Todo{
-			Id:      id,
-			Title:   todo.GetTitle(),
-			Note:    todo.GetNote(),
-			DueDate: todo.GetDueDate(),
+			Id:          id,
+			Title:       todo.GetTitle(),
+			Note:        todo.GetNote(),
+			DueDate:     todo.GetDueDate(),
+			Status:      todo.GetStatus(),
+			CompletedAt: todo.GetCompletedAt(),
+			Tags:        todo.GetTags(),
+			Priority:    todo.GetPriority(),
+			CreatedAt:   todo.GetCreatedAt(),
+			ListId:      todo.GetListId(),
+			Version:     1,
+			Owner:       owner,
 		},
 	}, nil
 }
 
 // ReadTodo request handler
 func (s *Server) ReadTodo(ctx context.Context, req *todolistpb.ReadTodoRequest) (*todolistpb.ReadTodoResponse, error) {
-	fmt.Println("Read todo request")
-	ctx = db.SetRepository(ctx, s.Repo)
 	todoID := req.GetTodoId()
 
 	if todoID == 0 {
@@ -53,12 +83,14 @@ func (s *Server) ReadTodo(ctx context.Context, req *todolistpb.ReadTodoRequest)
 		)
 	}
 
-	todo, err := db.Get(ctx, todoID)
+	todoCtx, err := s.todoContext(ctx, todoID, todolistpb.ListMember_VIEWER)
 	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
-		)
+		return nil, err
+	}
+
+	todo, err := s.Repo.Get(todoCtx, todoID)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
 	}
 
 	if todo.GetId() == 0 {
@@ -75,8 +107,6 @@ func (s *Server) ReadTodo(ctx context.Context, req *todolistpb.ReadTodoRequest)
 
 // UpdateTodo request handler
 func (s *Server) UpdateTodo(ctx context.Context, req *todolistpb.UpdateTodoRequest) (*todolistpb.UpdateTodoResponse, error) {
-	fmt.Println("Update Todo request")
-	ctx = db.SetRepository(ctx, s.Repo)
 	todo := req.GetTodo()
 
 	if todo.GetId() == 0 {
@@ -86,13 +116,34 @@ func (s *Server) UpdateTodo(ctx context.Context, req *todolistpb.UpdateTodoReque
 		)
 	}
 
-	todoNew, err := db.Update(ctx, todo)
+	paths, err := updatePaths(req.GetUpdateMask())
 	if err != nil {
+		return nil, err
+	}
+
+	todoCtx, err := s.todoContext(ctx, todo.GetId(), todolistpb.ListMember_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasPath(paths, "list_id") {
+		if err := s.checkTodoList(ctx, todo.GetListId()); err != nil {
+			return nil, err
+		}
+	}
+
+	todo.CompletedAt = completionTime(todo.GetStatus())
+
+	todoNew, err := s.Repo.Update(todoCtx, todo, paths, req.GetExpectedVersion())
+	if errors.Is(err, db.ErrVersionMismatch) {
 		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
+			codes.Aborted,
+			fmt.Sprintf("Todo was modified, it does not have the expected version: %v", req.GetExpectedVersion()),
 		)
 	}
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
 
 	if todoNew.GetId() == 0 {
 		return nil, status.Errorf(
@@ -108,8 +159,6 @@ func (s *Server) UpdateTodo(ctx context.Context, req *todolistpb.UpdateTodoReque
 
 // DeleteTodo request handler
 func (s *Server) DeleteTodo(ctx context.Context, req *todolistpb.DeleteTodoRequest) (*todolistpb.DeleteTodoResponse, error) {
-	fmt.Println("Delete todo request")
-	ctx = db.SetRepository(ctx, s.Repo)
 	todoID := req.GetTodoId()
 
 	if todoID == 0 {
@@ -119,13 +168,21 @@ func (s *Server) DeleteTodo(ctx context.Context, req *todolistpb.DeleteTodoReque
 		)
 	}
 
-	count, err := db.Delete(ctx, todoID)
+	todoCtx, err := s.todoContext(ctx, todoID, todolistpb.ListMember_EDITOR)
 	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.Repo.Delete(todoCtx, todoID, req.GetExpectedVersion())
+	if errors.Is(err, db.ErrVersionMismatch) {
 		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
+			codes.Aborted,
+			fmt.Sprintf("Todo was modified, it does not have the expected version: %v", req.GetExpectedVersion()),
 		)
 	}
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
 
 	if count == 0 {
 		return nil, status.Errorf(
@@ -139,20 +196,233 @@ func (s *Server) DeleteTodo(ctx context.Context, req *todolistpb.DeleteTodoReque
 
 // ListTodos request handler
 func (s *Server) ListTodos(req *todolistpb.ListTodosRequest, stream todolistpb.TodoListService_ListTodosServer) error {
-	fmt.Println("List todos request")
-	ctx := db.SetRepository(context.Background(), s.Repo)
+	ctx := stream.Context()
 
-	todoList, err := db.List(ctx)
-	if err != nil {
+	if req.GetPageSize() < 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Page size can not be negative: %v", req.GetPageSize()),
+		)
+	}
+
+	if req.GetListId() != 0 && req.GetInbox() {
 		return status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
+			codes.InvalidArgument,
+			fmt.Sprintf("List ID and inbox can not be set together"),
 		)
 	}
 
-	for _, todo := range todoList {
-		stream.Send(&todolistpb.ListTodosResponse{Todo: todo})
+	// the todos of a list are listed together with the todos of its other members
+	listCtx := ctx
+	if req.GetListId() != 0 {
+		if _, err := s.authorizeList(ctx, req.GetListId(), todolistpb.ListMember_VIEWER); err != nil {
+			return err
+		}
+		listCtx = db.WithAllTenants(ctx)
+	}
+
+	todoList, next, err := s.Repo.List(listCtx, req)
+	if errors.Is(err, db.ErrInvalidPageToken) {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Invalid page token: %v", req.GetPageToken()),
+		)
+	}
+	if err != nil {
+		return repositoryError(ctx, err)
+	}
+
+	for i, todo := range todoList {
+		res := &todolistpb.ListTodosResponse{Todo: todo}
+		if i == len(todoList)-1 {
+			res.NextPageToken = next
+		}
+
+		if err := stream.Send(res); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// WatchTodos request handler
+func (s *Server) WatchTodos(req *todolistpb.WatchTodosRequest, stream todolistpb.TodoListService_WatchTodosServer) error {
+	ctx := stream.Context()
+
+	watcher, ok := s.Repo.(db.Watcher)
+	if !ok {
+		return status.Errorf(
+			codes.Unimplemented,
+			fmt.Sprintf("Watching the todos is not supported by the database"),
+		)
+	}
+
+	// the headers are sent before the first change, so the clients know that the watch is started
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	// the changes of the todos of the other owners are skipped, unless they are in a list shared with the caller
+	err := watcher.Watch(ctx, req.GetResumeToken(), func(e *db.Event) error {
+		visible, err := s.todoVisible(ctx, e.Todo)
+		if err != nil || !visible {
+			return err
+		}
+
+		return stream.Send(&todolistpb.WatchTodosResponse{
+			Type:        e.Type,
+			Todo:        e.Todo,
+			ResumeToken: e.ResumeToken,
+		})
+	})
+	if errors.Is(err, db.ErrInvalidResumeToken) {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Invalid resume token: %v", req.GetResumeToken()),
+		)
+	}
+	if errors.Is(err, db.ErrResumeTokenExpired) {
+		return status.Errorf(
+			codes.OutOfRange,
+			fmt.Sprintf("Resume token expired, the todos have to be listed again: %v", req.GetResumeToken()),
+		)
+	}
+
+	// the watch is only stopped by an error or by cancelling the request
+	return repositoryError(ctx, err)
+}
+
+// CompleteTodo request handler
+func (s *Server) CompleteTodo(ctx context.Context, req *todolistpb.CompleteTodoRequest) (*todolistpb.CompleteTodoResponse, error) {
+	todoID := req.GetTodoId()
+
+	if todoID == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	todoCtx, err := s.todoContext(ctx, todoID, todolistpb.ListMember_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := s.Repo.Complete(todoCtx, todoID, ptypes.TimestampNow())
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if todo.GetId() == 0 {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Could not found Todo with the specified ID: %v", todoID),
+		)
+	}
+
+	return &todolistpb.CompleteTodoResponse{
+		Todo: todo,
+	}, nil
+}
+
+// ReopenTodo request handler
+func (s *Server) ReopenTodo(ctx context.Context, req *todolistpb.ReopenTodoRequest) (*todolistpb.ReopenTodoResponse, error) {
+	todoID := req.GetTodoId()
+
+	if todoID == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	todoCtx, err := s.todoContext(ctx, todoID, todolistpb.ListMember_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := s.Repo.Reopen(todoCtx, todoID)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if todo.GetId() == 0 {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Could not found Todo with the specified ID: %v", todoID),
+		)
+	}
+
+	return &todolistpb.ReopenTodoResponse{
+		Todo: todo,
+	}, nil
+}
+
+// completionTime returns with the current time for done todos, so the completion time is always set by the server
+func completionTime(s todolistpb.Status) *timestamp.Timestamp {
+	if s != todolistpb.Status_DONE {
+		return nil
+	}
+
+	return ptypes.TimestampNow()
+}
+
+// updatePaths returns with the paths of the update mask validated against the Todo fields,
+// all the updatable fields are returned for an empty mask
+func updatePaths(mask *field_mask.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return db.UpdatePaths, nil
+	}
+
+	fields := (&todolistpb.Todo{}).ProtoReflect().Descriptor().Fields()
+	for _, path := range mask.GetPaths() {
+		if fields.ByName(protoreflect.Name(path)) == nil {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				fmt.Sprintf("Invalid update mask path: %v", path),
+			)
+		}
+
+		if !hasPath(db.UpdatePaths, path) {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				fmt.Sprintf("Field can not be updated: %v", path),
+			)
+		}
+	}
+
+	return mask.GetPaths(), nil
+}
+
+func hasPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// repositoryError returns with the status of the repository error, the query is cancelled
+// together with the request when it is cancelled or its deadline is exceeded
+func repositoryError(ctx context.Context, err error) error {
+	switch ctx.Err() {
+	case context.Canceled:
+		return status.Errorf(
+			codes.Canceled,
+			fmt.Sprintf("Request canceled: %v", err),
+		)
+	case context.DeadlineExceeded:
+		return status.Errorf(
+			codes.DeadlineExceeded,
+			fmt.Sprintf("Deadline exceeded: %v", err),
+		)
+	}
+
+	return status.Errorf(
+		codes.Internal,
+		fmt.Sprintf("Internal error: %v", err),
+	)
+}
@@ -12,12 +12,14 @@ import (
 	"github.com/halimi/todo-list-service/db"
 	"github.com/halimi/todo-list-service/server"
 	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 func TestCreateTodo(t *testing.T) {
-	s := server.Server{&db.MockDB{}}
+	s := server.Server{Repo: &db.MockDB{}}
 
 	dd, err := ptypes.TimestampProto(time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local))
 	if err != nil {
@@ -35,11 +37,17 @@ func TestCreateTodo(t *testing.T) {
 	}
 	gotTodo := res.GetTodo()
 
+	if gotTodo.GetCreatedAt() == nil {
+		t.Fatalf("Want: creation time, Got: nil\n")
+	}
+
 	wantTodo := &todolistpb.Todo{
-		Id:      1,
-		Title:   "Create Todo test",
-		Note:    "This is a test",
-		DueDate: dd,
+		Id:        1,
+		Title:     "Create Todo test",
+		Note:      "This is a test",
+		DueDate:   dd,
+		CreatedAt: gotTodo.GetCreatedAt(),
+		Version:   1,
 	}
 
 	if !reflect.DeepEqual(gotTodo, wantTodo) {
@@ -48,7 +56,7 @@ func TestCreateTodo(t *testing.T) {
 }
 
 func TestReadTodo(t *testing.T) {
-	s := server.Server{&db.MockDB{}}
+	s := server.Server{Repo: &db.MockDB{}}
 
 	res, err := s.ReadTodo(context.Background(), &todolistpb.ReadTodoRequest{TodoId: 1})
 	if err != nil {
@@ -67,6 +75,7 @@ func TestReadTodo(t *testing.T) {
 		Title:   "Test Todo",
 		Note:    "This is a test",
 		DueDate: dd,
+		Version: 1,
 	}
 
 	if !reflect.DeepEqual(gotTodo, wantTodo) {
@@ -75,7 +84,7 @@ func TestReadTodo(t *testing.T) {
 }
 
 func TestUpdateTodo(t *testing.T) {
-	s := server.Server{&db.MockDB{}}
+	s := server.Server{Repo: &db.MockDB{}}
 
 	dd, err := ptypes.TimestampProto(time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local))
 	if err != nil {
@@ -96,13 +105,30 @@ func TestUpdateTodo(t *testing.T) {
 
 	gotTodo := res.GetTodo()
 
+	wantTodo.Version = 2
+
 	if !reflect.DeepEqual(gotTodo, wantTodo) {
 		t.Fatalf("Want: %v, Got: %v\n", wantTodo, gotTodo)
 	}
 }
 
+func TestUpdateTodoVersionMismatch(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	todo := &todolistpb.Todo{
+		Id:    1,
+		Title: "Update Todo version test",
+	}
+
+	_, gotErr := s.UpdateTodo(context.Background(), &todolistpb.UpdateTodoRequest{Todo: todo, ExpectedVersion: 5})
+
+	if status.Code(gotErr) != codes.Aborted {
+		t.Fatalf("Want: %v, Got: %v\n", codes.Aborted, gotErr)
+	}
+}
+
 func TestDeleteTodo(t *testing.T) {
-	s := server.Server{&db.MockDB{}}
+	s := server.Server{Repo: &db.MockDB{}}
 
 	var todoID int32 = 1
 	_, gotErr := s.DeleteTodo(context.Background(), &todolistpb.DeleteTodoRequest{TodoId: todoID})
@@ -116,3 +142,217 @@ func TestDeleteTodo(t *testing.T) {
 		t.Fatalf("Want: %v, Got: %v\n", wantErr, gotErr)
 	}
 }
+
+func TestCompleteTodo(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	res, err := s.CompleteTodo(context.Background(), &todolistpb.CompleteTodoRequest{TodoId: 1})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+
+	gotTodo := res.GetTodo()
+
+	if gotTodo.GetStatus() != todolistpb.Status_DONE {
+		t.Fatalf("Want: %v, Got: %v\n", todolistpb.Status_DONE, gotTodo.GetStatus())
+	}
+
+	if gotTodo.GetCompletedAt() == nil {
+		t.Fatalf("Want: completion time, Got: nil\n")
+	}
+}
+
+func TestReopenTodo(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	res, err := s.ReopenTodo(context.Background(), &todolistpb.ReopenTodoRequest{TodoId: 1})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+
+	gotTodo := res.GetTodo()
+
+	if gotTodo.GetStatus() != todolistpb.Status_OPEN {
+		t.Fatalf("Want: %v, Got: %v\n", todolistpb.Status_OPEN, gotTodo.GetStatus())
+	}
+
+	if gotTodo.GetCompletedAt() != nil {
+		t.Fatalf("Want: nil, Got: %v\n", gotTodo.GetCompletedAt())
+	}
+}
+
+func TestCreateDoneTodo(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	todo := &todolistpb.Todo{
+		Title:   "Create done Todo test",
+		DueDate: ptypes.TimestampNow(),
+		Status:  todolistpb.Status_DONE,
+	}
+	res, err := s.CreateTodo(context.Background(), &todolistpb.CreateTodoRequest{Todo: todo})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+
+	if res.GetTodo().GetCompletedAt() == nil {
+		t.Fatalf("Want: completion time, Got: nil\n")
+	}
+}
+
+// listStream is collecting the responses of the ListTodos stream
+type listStream struct {
+	todolistpb.TodoListService_ListTodosServer
+	res []*todolistpb.ListTodosResponse
+}
+
+func (s *listStream) Send(res *todolistpb.ListTodosResponse) error {
+	s.res = append(s.res, res)
+	return nil
+}
+
+func (s *listStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestListTodos(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	stream := &listStream{}
+	if err := s.ListTodos(&todolistpb.ListTodosRequest{Descending: true, PageSize: 1}, stream); err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+
+	if len(stream.res) != 1 {
+		t.Fatalf("Want: 1 todo, Got: %v\n", len(stream.res))
+	}
+
+	if stream.res[0].GetTodo().GetId() != 2 {
+		t.Fatalf("Want: 2, Got: %v\n", stream.res[0].GetTodo().GetId())
+	}
+
+	next := stream.res[0].GetNextPageToken()
+	if next == "" {
+		t.Fatalf("Want: next page token, Got: empty\n")
+	}
+
+	stream = &listStream{}
+	if err := s.ListTodos(&todolistpb.ListTodosRequest{Descending: true, PageSize: 1, PageToken: next}, stream); err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+
+	if len(stream.res) != 1 || stream.res[0].GetTodo().GetId() != 1 || stream.res[0].GetNextPageToken() != "" {
+		t.Fatalf("Want: last todo 1, Got: %v\n", stream.res)
+	}
+}
+
+func TestListTodosInvalidPageToken(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	gotErr := s.ListTodos(&todolistpb.ListTodosRequest{PageToken: "invalid"}, &listStream{})
+
+	if status.Code(gotErr) != codes.InvalidArgument {
+		t.Fatalf("Want: %v, Got: %v\n", codes.InvalidArgument, gotErr)
+	}
+}
+
+func TestUpdateTodoMask(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	todo := &todolistpb.Todo{
+		Id:    1,
+		Title: "Update Todo mask test",
+	}
+	mask := &field_mask.FieldMask{Paths: []string{"title"}}
+
+	res, err := s.UpdateTodo(context.Background(), &todolistpb.UpdateTodoRequest{Todo: todo, UpdateMask: mask})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+
+	gotTodo := res.GetTodo()
+
+	// the note and the due date of the stored todo are kept
+	if gotTodo.GetTitle() != "Update Todo mask test" || gotTodo.GetNote() != "This is a test" || gotTodo.GetDueDate() == nil {
+		t.Fatalf("Want: only the title updated, Got: %v\n", gotTodo)
+	}
+}
+
+func TestUpdateTodoInvalidMask(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	for _, path := range []string{"unknown", "created_at", "due_date.seconds"} {
+		mask := &field_mask.FieldMask{Paths: []string{path}}
+		_, gotErr := s.UpdateTodo(context.Background(), &todolistpb.UpdateTodoRequest{Todo: &todolistpb.Todo{Id: 1}, UpdateMask: mask})
+
+		if status.Code(gotErr) != codes.InvalidArgument {
+			t.Fatalf("%v: Want: %v, Got: %v\n", path, codes.InvalidArgument, gotErr)
+		}
+	}
+}
+
+// canceledDB is returning the error of the cancelled context like the database driver
+type canceledDB struct {
+	db.MockDB
+}
+
+func (c *canceledDB) Get(ctx context.Context, id int32) (*todolistpb.Todo, error) {
+	return nil, ctx.Err()
+}
+
+func TestReadTodoCanceled(t *testing.T) {
+	s := server.Server{Repo: &canceledDB{}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	_, gotErr := s.ReadTodo(ctx, &todolistpb.ReadTodoRequest{TodoId: 1})
+
+	if status.Code(gotErr) != codes.DeadlineExceeded {
+		t.Fatalf("Want: %v, Got: %v\n", codes.DeadlineExceeded, gotErr)
+	}
+}
+
+// watchStream is collecting the responses of the WatchTodos stream until its context is done
+type watchStream struct {
+	todolistpb.TodoListService_WatchTodosServer
+	ctx context.Context
+	res []*todolistpb.WatchTodosResponse
+}
+
+func (s *watchStream) Send(res *todolistpb.WatchTodosResponse) error {
+	s.res = append(s.res, res)
+	return nil
+}
+
+func (s *watchStream) SendHeader(md metadata.MD) error {
+	return nil
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestWatchTodos(t *testing.T) {
+	tests := []struct {
+		repo     db.Repository
+		token    string
+		wantCode codes.Code
+	}{
+		{&db.MockDB{}, "", codes.Unimplemented},
+		{db.NewBroadcaster(&db.MockDB{}, 10), "invalid", codes.InvalidArgument},
+		{db.NewBroadcaster(&db.MockDB{}, 10), "1.1", codes.OutOfRange},
+		{db.NewBroadcaster(&db.MockDB{}, 10), "", codes.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		s := server.Server{Repo: tt.repo}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := s.WatchTodos(&todolistpb.WatchTodosRequest{ResumeToken: tt.token}, &watchStream{ctx: ctx})
+		cancel()
+
+		if status.Code(err) != tt.wantCode {
+			t.Fatalf("Want: %v, Got: %v\n", tt.wantCode, err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/halimi/todo-list-service/auth"
+	"github.com/halimi/todo-list-service/db"
+)
+
+// TenantUnaryInterceptor is scoping the repository calls of the request to the todos and the lists
+// of the authenticated principal, without authentication everything is owned by the empty owner
+func TenantUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(requestTenant(ctx), req)
+}
+
+// TenantStreamInterceptor is scoping the repository calls of the stream to the todos and the lists
+// of the authenticated principal
+func TenantStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextStream{ServerStream: ss, ctx: requestTenant(ss.Context())})
+}
+
+// requestTenant returns with the context of the owner of the request, the owner of the principal is
+// namespaced by its issuer, so the same subject of a token and of a certificate are different owners
+func requestTenant(ctx context.Context) context.Context {
+	var owner string
+	if p, ok := auth.FromContext(ctx); ok {
+		owner = p.Owner()
+	}
+
+	return db.WithTenant(ctx, owner)
+}
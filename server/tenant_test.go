@@ -0,0 +1,137 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/halimi/todo-list-service/auth"
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/server"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTenantNotFound(t *testing.T) {
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := server.Server{Repo: memory, History: memory}
+	alice := db.WithTenant(context.Background(), "alice")
+	bob := db.WithTenant(context.Background(), "bob")
+
+	list, err := s.CreateTodoList(alice, &todolistpb.CreateTodoListRequest{TodoList: &todolistpb.TodoList{Name: "Alice list"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listID := list.GetTodoList().GetId()
+
+	created, err := s.CreateTodo(alice, &todolistpb.CreateTodoRequest{Todo: &todolistpb.Todo{Title: "Alice todo", DueDate: ptypes.TimestampNow(), ListId: listID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	todoID := created.GetTodo().GetId()
+
+	if created.GetTodo().GetOwner() != "alice" || list.GetTodoList().GetOwner() != "alice" {
+		t.Fatalf("Want: alice, Got: %v %v\n", created.GetTodo(), list.GetTodoList())
+	}
+
+	// the todo and the list of alice do not exist for bob
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{"ReadTodo", func() error {
+			_, err := s.ReadTodo(bob, &todolistpb.ReadTodoRequest{TodoId: todoID})
+			return err
+		}},
+		{"UpdateTodo", func() error {
+			req := &todolistpb.UpdateTodoRequest{Todo: &todolistpb.Todo{Id: todoID, Title: "Bob todo"}, UpdateMask: &field_mask.FieldMask{Paths: []string{"title"}}, ExpectedVersion: 1}
+			_, err := s.UpdateTodo(bob, req)
+			return err
+		}},
+		{"DeleteTodo", func() error {
+			_, err := s.DeleteTodo(bob, &todolistpb.DeleteTodoRequest{TodoId: todoID, ExpectedVersion: 1})
+			return err
+		}},
+		{"CompleteTodo", func() error {
+			_, err := s.CompleteTodo(bob, &todolistpb.CompleteTodoRequest{TodoId: todoID})
+			return err
+		}},
+		{"ReadTodoList", func() error {
+			_, err := s.ReadTodoList(bob, &todolistpb.ReadTodoListRequest{ListId: listID})
+			return err
+		}},
+		{"RevertTodo", func() error {
+			_, err := s.RevertTodo(bob, &todolistpb.RevertTodoRequest{TodoId: todoID, RevisionId: 1})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); status.Code(err) != codes.NotFound {
+				t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+			}
+		})
+	}
+
+	// the list of alice can not be used by bob
+	_, err = s.CreateTodo(bob, &todolistpb.CreateTodoRequest{Todo: &todolistpb.Todo{Title: "Bob todo", DueDate: ptypes.TimestampNow(), ListId: listID}})
+	if status.Code(err) == codes.OK {
+		t.Fatalf("Want: error, Got: %v\n", err)
+	}
+
+	res, err := s.ReadTodo(alice, &todolistpb.ReadTodoRequest{TodoId: todoID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.GetTodo().GetTitle() != "Alice todo" || res.GetTodo().GetVersion() != 1 {
+		t.Fatalf("Want: Alice todo, Got: %v\n", res.GetTodo())
+	}
+}
+
+func TestTenantUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		owner     string
+	}{
+		{"Anonymous", nil, ""},
+		{"Token", &auth.Principal{Subject: "alice", Issuer: "https://issuer.example.com"}, "jwt:https%3A//issuer.example.com:alice"},
+		{"OtherIssuer", &auth.Principal{Subject: "alice", Issuer: "https://other.example.com"}, "jwt:https%3A//other.example.com:alice"},
+		{"Certificate", &auth.Principal{Subject: "alice", Issuer: "CN=Client CA", Certificate: true}, "cert:CN=Client CA:alice"},
+		{"OtherCA", &auth.Principal{Subject: "alice", Issuer: "CN=Other CA", Certificate: true}, "cert:CN=Other CA:alice"},
+		{"ColonInSubject", &auth.Principal{Subject: "b:alice", Issuer: "a"}, "jwt:a:b:alice"},
+		{"ColonInIssuer", &auth.Principal{Subject: "alice", Issuer: "a:b"}, "jwt:a%3Ab:alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.NewContext(ctx, tt.principal)
+			}
+
+			var owner string
+			var all bool
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				owner, all = db.TenantFromContext(ctx)
+				return nil, nil
+			}
+
+			if _, err := server.TenantUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+				t.Fatal(err)
+			}
+
+			if owner != tt.owner || all {
+				t.Fatalf("Want: %v, Got: %v %v\n", tt.owner, owner, all)
+			}
+		})
+	}
+}
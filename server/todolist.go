@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// CreateTodoList request handler
+func (s *Server) CreateTodoList(ctx context.Context, req *todolistpb.CreateTodoListRequest) (*todolistpb.CreateTodoListResponse, error) {
+	list := req.GetTodoList()
+
+	if list.GetName() == "" {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get name"),
+		)
+	}
+
+	list.CreatedAt = ptypes.TimestampNow()
+
+	id, err := s.Repo.InsertTodoList(ctx, list)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	owner, _ := db.TenantFromContext(ctx)
+
+	return &todolistpb.CreateTodoListResponse{
+		TodoList: &todolistpb.TodoList{
+			Id:          id,
+			Name:        list.GetName(),
+			Description: list.GetDescription(),
+			Color:       list.GetColor(),
+			CreatedAt:   list.GetCreatedAt(),
+			Owner:       owner,
+		},
+	}, nil
+}
+
+// ReadTodoList request handler
+func (s *Server) ReadTodoList(ctx context.Context, req *todolistpb.ReadTodoListRequest) (*todolistpb.ReadTodoListResponse, error) {
+	listID := req.GetListId()
+
+	if listID == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	list, err := s.authorizeList(ctx, listID, todolistpb.ListMember_VIEWER)
+	if err != nil {
+		return nil, err
+	}
+
+	return &todolistpb.ReadTodoListResponse{
+		TodoList: list,
+	}, nil
+}
+
+// UpdateTodoList request handler
+func (s *Server) UpdateTodoList(ctx context.Context, req *todolistpb.UpdateTodoListRequest) (*todolistpb.UpdateTodoListResponse, error) {
+	list := req.GetTodoList()
+
+	if list.GetId() == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	if list.GetName() == "" {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get name"),
+		)
+	}
+
+	if _, err := s.authorizeList(ctx, list.GetId(), todolistpb.ListMember_OWNER); err != nil {
+		return nil, err
+	}
+
+	listNew, err := s.Repo.UpdateTodoList(db.WithAllTenants(ctx), list)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if listNew.GetId() == 0 {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Could not found TodoList with the specified ID: %v", list.GetId()),
+		)
+	}
+
+	return &todolistpb.UpdateTodoListResponse{
+		TodoList: listNew,
+	}, nil
+}
+
+// DeleteTodoList request handler
+func (s *Server) DeleteTodoList(ctx context.Context, req *todolistpb.DeleteTodoListRequest) (*todolistpb.DeleteTodoListResponse, error) {
+	listID := req.GetListId()
+
+	if listID == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	if _, err := s.authorizeList(ctx, listID, todolistpb.ListMember_OWNER); err != nil {
+		return nil, err
+	}
+
+	// the todos of the other members are deleted or moved to their inbox too
+	count, err := s.Repo.DeleteTodoList(db.WithAllTenants(ctx), listID, req.GetDeleteTodos())
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if count == 0 {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Could not found TodoList with the specified ID: %v", listID),
+		)
+	}
+
+	return &todolistpb.DeleteTodoListResponse{}, nil
+}
+
+// ListTodoLists request handler, the lists shared with the caller are listed too
+func (s *Server) ListTodoLists(req *todolistpb.ListTodoListsRequest, stream todolistpb.TodoListService_ListTodoListsServer) error {
+	ctx := stream.Context()
+
+	lists, err := s.Repo.ListTodoLists(ctx)
+	if err != nil {
+		return repositoryError(ctx, err)
+	}
+
+	shared, err := s.sharedTodoLists(ctx)
+	if err != nil {
+		return repositoryError(ctx, err)
+	}
+
+	lists = append(lists, shared...)
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].GetId() < lists[j].GetId()
+	})
+
+	for _, list := range lists {
+		if err := stream.Send(&todolistpb.ListTodoListsResponse{TodoList: list}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sharedTodoLists returns with the lists shared with the caller
+func (s *Server) sharedTodoLists(ctx context.Context) ([]*todolistpb.TodoList, error) {
+	if s.Members == nil {
+		return nil, nil
+	}
+
+	subject, _ := db.TenantFromContext(ctx)
+	members, err := s.Members.ListMemberships(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	var lists []*todolistpb.TodoList
+	for _, member := range members {
+		list, err := s.Repo.GetTodoList(db.WithAllTenants(ctx), member.GetListId())
+		if err != nil {
+			return nil, err
+		}
+
+		// the lists owned by the caller are already listed
+		if list.GetId() != 0 && !db.OwnedBy(ctx, list.GetOwner()) {
+			lists = append(lists, list)
+		}
+	}
+
+	return lists, nil
+}
+
+// checkTodoList returns with NOT_FOUND error if the list does not exist or it is not shared with the caller,
+// PERMISSION_DENIED if the caller is not an editor of the list, 0 is the inbox which always exists
+func (s *Server) checkTodoList(ctx context.Context, listID int32) error {
+	if listID == 0 {
+		return nil
+	}
+
+	_, err := s.authorizeList(ctx, listID, todolistpb.ListMember_EDITOR)
+	return err
+}
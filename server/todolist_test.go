@@ -0,0 +1,117 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/server"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateTodoList(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	list := &todolistpb.TodoList{
+		Name:        "Create TodoList test",
+		Description: "This is a test",
+		Color:       "#ff0000",
+	}
+	res, err := s.CreateTodoList(context.Background(), &todolistpb.CreateTodoListRequest{TodoList: list})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+	gotList := res.GetTodoList()
+
+	if gotList.GetCreatedAt() == nil {
+		t.Fatalf("Want: creation time, Got: nil\n")
+	}
+
+	wantList := &todolistpb.TodoList{
+		Id:          1,
+		Name:        "Create TodoList test",
+		Description: "This is a test",
+		Color:       "#ff0000",
+		CreatedAt:   gotList.GetCreatedAt(),
+	}
+
+	if !reflect.DeepEqual(gotList, wantList) {
+		t.Fatalf("Want: %v, Got: %v\n", wantList, gotList)
+	}
+}
+
+func TestCreateTodoListWithoutName(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	_, gotErr := s.CreateTodoList(context.Background(), &todolistpb.CreateTodoListRequest{TodoList: &todolistpb.TodoList{}})
+
+	if status.Code(gotErr) != codes.InvalidArgument {
+		t.Fatalf("Want: %v, Got: %v\n", codes.InvalidArgument, gotErr)
+	}
+}
+
+func TestReadTodoList(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	res, err := s.ReadTodoList(context.Background(), &todolistpb.ReadTodoListRequest{ListId: 1})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+
+	gotList := res.GetTodoList()
+
+	if gotList.GetId() != 1 || gotList.GetName() != "Test List" {
+		t.Fatalf("Want: Test List, Got: %v\n", gotList)
+	}
+}
+
+func TestUpdateTodoList(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	wantList := &todolistpb.TodoList{
+		Id:   1,
+		Name: "Update TodoList test",
+	}
+
+	res, err := s.UpdateTodoList(context.Background(), &todolistpb.UpdateTodoListRequest{TodoList: wantList})
+	if err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+
+	gotList := res.GetTodoList()
+
+	if !reflect.DeepEqual(gotList, wantList) {
+		t.Fatalf("Want: %v, Got: %v\n", wantList, gotList)
+	}
+}
+
+func TestDeleteTodoList(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	var listID int32 = 1
+	_, gotErr := s.DeleteTodoList(context.Background(), &todolistpb.DeleteTodoListRequest{ListId: listID})
+
+	wantErr := status.Errorf(
+		codes.NotFound,
+		fmt.Sprintf("Could not found TodoList with the specified ID: %v", listID),
+	)
+
+	if !reflect.DeepEqual(gotErr, wantErr) {
+		t.Fatalf("Want: %v, Got: %v\n", wantErr, gotErr)
+	}
+}
+
+func TestListTodosInListAndInbox(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	gotErr := s.ListTodos(&todolistpb.ListTodosRequest{ListId: 1, Inbox: true}, &listStream{})
+
+	if status.Code(gotErr) != codes.InvalidArgument {
+		t.Fatalf("Want: %v, Got: %v\n", codes.InvalidArgument, gotErr)
+	}
+}
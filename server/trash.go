@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// RestoreTodo request handler
+func (s *Server) RestoreTodo(ctx context.Context, req *todolistpb.RestoreTodoRequest) (*todolistpb.RestoreTodoResponse, error) {
+	todoID := req.GetTodoId()
+
+	if todoID == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	todoCtx, err := s.todoContext(ctx, todoID, todolistpb.ListMember_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := s.Repo.Restore(todoCtx, todoID)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if todo.GetId() == 0 {
+		return nil, status.Errorf(
+			codes.NotFound,
+			fmt.Sprintf("Could not found Todo in the trash with the specified ID: %v", todoID),
+		)
+	}
+
+	return &todolistpb.RestoreTodoResponse{
+		Todo: todo,
+	}, nil
+}
+
+// ListTrash request handler
+func (s *Server) ListTrash(req *todolistpb.ListTrashRequest, stream todolistpb.TodoListService_ListTrashServer) error {
+	ctx := stream.Context()
+
+	todos, err := s.Repo.ListTrash(ctx)
+	if err != nil {
+		return repositoryError(ctx, err)
+	}
+
+	for _, todo := range todos {
+		if err := stream.Send(&todolistpb.ListTrashResponse{Todo: todo}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EmptyTrash request handler
+func (s *Server) EmptyTrash(ctx context.Context, req *todolistpb.EmptyTrashRequest) (*todolistpb.EmptyTrashResponse, error) {
+	count, err := s.Repo.Purge(ctx, nil)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	return &todolistpb.EmptyTrashResponse{
+		Count: count,
+	}, nil
+}
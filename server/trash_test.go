@@ -0,0 +1,95 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/server"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type trashListStream struct {
+	todolistpb.TodoListService_ListTrashServer
+	res []*todolistpb.ListTrashResponse
+}
+
+func (s *trashListStream) Send(res *todolistpb.ListTrashResponse) error {
+	s.res = append(s.res, res)
+	return nil
+}
+
+func (s *trashListStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestTrash(t *testing.T) {
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := server.Server{Repo: memory}
+	ctx := context.Background()
+
+	created, err := s.CreateTodo(ctx, &todolistpb.CreateTodoRequest{Todo: &todolistpb.Todo{Title: "Trash test", DueDate: ptypes.TimestampNow()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	todoID := created.GetTodo().GetId()
+
+	if _, err := s.DeleteTodo(ctx, &todolistpb.DeleteTodoRequest{TodoId: todoID}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.ReadTodo(ctx, &todolistpb.ReadTodoRequest{TodoId: todoID}); status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+
+	stream := &trashListStream{}
+	if err := s.ListTrash(&todolistpb.ListTrashRequest{}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.res) != 1 || stream.res[0].GetTodo().GetId() != todoID || stream.res[0].GetTodo().GetDeletedAt() == nil {
+		t.Fatalf("Want: deleted todo %v, Got: %v\n", todoID, stream.res)
+	}
+
+	res, err := s.RestoreTodo(ctx, &todolistpb.RestoreTodoRequest{TodoId: todoID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.GetTodo().GetTitle() != "Trash test" || res.GetTodo().GetDeletedAt() != nil {
+		t.Fatalf("Want: restored todo, Got: %v\n", res.GetTodo())
+	}
+
+	if _, err := s.RestoreTodo(ctx, &todolistpb.RestoreTodoRequest{TodoId: todoID}); status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+
+	if _, err := s.DeleteTodo(ctx, &todolistpb.DeleteTodoRequest{TodoId: todoID}); err != nil {
+		t.Fatal(err)
+	}
+
+	emptied, err := s.EmptyTrash(ctx, &todolistpb.EmptyTrashRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if emptied.GetCount() != 1 {
+		t.Fatalf("Want: 1, Got: %v\n", emptied.GetCount())
+	}
+}
+
+func TestRestoreTodoInvalid(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	_, err := s.RestoreTodo(context.Background(), &todolistpb.RestoreTodoRequest{})
+
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Want: %v, Got: %v\n", codes.InvalidArgument, err)
+	}
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/events"
+	"github.com/halimi/todo-list-service/todolistpb"
+)
+
+// CreateWebhook request handler
+func (s *Server) CreateWebhook(ctx context.Context, req *todolistpb.CreateWebhookRequest) (*todolistpb.CreateWebhookResponse, error) {
+	store, err := s.webhookStore()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := req.GetWebhook()
+
+	if err := checkWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	if webhook.GetSecret() == "" {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get secret"),
+		)
+	}
+
+	webhook.Disabled = false
+	webhook.CreatedAt = ptypes.TimestampNow()
+
+	id, err := store.InsertWebhook(ctx, webhook)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	owner, _ := db.TenantFromContext(ctx)
+
+	return &todolistpb.CreateWebhookResponse{
+		Webhook: &todolistpb.Webhook{
+			Id:         id,
+			Url:        webhook.GetUrl(),
+			EventTypes: webhook.GetEventTypes(),
+			CreatedAt:  webhook.GetCreatedAt(),
+			Owner:      owner,
+		},
+	}, nil
+}
+
+// ReadWebhook request handler
+func (s *Server) ReadWebhook(ctx context.Context, req *todolistpb.ReadWebhookRequest) (*todolistpb.ReadWebhookResponse, error) {
+	store, err := s.webhookStore()
+	if err != nil {
+		return nil, err
+	}
+
+	webhookID := req.GetWebhookId()
+
+	if webhookID == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	webhook, err := store.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if webhook.GetId() == 0 {
+		return nil, webhookNotFound(webhookID)
+	}
+
+	return &todolistpb.ReadWebhookResponse{
+		Webhook: withoutSecret(webhook),
+	}, nil
+}
+
+// UpdateWebhook request handler
+func (s *Server) UpdateWebhook(ctx context.Context, req *todolistpb.UpdateWebhookRequest) (*todolistpb.UpdateWebhookResponse, error) {
+	store, err := s.webhookStore()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := req.GetWebhook()
+
+	if webhook.GetId() == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	if err := checkWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	webhookNew, err := store.UpdateWebhook(ctx, webhook)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if webhookNew.GetId() == 0 {
+		return nil, webhookNotFound(webhook.GetId())
+	}
+
+	return &todolistpb.UpdateWebhookResponse{
+		Webhook: withoutSecret(webhookNew),
+	}, nil
+}
+
+// DeleteWebhook request handler
+func (s *Server) DeleteWebhook(ctx context.Context, req *todolistpb.DeleteWebhookRequest) (*todolistpb.DeleteWebhookResponse, error) {
+	store, err := s.webhookStore()
+	if err != nil {
+		return nil, err
+	}
+
+	webhookID := req.GetWebhookId()
+
+	if webhookID == 0 {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	count, err := store.DeleteWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, repositoryError(ctx, err)
+	}
+
+	if count == 0 {
+		return nil, webhookNotFound(webhookID)
+	}
+
+	return &todolistpb.DeleteWebhookResponse{}, nil
+}
+
+// ListWebhooks request handler
+func (s *Server) ListWebhooks(req *todolistpb.ListWebhooksRequest, stream todolistpb.TodoListService_ListWebhooksServer) error {
+	store, err := s.webhookStore()
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+
+	webhooks, err := store.ListWebhooks(ctx)
+	if err != nil {
+		return repositoryError(ctx, err)
+	}
+
+	for _, webhook := range webhooks {
+		if err := stream.Send(&todolistpb.ListWebhooksResponse{Webhook: withoutSecret(webhook)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveries request handler
+func (s *Server) ListWebhookDeliveries(req *todolistpb.ListWebhookDeliveriesRequest, stream todolistpb.TodoListService_ListWebhookDeliveriesServer) error {
+	store, err := s.webhookStore()
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	webhookID := req.GetWebhookId()
+
+	if webhookID == 0 {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get ID"),
+		)
+	}
+
+	webhook, err := store.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return repositoryError(ctx, err)
+	}
+
+	if webhook.GetId() == 0 {
+		return webhookNotFound(webhookID)
+	}
+
+	deliveries, err := store.ListWebhookDeliveries(ctx, webhookID, db.DeliveryPageSize(req))
+	if err != nil {
+		return repositoryError(ctx, err)
+	}
+
+	for _, delivery := range deliveries {
+		if err := stream.Send(&todolistpb.ListWebhookDeliveriesResponse{Delivery: delivery}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// webhookStore returns with UNIMPLEMENTED error if the webhooks are not stored
+func (s *Server) webhookStore() (db.WebhookStore, error) {
+	if s.Webhooks == nil {
+		return nil, status.Errorf(
+			codes.Unimplemented,
+			fmt.Sprintf("Webhooks are not supported by the database"),
+		)
+	}
+
+	return s.Webhooks, nil
+}
+
+// checkWebhook returns with INVALID_ARGUMENT error if the URL or the event types are not valid
+func checkWebhook(webhook *todolistpb.Webhook) error {
+	u, err := url.Parse(webhook.GetUrl())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("Could not get an absolute HTTP URL: %q", webhook.GetUrl()),
+		)
+	}
+
+	for _, t := range webhook.GetEventTypes() {
+		valid := false
+		for _, eventType := range events.Types {
+			if t == eventType {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return status.Errorf(
+				codes.InvalidArgument,
+				fmt.Sprintf("Unknown event type %q, use some of: %v", t, events.Types),
+			)
+		}
+	}
+
+	return nil
+}
+
+// withoutSecret returns with a copy of the webhook without its secret
+func withoutSecret(webhook *todolistpb.Webhook) *todolistpb.Webhook {
+	w := proto.Clone(webhook).(*todolistpb.Webhook)
+	w.Secret = ""
+
+	return w
+}
+
+func webhookNotFound(webhookID int32) error {
+	return status.Errorf(
+		codes.NotFound,
+		fmt.Sprintf("Could not found Webhook with the specified ID: %v", webhookID),
+	)
+}
@@ -0,0 +1,272 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/server"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type webhookListStream struct {
+	todolistpb.TodoListService_ListWebhooksServer
+	ctx context.Context
+	res []*todolistpb.ListWebhooksResponse
+}
+
+func (s *webhookListStream) Send(res *todolistpb.ListWebhooksResponse) error {
+	s.res = append(s.res, res)
+	return nil
+}
+
+func (s *webhookListStream) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+
+	return s.ctx
+}
+
+type deliveryListStream struct {
+	todolistpb.TodoListService_ListWebhookDeliveriesServer
+	ctx context.Context
+	res []*todolistpb.ListWebhookDeliveriesResponse
+}
+
+func (s *deliveryListStream) Send(res *todolistpb.ListWebhookDeliveriesResponse) error {
+	s.res = append(s.res, res)
+	return nil
+}
+
+func (s *deliveryListStream) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+
+	return s.ctx
+}
+
+func setupWebhookServer(t *testing.T) (*server.Server, *db.Memory) {
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &server.Server{Repo: memory, Webhooks: memory}, memory
+}
+
+func TestCreateWebhook(t *testing.T) {
+	s, _ := setupWebhookServer(t)
+	ctx := context.Background()
+
+	webhook := &todolistpb.Webhook{
+		Url:        "https://example.com/hook",
+		EventTypes: []string{"todo.created", "todo.due"},
+		Secret:     "secret",
+	}
+	res, err := s.CreateWebhook(ctx, &todolistpb.CreateWebhookRequest{Webhook: webhook})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := res.GetWebhook()
+	if got.GetId() == 0 || got.GetUrl() != "https://example.com/hook" || len(got.GetEventTypes()) != 2 || got.GetCreatedAt() == nil {
+		t.Fatalf("Want: %v, Got: %v\n", webhook, got)
+	}
+
+	// the secret is never returned
+	if got.GetSecret() != "" {
+		t.Fatalf("Want: no secret, Got: %v\n", got.GetSecret())
+	}
+
+	read, err := s.ReadWebhook(ctx, &todolistpb.ReadWebhookRequest{WebhookId: got.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read.GetWebhook().GetSecret() != "" || read.GetWebhook().GetUrl() != "https://example.com/hook" {
+		t.Fatalf("Want: %v without secret, Got: %v\n", got, read.GetWebhook())
+	}
+
+	stream := &webhookListStream{}
+	if err := s.ListWebhooks(&todolistpb.ListWebhooksRequest{}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.res) != 1 || stream.res[0].GetWebhook().GetSecret() != "" {
+		t.Fatalf("Want: 1 webhook without secret, Got: %v\n", stream.res)
+	}
+}
+
+func TestCreateWebhookInvalid(t *testing.T) {
+	s, _ := setupWebhookServer(t)
+
+	tests := []struct {
+		name    string
+		webhook *todolistpb.Webhook
+	}{
+		{"NoURL", &todolistpb.Webhook{Secret: "secret"}},
+		{"RelativeURL", &todolistpb.Webhook{Url: "/hook", Secret: "secret"}},
+		{"Scheme", &todolistpb.Webhook{Url: "ftp://example.com/hook", Secret: "secret"}},
+		{"NoSecret", &todolistpb.Webhook{Url: "https://example.com/hook"}},
+		{"EventType", &todolistpb.Webhook{Url: "https://example.com/hook", Secret: "secret", EventTypes: []string{"todo.moved"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateWebhook(context.Background(), &todolistpb.CreateWebhookRequest{Webhook: tt.webhook})
+
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("Want: %v, Got: %v\n", codes.InvalidArgument, err)
+			}
+		})
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	s, memory := setupWebhookServer(t)
+	ctx := context.Background()
+
+	id, err := memory.InsertWebhook(ctx, &todolistpb.Webhook{Url: "https://example.com/hook", Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := memory.RecordWebhookResult(ctx, id, false, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// the disabled webhook is enabled again
+	res, err := s.UpdateWebhook(ctx, &todolistpb.UpdateWebhookRequest{Webhook: &todolistpb.Webhook{Id: id, Url: "https://example.com/new"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := res.GetWebhook()
+	if got.GetUrl() != "https://example.com/new" || got.GetDisabled() || got.GetFailureCount() != 0 || got.GetSecret() != "" {
+		t.Fatalf("Want: enabled webhook, Got: %v\n", got)
+	}
+
+	webhook, err := memory.GetWebhook(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if webhook.GetSecret() != "secret" {
+		t.Fatalf("Want: %v, Got: %v\n", "secret", webhook.GetSecret())
+	}
+}
+
+func TestWebhookNotFound(t *testing.T) {
+	s, _ := setupWebhookServer(t)
+	ctx := context.Background()
+
+	_, err := s.ReadWebhook(ctx, &todolistpb.ReadWebhookRequest{WebhookId: 100})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+
+	_, err = s.UpdateWebhook(ctx, &todolistpb.UpdateWebhookRequest{Webhook: &todolistpb.Webhook{Id: 100, Url: "https://example.com/hook"}})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+
+	_, err = s.DeleteWebhook(ctx, &todolistpb.DeleteWebhookRequest{WebhookId: 100})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+
+	err = s.ListWebhookDeliveries(&todolistpb.ListWebhookDeliveriesRequest{WebhookId: 100}, &deliveryListStream{})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+}
+
+func TestWebhookOfOtherOwner(t *testing.T) {
+	s, _ := setupWebhookServer(t)
+	alice := db.WithTenant(context.Background(), "alice")
+	bob := db.WithTenant(context.Background(), "bob")
+
+	res, err := s.CreateWebhook(alice, &todolistpb.CreateWebhookRequest{Webhook: &todolistpb.Webhook{Url: "https://example.com/hook", Secret: "secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := res.GetWebhook().GetId()
+	if res.GetWebhook().GetOwner() != "alice" {
+		t.Fatalf("Want: %v, Got: %v\n", "alice", res.GetWebhook().GetOwner())
+	}
+
+	// the webhooks of the other owners are not found
+	_, err = s.ReadWebhook(bob, &todolistpb.ReadWebhookRequest{WebhookId: id})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+
+	_, err = s.UpdateWebhook(bob, &todolistpb.UpdateWebhookRequest{Webhook: &todolistpb.Webhook{Id: id, Url: "https://example.com/bob"}})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+
+	_, err = s.DeleteWebhook(bob, &todolistpb.DeleteWebhookRequest{WebhookId: id})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+
+	err = s.ListWebhookDeliveries(&todolistpb.ListWebhookDeliveriesRequest{WebhookId: id}, &deliveryListStream{ctx: bob})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Want: %v, Got: %v\n", codes.NotFound, err)
+	}
+
+	stream := &webhookListStream{ctx: bob}
+	if err := s.ListWebhooks(&todolistpb.ListWebhooksRequest{}, stream); err != nil || len(stream.res) != 0 {
+		t.Fatalf("Want: no webhooks, Got: %v %v\n", stream.res, err)
+	}
+
+	stream = &webhookListStream{ctx: alice}
+	if err := s.ListWebhooks(&todolistpb.ListWebhooksRequest{}, stream); err != nil || len(stream.res) != 1 {
+		t.Fatalf("Want: 1 webhook, Got: %v %v\n", stream.res, err)
+	}
+
+	if _, err := s.ReadWebhook(alice, &todolistpb.ReadWebhookRequest{WebhookId: id}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	s, memory := setupWebhookServer(t)
+	ctx := context.Background()
+
+	id, err := memory.InsertWebhook(ctx, &todolistpb.Webhook{Url: "https://example.com/hook", Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := int32(1); i <= 3; i++ {
+		if _, err := memory.InsertWebhookDelivery(ctx, &todolistpb.WebhookDelivery{WebhookId: id, EventId: 1, Attempt: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stream := &deliveryListStream{}
+	if err := s.ListWebhookDeliveries(&todolistpb.ListWebhookDeliveriesRequest{WebhookId: id, PageSize: 2}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stream.res) != 2 || stream.res[0].GetDelivery().GetAttempt() != 3 || stream.res[1].GetDelivery().GetAttempt() != 2 {
+		t.Fatalf("Want: attempts 3 2, Got: %v\n", stream.res)
+	}
+}
+
+func TestWebhooksUnimplemented(t *testing.T) {
+	s := server.Server{Repo: &db.MockDB{}}
+
+	_, err := s.CreateWebhook(context.Background(), &todolistpb.CreateWebhookRequest{})
+
+	if status.Code(err) != codes.Unimplemented {
+		t.Fatalf("Want: %v, Got: %v\n", codes.Unimplemented, err)
+	}
+}
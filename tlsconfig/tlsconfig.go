@@ -0,0 +1,200 @@
+// Package tlsconfig is loading the TLS configurations of the gRPC server and its clients from PEM files,
+// the certificates of the server are reloaded when the files change, without a restart
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+const defaultReloadInterval = time.Minute
+
+// Server is the TLS configuration of the server, the certificates are read from the files by Load
+// and they are read again by Run when the files change
+type Server struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is the PEM file of the CAs of the client certificates, the clients are not asked
+	// for a certificate if it is empty
+	ClientCAFile string
+	// OptionalClientCert is accepting the connections without a client certificate, the certificates
+	// sent by the clients are verified anyway
+	OptionalClientCert bool
+
+	// Interval is the time between checking the files for changes, 1m if 0
+	Interval time.Duration
+
+	mu      sync.RWMutex
+	config  *tls.Config
+	modTime time.Time
+}
+
+// Load is reading the certificates from the files
+func (s *Server) Load() error {
+	// the modification time is read first, so a change during the load is loaded again by Run
+	modTime, err := s.lastModTime()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.CertFile, s.KeyFile)
+	if err != nil {
+		return fmt.Errorf("could not load the certificate %v: %v", s.CertFile, err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2"},
+	}
+
+	if s.ClientCAFile != "" {
+		config.ClientCAs, err = loadCertPool(s.ClientCAFile)
+		if err != nil {
+			return err
+		}
+
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		if s.OptionalClientCert {
+			config.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+
+	s.mu.Lock()
+	s.config = config
+	s.modTime = modTime
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Config returns with the TLS configuration of the listener, every handshake is using the last
+// loaded certificates
+func (s *Server) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+
+			if s.config == nil {
+				return nil, errors.New("the TLS certificates are not loaded")
+			}
+
+			return s.config, nil
+		},
+	}
+}
+
+// Run is reloading the certificates when the files change until the context is done, the previous
+// certificates are kept if the changed files can not be loaded
+func (s *Server) Run(ctx context.Context) error {
+	interval := s.Interval
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		reloaded, err := s.Reload()
+		if err != nil {
+			log.Printf("Could not reload the TLS certificates: %v", err)
+		} else if reloaded {
+			log.Printf("Reloaded the TLS certificates")
+		}
+	}
+}
+
+// Reload is loading the certificates again if any of the files changed since the last load
+func (s *Server) Reload() (bool, error) {
+	modTime, err := s.lastModTime()
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	changed := !modTime.Equal(s.modTime)
+	s.mu.RUnlock()
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, s.Load()
+}
+
+// lastModTime returns with the last modification time of the files
+func (s *Server) lastModTime() (time.Time, error) {
+	var last time.Time
+	for _, path := range []string{s.CertFile, s.KeyFile, s.ClientCAFile} {
+		if path == "" {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+
+	return last, nil
+}
+
+// Client returns with the TLS configuration of a client, the certificate of the server is verified
+// by the CAs of the caFile, or by the CAs of the system if it is empty. The client certificate
+// of the certFile and the keyFile is sent if they are set.
+func Client(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+
+	if caFile != "" {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load the client certificate %v: %v", certFile, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// loadCertPool returns with the pool of the certificates of the PEM file
+func loadCertPool(path string) (*x509.CertPool, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("no certificates in %v", path)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,276 @@
+package tlsconfig_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/halimi/todo-list-service/tlsconfig"
+)
+
+// issuer is a certificate with its key, it is signing the other certificates
+type issuer struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newCA returns with a self-signed CA
+func newCA(t *testing.T, name string) *issuer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &issuer{cert: cert, key: key}
+}
+
+// writeCA is writing the certificate of the CA to a PEM file, it returns with the path of the file
+func writeCA(t *testing.T, ca *issuer, name string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// writeCert is writing a certificate of the common name signed by the CA and its key to PEM files,
+// it returns with the paths of the files
+func writeCert(t *testing.T, dir string, ca *issuer, serial int64, commonName string, usage x509.ExtKeyUsage) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, commonName+".crt")
+	keyFile := filepath.Join(dir, commonName+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+// touch is setting the modification time of the files to the future, so the change is seen by Reload
+// even if the file system has a coarse time resolution
+func touch(t *testing.T, modTime time.Time, paths ...string) {
+	for _, path := range paths {
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// handshake returns with the certificate of the server seen by the client, and the certificate
+// of the client seen by the server
+func handshake(t *testing.T, serverConfig, clientConfig *tls.Config) (*x509.Certificate, *x509.Certificate, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	type result struct {
+		conn *tls.Conn
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		c, err := lis.Accept()
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		conn := tls.Server(c, serverConfig)
+		results <- result{conn: conn, err: conn.Handshake()}
+		conn.Close()
+	}()
+
+	client, err := tls.Dial("tcp", lis.Addr().String(), clientConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer client.Close()
+
+	// the client certificate is verified by the server after the handshake of the client with TLS 1.3
+	server := <-results
+	if server.err != nil {
+		return nil, nil, server.err
+	}
+
+	var clientCert *x509.Certificate
+	if certs := server.conn.ConnectionState().PeerCertificates; len(certs) > 0 {
+		clientCert = certs[0]
+	}
+
+	return client.ConnectionState().PeerCertificates[0], clientCert, nil
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newCA(t, "Server CA")
+	clientCA := newCA(t, "Client CA")
+	serverCAFile := writeCA(t, serverCA, "server-ca.crt")
+	clientCAFile := writeCA(t, clientCA, "client-ca.crt")
+	certFile, keyFile := writeCert(t, dir, serverCA, 2, "server", x509.ExtKeyUsageServerAuth)
+	clientCertFile, clientKeyFile := writeCert(t, dir, clientCA, 3, "alice", x509.ExtKeyUsageClientAuth)
+
+	s := &tlsconfig.Server{CertFile: certFile, KeyFile: keyFile, ClientCAFile: clientCAFile}
+	if err := s.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	withCert, err := tlsconfig.Client(serverCAFile, clientCertFile, clientKeyFile, "localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, clientCert, err := handshake(t, s.Config(), withCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if clientCert == nil || clientCert.Subject.CommonName != "alice" {
+		t.Fatalf("Want: alice, Got: %v\n", clientCert)
+	}
+
+	withoutCert, err := tlsconfig.Client(serverCAFile, "", "", "localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := handshake(t, s.Config(), withoutCert); err == nil {
+		t.Fatalf("Want: error, Got: nil\n")
+	}
+
+	// the connections without a client certificate are accepted if it is optional
+	s.OptionalClientCert = true
+	if err := s.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := handshake(t, s.Config(), withoutCert); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t, "Server CA")
+	caFile := writeCA(t, ca, "ca.crt")
+	certFile, keyFile := writeCert(t, dir, ca, 2, "server", x509.ExtKeyUsageServerAuth)
+
+	s := &tlsconfig.Server{CertFile: certFile, KeyFile: keyFile}
+	if err := s.Load(); err != nil {
+		t.Fatal(err)
+	}
+	config := s.Config()
+
+	client, err := tlsconfig.Client(caFile, "", "", "localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := s.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reloaded {
+		t.Fatalf("Want: false, Got: %v\n", reloaded)
+	}
+
+	// the certificate is replaced in place, the config of the listener is serving the new one
+	writeCert(t, dir, ca, 4, "server", x509.ExtKeyUsageServerAuth)
+	touch(t, time.Now().Add(time.Minute), certFile, keyFile)
+
+	reloaded, err = s.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reloaded {
+		t.Fatalf("Want: true, Got: %v\n", reloaded)
+	}
+
+	serverCert, _, err := handshake(t, config, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if serverCert.SerialNumber.Int64() != 4 {
+		t.Fatalf("Want: 4, Got: %v\n", serverCert.SerialNumber)
+	}
+
+	// the previous certificate is kept if the new one can not be loaded
+	if err := os.WriteFile(certFile, []byte("invalid"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, time.Now().Add(2*time.Minute), certFile)
+
+	if _, err := s.Reload(); err == nil {
+		t.Fatalf("Want: error, Got: nil\n")
+	}
+
+	serverCert, _, err = handshake(t, config, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if serverCert.SerialNumber.Int64() != 4 {
+		t.Fatalf("Want: 4, Got: %v\n", serverCert.SerialNumber)
+	}
+}
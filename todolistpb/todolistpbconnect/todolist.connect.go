@@ -0,0 +1,919 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: todolistpb/todolist.proto
+
+package todolistpbconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	todolistpb "github.com/halimi/todo-list-service/todolistpb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TodoListServiceName is the fully-qualified name of the TodoListService service.
+	TodoListServiceName = "todolist.TodoListService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TodoListServiceCreateTodoProcedure is the fully-qualified name of the TodoListService's
+	// CreateTodo RPC.
+	TodoListServiceCreateTodoProcedure = "/todolist.TodoListService/CreateTodo"
+	// TodoListServiceReadTodoProcedure is the fully-qualified name of the TodoListService's ReadTodo
+	// RPC.
+	TodoListServiceReadTodoProcedure = "/todolist.TodoListService/ReadTodo"
+	// TodoListServiceUpdateTodoProcedure is the fully-qualified name of the TodoListService's
+	// UpdateTodo RPC.
+	TodoListServiceUpdateTodoProcedure = "/todolist.TodoListService/UpdateTodo"
+	// TodoListServiceDeleteTodoProcedure is the fully-qualified name of the TodoListService's
+	// DeleteTodo RPC.
+	TodoListServiceDeleteTodoProcedure = "/todolist.TodoListService/DeleteTodo"
+	// TodoListServiceListTodosProcedure is the fully-qualified name of the TodoListService's ListTodos
+	// RPC.
+	TodoListServiceListTodosProcedure = "/todolist.TodoListService/ListTodos"
+	// TodoListServiceWatchTodosProcedure is the fully-qualified name of the TodoListService's
+	// WatchTodos RPC.
+	TodoListServiceWatchTodosProcedure = "/todolist.TodoListService/WatchTodos"
+	// TodoListServiceCompleteTodoProcedure is the fully-qualified name of the TodoListService's
+	// CompleteTodo RPC.
+	TodoListServiceCompleteTodoProcedure = "/todolist.TodoListService/CompleteTodo"
+	// TodoListServiceReopenTodoProcedure is the fully-qualified name of the TodoListService's
+	// ReopenTodo RPC.
+	TodoListServiceReopenTodoProcedure = "/todolist.TodoListService/ReopenTodo"
+	// TodoListServiceGetTodoHistoryProcedure is the fully-qualified name of the TodoListService's
+	// GetTodoHistory RPC.
+	TodoListServiceGetTodoHistoryProcedure = "/todolist.TodoListService/GetTodoHistory"
+	// TodoListServiceRevertTodoProcedure is the fully-qualified name of the TodoListService's
+	// RevertTodo RPC.
+	TodoListServiceRevertTodoProcedure = "/todolist.TodoListService/RevertTodo"
+	// TodoListServiceRestoreTodoProcedure is the fully-qualified name of the TodoListService's
+	// RestoreTodo RPC.
+	TodoListServiceRestoreTodoProcedure = "/todolist.TodoListService/RestoreTodo"
+	// TodoListServiceListTrashProcedure is the fully-qualified name of the TodoListService's ListTrash
+	// RPC.
+	TodoListServiceListTrashProcedure = "/todolist.TodoListService/ListTrash"
+	// TodoListServiceEmptyTrashProcedure is the fully-qualified name of the TodoListService's
+	// EmptyTrash RPC.
+	TodoListServiceEmptyTrashProcedure = "/todolist.TodoListService/EmptyTrash"
+	// TodoListServiceCreateTodoListProcedure is the fully-qualified name of the TodoListService's
+	// CreateTodoList RPC.
+	TodoListServiceCreateTodoListProcedure = "/todolist.TodoListService/CreateTodoList"
+	// TodoListServiceReadTodoListProcedure is the fully-qualified name of the TodoListService's
+	// ReadTodoList RPC.
+	TodoListServiceReadTodoListProcedure = "/todolist.TodoListService/ReadTodoList"
+	// TodoListServiceUpdateTodoListProcedure is the fully-qualified name of the TodoListService's
+	// UpdateTodoList RPC.
+	TodoListServiceUpdateTodoListProcedure = "/todolist.TodoListService/UpdateTodoList"
+	// TodoListServiceDeleteTodoListProcedure is the fully-qualified name of the TodoListService's
+	// DeleteTodoList RPC.
+	TodoListServiceDeleteTodoListProcedure = "/todolist.TodoListService/DeleteTodoList"
+	// TodoListServiceListTodoListsProcedure is the fully-qualified name of the TodoListService's
+	// ListTodoLists RPC.
+	TodoListServiceListTodoListsProcedure = "/todolist.TodoListService/ListTodoLists"
+	// TodoListServiceShareListProcedure is the fully-qualified name of the TodoListService's ShareList
+	// RPC.
+	TodoListServiceShareListProcedure = "/todolist.TodoListService/ShareList"
+	// TodoListServiceUnshareListProcedure is the fully-qualified name of the TodoListService's
+	// UnshareList RPC.
+	TodoListServiceUnshareListProcedure = "/todolist.TodoListService/UnshareList"
+	// TodoListServiceListMembersProcedure is the fully-qualified name of the TodoListService's
+	// ListMembers RPC.
+	TodoListServiceListMembersProcedure = "/todolist.TodoListService/ListMembers"
+	// TodoListServiceCreateWebhookProcedure is the fully-qualified name of the TodoListService's
+	// CreateWebhook RPC.
+	TodoListServiceCreateWebhookProcedure = "/todolist.TodoListService/CreateWebhook"
+	// TodoListServiceReadWebhookProcedure is the fully-qualified name of the TodoListService's
+	// ReadWebhook RPC.
+	TodoListServiceReadWebhookProcedure = "/todolist.TodoListService/ReadWebhook"
+	// TodoListServiceUpdateWebhookProcedure is the fully-qualified name of the TodoListService's
+	// UpdateWebhook RPC.
+	TodoListServiceUpdateWebhookProcedure = "/todolist.TodoListService/UpdateWebhook"
+	// TodoListServiceDeleteWebhookProcedure is the fully-qualified name of the TodoListService's
+	// DeleteWebhook RPC.
+	TodoListServiceDeleteWebhookProcedure = "/todolist.TodoListService/DeleteWebhook"
+	// TodoListServiceListWebhooksProcedure is the fully-qualified name of the TodoListService's
+	// ListWebhooks RPC.
+	TodoListServiceListWebhooksProcedure = "/todolist.TodoListService/ListWebhooks"
+	// TodoListServiceListWebhookDeliveriesProcedure is the fully-qualified name of the
+	// TodoListService's ListWebhookDeliveries RPC.
+	TodoListServiceListWebhookDeliveriesProcedure = "/todolist.TodoListService/ListWebhookDeliveries"
+)
+
+// TodoListServiceClient is a client for the todolist.TodoListService service.
+type TodoListServiceClient interface {
+	CreateTodo(context.Context, *connect.Request[todolistpb.CreateTodoRequest]) (*connect.Response[todolistpb.CreateTodoResponse], error)
+	// return NOT_FOUND if not found
+	ReadTodo(context.Context, *connect.Request[todolistpb.ReadTodoRequest]) (*connect.Response[todolistpb.ReadTodoResponse], error)
+	// return NOT_FOUND if not found, ABORTED if the version moved
+	UpdateTodo(context.Context, *connect.Request[todolistpb.UpdateTodoRequest]) (*connect.Response[todolistpb.UpdateTodoResponse], error)
+	// the todo is moved to the trash, return NOT_FOUND if not found, ABORTED if the version moved
+	DeleteTodo(context.Context, *connect.Request[todolistpb.DeleteTodoRequest]) (*connect.Response[todolistpb.DeleteTodoResponse], error)
+	// the todos are streamed one page at a time, over HTTP as one JSON object per line with the response in the result field
+	ListTodos(context.Context, *connect.Request[todolistpb.ListTodosRequest]) (*connect.ServerStreamForClient[todolistpb.ListTodosResponse], error)
+	// the changes of the todos are streamed as they happen until the client cancels the stream,
+	// return OUT_OF_RANGE if the events after the resume token are not kept anymore
+	WatchTodos(context.Context, *connect.Request[todolistpb.WatchTodosRequest]) (*connect.ServerStreamForClient[todolistpb.WatchTodosResponse], error)
+	// return NOT_FOUND if not found
+	CompleteTodo(context.Context, *connect.Request[todolistpb.CompleteTodoRequest]) (*connect.Response[todolistpb.CompleteTodoResponse], error)
+	// return NOT_FOUND if not found
+	ReopenTodo(context.Context, *connect.Request[todolistpb.ReopenTodoRequest]) (*connect.Response[todolistpb.ReopenTodoResponse], error)
+	// the revisions of the todo are streamed one page at a time, the newest first
+	GetTodoHistory(context.Context, *connect.Request[todolistpb.GetTodoHistoryRequest]) (*connect.ServerStreamForClient[todolistpb.GetTodoHistoryResponse], error)
+	// the fields of the todo are set as they were after the revision, return NOT_FOUND if the todo
+	// or the revision is not found, ABORTED if the version moved
+	RevertTodo(context.Context, *connect.Request[todolistpb.RevertTodoRequest]) (*connect.Response[todolistpb.RevertTodoResponse], error)
+	// the todo is moved back from the trash, return NOT_FOUND if it is not in the trash
+	RestoreTodo(context.Context, *connect.Request[todolistpb.RestoreTodoRequest]) (*connect.Response[todolistpb.RestoreTodoResponse], error)
+	// the todos of the trash are streamed, the last deleted first
+	ListTrash(context.Context, *connect.Request[todolistpb.ListTrashRequest]) (*connect.ServerStreamForClient[todolistpb.ListTrashResponse], error)
+	// the todos of the trash are deleted permanently
+	EmptyTrash(context.Context, *connect.Request[todolistpb.EmptyTrashRequest]) (*connect.Response[todolistpb.EmptyTrashResponse], error)
+	CreateTodoList(context.Context, *connect.Request[todolistpb.CreateTodoListRequest]) (*connect.Response[todolistpb.CreateTodoListResponse], error)
+	// return NOT_FOUND if not found
+	ReadTodoList(context.Context, *connect.Request[todolistpb.ReadTodoListRequest]) (*connect.Response[todolistpb.ReadTodoListResponse], error)
+	// return NOT_FOUND if not found
+	UpdateTodoList(context.Context, *connect.Request[todolistpb.UpdateTodoListRequest]) (*connect.Response[todolistpb.UpdateTodoListResponse], error)
+	// return NOT_FOUND if not found, the todos deleted with the list are moved to the trash
+	DeleteTodoList(context.Context, *connect.Request[todolistpb.DeleteTodoListRequest]) (*connect.Response[todolistpb.DeleteTodoListResponse], error)
+	// the lists are streamed, over HTTP as one JSON object per line with the response in the result field
+	ListTodoLists(context.Context, *connect.Request[todolistpb.ListTodoListsRequest]) (*connect.ServerStreamForClient[todolistpb.ListTodoListsResponse], error)
+	// the list is shared with the subject or its role is changed, return NOT_FOUND if the list is not found,
+	// PERMISSION_DENIED if the caller is not an owner of the list
+	ShareList(context.Context, *connect.Request[todolistpb.ShareListRequest]) (*connect.Response[todolistpb.ShareListResponse], error)
+	// the subject is removed from the members of the list, the members can remove themselves,
+	// return NOT_FOUND if the subject is not a member, PERMISSION_DENIED if the caller is not an owner of the list
+	UnshareList(context.Context, *connect.Request[todolistpb.UnshareListRequest]) (*connect.Response[todolistpb.UnshareListResponse], error)
+	// the members of the list are streamed, the owner of the list first, return NOT_FOUND if the list is not found
+	ListMembers(context.Context, *connect.Request[todolistpb.ListMembersRequest]) (*connect.ServerStreamForClient[todolistpb.ListMembersResponse], error)
+	CreateWebhook(context.Context, *connect.Request[todolistpb.CreateWebhookRequest]) (*connect.Response[todolistpb.CreateWebhookResponse], error)
+	// return NOT_FOUND if not found
+	ReadWebhook(context.Context, *connect.Request[todolistpb.ReadWebhookRequest]) (*connect.Response[todolistpb.ReadWebhookResponse], error)
+	// return NOT_FOUND if not found, a disabled webhook is enabled again by setting disabled to false
+	UpdateWebhook(context.Context, *connect.Request[todolistpb.UpdateWebhookRequest]) (*connect.Response[todolistpb.UpdateWebhookResponse], error)
+	// return NOT_FOUND if not found, the deliveries of the webhook are deleted too
+	DeleteWebhook(context.Context, *connect.Request[todolistpb.DeleteWebhookRequest]) (*connect.Response[todolistpb.DeleteWebhookResponse], error)
+	// the webhooks are streamed, over HTTP as one JSON object per line with the response in the result field
+	ListWebhooks(context.Context, *connect.Request[todolistpb.ListWebhooksRequest]) (*connect.ServerStreamForClient[todolistpb.ListWebhooksResponse], error)
+	// the attempts of the deliveries are streamed from the newest, return NOT_FOUND if the webhook is not found
+	ListWebhookDeliveries(context.Context, *connect.Request[todolistpb.ListWebhookDeliveriesRequest]) (*connect.ServerStreamForClient[todolistpb.ListWebhookDeliveriesResponse], error)
+}
+
+// NewTodoListServiceClient constructs a client for the todolist.TodoListService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTodoListServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TodoListServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	todoListServiceMethods := todolistpb.File_todolistpb_todolist_proto.Services().ByName("TodoListService").Methods()
+	return &todoListServiceClient{
+		createTodo: connect.NewClient[todolistpb.CreateTodoRequest, todolistpb.CreateTodoResponse](
+			httpClient,
+			baseURL+TodoListServiceCreateTodoProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("CreateTodo")),
+			connect.WithClientOptions(opts...),
+		),
+		readTodo: connect.NewClient[todolistpb.ReadTodoRequest, todolistpb.ReadTodoResponse](
+			httpClient,
+			baseURL+TodoListServiceReadTodoProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ReadTodo")),
+			connect.WithClientOptions(opts...),
+		),
+		updateTodo: connect.NewClient[todolistpb.UpdateTodoRequest, todolistpb.UpdateTodoResponse](
+			httpClient,
+			baseURL+TodoListServiceUpdateTodoProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("UpdateTodo")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTodo: connect.NewClient[todolistpb.DeleteTodoRequest, todolistpb.DeleteTodoResponse](
+			httpClient,
+			baseURL+TodoListServiceDeleteTodoProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("DeleteTodo")),
+			connect.WithClientOptions(opts...),
+		),
+		listTodos: connect.NewClient[todolistpb.ListTodosRequest, todolistpb.ListTodosResponse](
+			httpClient,
+			baseURL+TodoListServiceListTodosProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ListTodos")),
+			connect.WithClientOptions(opts...),
+		),
+		watchTodos: connect.NewClient[todolistpb.WatchTodosRequest, todolistpb.WatchTodosResponse](
+			httpClient,
+			baseURL+TodoListServiceWatchTodosProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("WatchTodos")),
+			connect.WithClientOptions(opts...),
+		),
+		completeTodo: connect.NewClient[todolistpb.CompleteTodoRequest, todolistpb.CompleteTodoResponse](
+			httpClient,
+			baseURL+TodoListServiceCompleteTodoProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("CompleteTodo")),
+			connect.WithClientOptions(opts...),
+		),
+		reopenTodo: connect.NewClient[todolistpb.ReopenTodoRequest, todolistpb.ReopenTodoResponse](
+			httpClient,
+			baseURL+TodoListServiceReopenTodoProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ReopenTodo")),
+			connect.WithClientOptions(opts...),
+		),
+		getTodoHistory: connect.NewClient[todolistpb.GetTodoHistoryRequest, todolistpb.GetTodoHistoryResponse](
+			httpClient,
+			baseURL+TodoListServiceGetTodoHistoryProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("GetTodoHistory")),
+			connect.WithClientOptions(opts...),
+		),
+		revertTodo: connect.NewClient[todolistpb.RevertTodoRequest, todolistpb.RevertTodoResponse](
+			httpClient,
+			baseURL+TodoListServiceRevertTodoProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("RevertTodo")),
+			connect.WithClientOptions(opts...),
+		),
+		restoreTodo: connect.NewClient[todolistpb.RestoreTodoRequest, todolistpb.RestoreTodoResponse](
+			httpClient,
+			baseURL+TodoListServiceRestoreTodoProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("RestoreTodo")),
+			connect.WithClientOptions(opts...),
+		),
+		listTrash: connect.NewClient[todolistpb.ListTrashRequest, todolistpb.ListTrashResponse](
+			httpClient,
+			baseURL+TodoListServiceListTrashProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ListTrash")),
+			connect.WithClientOptions(opts...),
+		),
+		emptyTrash: connect.NewClient[todolistpb.EmptyTrashRequest, todolistpb.EmptyTrashResponse](
+			httpClient,
+			baseURL+TodoListServiceEmptyTrashProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("EmptyTrash")),
+			connect.WithClientOptions(opts...),
+		),
+		createTodoList: connect.NewClient[todolistpb.CreateTodoListRequest, todolistpb.CreateTodoListResponse](
+			httpClient,
+			baseURL+TodoListServiceCreateTodoListProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("CreateTodoList")),
+			connect.WithClientOptions(opts...),
+		),
+		readTodoList: connect.NewClient[todolistpb.ReadTodoListRequest, todolistpb.ReadTodoListResponse](
+			httpClient,
+			baseURL+TodoListServiceReadTodoListProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ReadTodoList")),
+			connect.WithClientOptions(opts...),
+		),
+		updateTodoList: connect.NewClient[todolistpb.UpdateTodoListRequest, todolistpb.UpdateTodoListResponse](
+			httpClient,
+			baseURL+TodoListServiceUpdateTodoListProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("UpdateTodoList")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTodoList: connect.NewClient[todolistpb.DeleteTodoListRequest, todolistpb.DeleteTodoListResponse](
+			httpClient,
+			baseURL+TodoListServiceDeleteTodoListProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("DeleteTodoList")),
+			connect.WithClientOptions(opts...),
+		),
+		listTodoLists: connect.NewClient[todolistpb.ListTodoListsRequest, todolistpb.ListTodoListsResponse](
+			httpClient,
+			baseURL+TodoListServiceListTodoListsProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ListTodoLists")),
+			connect.WithClientOptions(opts...),
+		),
+		shareList: connect.NewClient[todolistpb.ShareListRequest, todolistpb.ShareListResponse](
+			httpClient,
+			baseURL+TodoListServiceShareListProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ShareList")),
+			connect.WithClientOptions(opts...),
+		),
+		unshareList: connect.NewClient[todolistpb.UnshareListRequest, todolistpb.UnshareListResponse](
+			httpClient,
+			baseURL+TodoListServiceUnshareListProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("UnshareList")),
+			connect.WithClientOptions(opts...),
+		),
+		listMembers: connect.NewClient[todolistpb.ListMembersRequest, todolistpb.ListMembersResponse](
+			httpClient,
+			baseURL+TodoListServiceListMembersProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ListMembers")),
+			connect.WithClientOptions(opts...),
+		),
+		createWebhook: connect.NewClient[todolistpb.CreateWebhookRequest, todolistpb.CreateWebhookResponse](
+			httpClient,
+			baseURL+TodoListServiceCreateWebhookProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("CreateWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		readWebhook: connect.NewClient[todolistpb.ReadWebhookRequest, todolistpb.ReadWebhookResponse](
+			httpClient,
+			baseURL+TodoListServiceReadWebhookProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ReadWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		updateWebhook: connect.NewClient[todolistpb.UpdateWebhookRequest, todolistpb.UpdateWebhookResponse](
+			httpClient,
+			baseURL+TodoListServiceUpdateWebhookProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("UpdateWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWebhook: connect.NewClient[todolistpb.DeleteWebhookRequest, todolistpb.DeleteWebhookResponse](
+			httpClient,
+			baseURL+TodoListServiceDeleteWebhookProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("DeleteWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhooks: connect.NewClient[todolistpb.ListWebhooksRequest, todolistpb.ListWebhooksResponse](
+			httpClient,
+			baseURL+TodoListServiceListWebhooksProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ListWebhooks")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhookDeliveries: connect.NewClient[todolistpb.ListWebhookDeliveriesRequest, todolistpb.ListWebhookDeliveriesResponse](
+			httpClient,
+			baseURL+TodoListServiceListWebhookDeliveriesProcedure,
+			connect.WithSchema(todoListServiceMethods.ByName("ListWebhookDeliveries")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// todoListServiceClient implements TodoListServiceClient.
+type todoListServiceClient struct {
+	createTodo            *connect.Client[todolistpb.CreateTodoRequest, todolistpb.CreateTodoResponse]
+	readTodo              *connect.Client[todolistpb.ReadTodoRequest, todolistpb.ReadTodoResponse]
+	updateTodo            *connect.Client[todolistpb.UpdateTodoRequest, todolistpb.UpdateTodoResponse]
+	deleteTodo            *connect.Client[todolistpb.DeleteTodoRequest, todolistpb.DeleteTodoResponse]
+	listTodos             *connect.Client[todolistpb.ListTodosRequest, todolistpb.ListTodosResponse]
+	watchTodos            *connect.Client[todolistpb.WatchTodosRequest, todolistpb.WatchTodosResponse]
+	completeTodo          *connect.Client[todolistpb.CompleteTodoRequest, todolistpb.CompleteTodoResponse]
+	reopenTodo            *connect.Client[todolistpb.ReopenTodoRequest, todolistpb.ReopenTodoResponse]
+	getTodoHistory        *connect.Client[todolistpb.GetTodoHistoryRequest, todolistpb.GetTodoHistoryResponse]
+	revertTodo            *connect.Client[todolistpb.RevertTodoRequest, todolistpb.RevertTodoResponse]
+	restoreTodo           *connect.Client[todolistpb.RestoreTodoRequest, todolistpb.RestoreTodoResponse]
+	listTrash             *connect.Client[todolistpb.ListTrashRequest, todolistpb.ListTrashResponse]
+	emptyTrash            *connect.Client[todolistpb.EmptyTrashRequest, todolistpb.EmptyTrashResponse]
+	createTodoList        *connect.Client[todolistpb.CreateTodoListRequest, todolistpb.CreateTodoListResponse]
+	readTodoList          *connect.Client[todolistpb.ReadTodoListRequest, todolistpb.ReadTodoListResponse]
+	updateTodoList        *connect.Client[todolistpb.UpdateTodoListRequest, todolistpb.UpdateTodoListResponse]
+	deleteTodoList        *connect.Client[todolistpb.DeleteTodoListRequest, todolistpb.DeleteTodoListResponse]
+	listTodoLists         *connect.Client[todolistpb.ListTodoListsRequest, todolistpb.ListTodoListsResponse]
+	shareList             *connect.Client[todolistpb.ShareListRequest, todolistpb.ShareListResponse]
+	unshareList           *connect.Client[todolistpb.UnshareListRequest, todolistpb.UnshareListResponse]
+	listMembers           *connect.Client[todolistpb.ListMembersRequest, todolistpb.ListMembersResponse]
+	createWebhook         *connect.Client[todolistpb.CreateWebhookRequest, todolistpb.CreateWebhookResponse]
+	readWebhook           *connect.Client[todolistpb.ReadWebhookRequest, todolistpb.ReadWebhookResponse]
+	updateWebhook         *connect.Client[todolistpb.UpdateWebhookRequest, todolistpb.UpdateWebhookResponse]
+	deleteWebhook         *connect.Client[todolistpb.DeleteWebhookRequest, todolistpb.DeleteWebhookResponse]
+	listWebhooks          *connect.Client[todolistpb.ListWebhooksRequest, todolistpb.ListWebhooksResponse]
+	listWebhookDeliveries *connect.Client[todolistpb.ListWebhookDeliveriesRequest, todolistpb.ListWebhookDeliveriesResponse]
+}
+
+// CreateTodo calls todolist.TodoListService.CreateTodo.
+func (c *todoListServiceClient) CreateTodo(ctx context.Context, req *connect.Request[todolistpb.CreateTodoRequest]) (*connect.Response[todolistpb.CreateTodoResponse], error) {
+	return c.createTodo.CallUnary(ctx, req)
+}
+
+// ReadTodo calls todolist.TodoListService.ReadTodo.
+func (c *todoListServiceClient) ReadTodo(ctx context.Context, req *connect.Request[todolistpb.ReadTodoRequest]) (*connect.Response[todolistpb.ReadTodoResponse], error) {
+	return c.readTodo.CallUnary(ctx, req)
+}
+
+// UpdateTodo calls todolist.TodoListService.UpdateTodo.
+func (c *todoListServiceClient) UpdateTodo(ctx context.Context, req *connect.Request[todolistpb.UpdateTodoRequest]) (*connect.Response[todolistpb.UpdateTodoResponse], error) {
+	return c.updateTodo.CallUnary(ctx, req)
+}
+
+// DeleteTodo calls todolist.TodoListService.DeleteTodo.
+func (c *todoListServiceClient) DeleteTodo(ctx context.Context, req *connect.Request[todolistpb.DeleteTodoRequest]) (*connect.Response[todolistpb.DeleteTodoResponse], error) {
+	return c.deleteTodo.CallUnary(ctx, req)
+}
+
+// ListTodos calls todolist.TodoListService.ListTodos.
+func (c *todoListServiceClient) ListTodos(ctx context.Context, req *connect.Request[todolistpb.ListTodosRequest]) (*connect.ServerStreamForClient[todolistpb.ListTodosResponse], error) {
+	return c.listTodos.CallServerStream(ctx, req)
+}
+
+// WatchTodos calls todolist.TodoListService.WatchTodos.
+func (c *todoListServiceClient) WatchTodos(ctx context.Context, req *connect.Request[todolistpb.WatchTodosRequest]) (*connect.ServerStreamForClient[todolistpb.WatchTodosResponse], error) {
+	return c.watchTodos.CallServerStream(ctx, req)
+}
+
+// CompleteTodo calls todolist.TodoListService.CompleteTodo.
+func (c *todoListServiceClient) CompleteTodo(ctx context.Context, req *connect.Request[todolistpb.CompleteTodoRequest]) (*connect.Response[todolistpb.CompleteTodoResponse], error) {
+	return c.completeTodo.CallUnary(ctx, req)
+}
+
+// ReopenTodo calls todolist.TodoListService.ReopenTodo.
+func (c *todoListServiceClient) ReopenTodo(ctx context.Context, req *connect.Request[todolistpb.ReopenTodoRequest]) (*connect.Response[todolistpb.ReopenTodoResponse], error) {
+	return c.reopenTodo.CallUnary(ctx, req)
+}
+
+// GetTodoHistory calls todolist.TodoListService.GetTodoHistory.
+func (c *todoListServiceClient) GetTodoHistory(ctx context.Context, req *connect.Request[todolistpb.GetTodoHistoryRequest]) (*connect.ServerStreamForClient[todolistpb.GetTodoHistoryResponse], error) {
+	return c.getTodoHistory.CallServerStream(ctx, req)
+}
+
+// RevertTodo calls todolist.TodoListService.RevertTodo.
+func (c *todoListServiceClient) RevertTodo(ctx context.Context, req *connect.Request[todolistpb.RevertTodoRequest]) (*connect.Response[todolistpb.RevertTodoResponse], error) {
+	return c.revertTodo.CallUnary(ctx, req)
+}
+
+// RestoreTodo calls todolist.TodoListService.RestoreTodo.
+func (c *todoListServiceClient) RestoreTodo(ctx context.Context, req *connect.Request[todolistpb.RestoreTodoRequest]) (*connect.Response[todolistpb.RestoreTodoResponse], error) {
+	return c.restoreTodo.CallUnary(ctx, req)
+}
+
+// ListTrash calls todolist.TodoListService.ListTrash.
+func (c *todoListServiceClient) ListTrash(ctx context.Context, req *connect.Request[todolistpb.ListTrashRequest]) (*connect.ServerStreamForClient[todolistpb.ListTrashResponse], error) {
+	return c.listTrash.CallServerStream(ctx, req)
+}
+
+// EmptyTrash calls todolist.TodoListService.EmptyTrash.
+func (c *todoListServiceClient) EmptyTrash(ctx context.Context, req *connect.Request[todolistpb.EmptyTrashRequest]) (*connect.Response[todolistpb.EmptyTrashResponse], error) {
+	return c.emptyTrash.CallUnary(ctx, req)
+}
+
+// CreateTodoList calls todolist.TodoListService.CreateTodoList.
+func (c *todoListServiceClient) CreateTodoList(ctx context.Context, req *connect.Request[todolistpb.CreateTodoListRequest]) (*connect.Response[todolistpb.CreateTodoListResponse], error) {
+	return c.createTodoList.CallUnary(ctx, req)
+}
+
+// ReadTodoList calls todolist.TodoListService.ReadTodoList.
+func (c *todoListServiceClient) ReadTodoList(ctx context.Context, req *connect.Request[todolistpb.ReadTodoListRequest]) (*connect.Response[todolistpb.ReadTodoListResponse], error) {
+	return c.readTodoList.CallUnary(ctx, req)
+}
+
+// UpdateTodoList calls todolist.TodoListService.UpdateTodoList.
+func (c *todoListServiceClient) UpdateTodoList(ctx context.Context, req *connect.Request[todolistpb.UpdateTodoListRequest]) (*connect.Response[todolistpb.UpdateTodoListResponse], error) {
+	return c.updateTodoList.CallUnary(ctx, req)
+}
+
+// DeleteTodoList calls todolist.TodoListService.DeleteTodoList.
+func (c *todoListServiceClient) DeleteTodoList(ctx context.Context, req *connect.Request[todolistpb.DeleteTodoListRequest]) (*connect.Response[todolistpb.DeleteTodoListResponse], error) {
+	return c.deleteTodoList.CallUnary(ctx, req)
+}
+
+// ListTodoLists calls todolist.TodoListService.ListTodoLists.
+func (c *todoListServiceClient) ListTodoLists(ctx context.Context, req *connect.Request[todolistpb.ListTodoListsRequest]) (*connect.ServerStreamForClient[todolistpb.ListTodoListsResponse], error) {
+	return c.listTodoLists.CallServerStream(ctx, req)
+}
+
+// ShareList calls todolist.TodoListService.ShareList.
+func (c *todoListServiceClient) ShareList(ctx context.Context, req *connect.Request[todolistpb.ShareListRequest]) (*connect.Response[todolistpb.ShareListResponse], error) {
+	return c.shareList.CallUnary(ctx, req)
+}
+
+// UnshareList calls todolist.TodoListService.UnshareList.
+func (c *todoListServiceClient) UnshareList(ctx context.Context, req *connect.Request[todolistpb.UnshareListRequest]) (*connect.Response[todolistpb.UnshareListResponse], error) {
+	return c.unshareList.CallUnary(ctx, req)
+}
+
+// ListMembers calls todolist.TodoListService.ListMembers.
+func (c *todoListServiceClient) ListMembers(ctx context.Context, req *connect.Request[todolistpb.ListMembersRequest]) (*connect.ServerStreamForClient[todolistpb.ListMembersResponse], error) {
+	return c.listMembers.CallServerStream(ctx, req)
+}
+
+// CreateWebhook calls todolist.TodoListService.CreateWebhook.
+func (c *todoListServiceClient) CreateWebhook(ctx context.Context, req *connect.Request[todolistpb.CreateWebhookRequest]) (*connect.Response[todolistpb.CreateWebhookResponse], error) {
+	return c.createWebhook.CallUnary(ctx, req)
+}
+
+// ReadWebhook calls todolist.TodoListService.ReadWebhook.
+func (c *todoListServiceClient) ReadWebhook(ctx context.Context, req *connect.Request[todolistpb.ReadWebhookRequest]) (*connect.Response[todolistpb.ReadWebhookResponse], error) {
+	return c.readWebhook.CallUnary(ctx, req)
+}
+
+// UpdateWebhook calls todolist.TodoListService.UpdateWebhook.
+func (c *todoListServiceClient) UpdateWebhook(ctx context.Context, req *connect.Request[todolistpb.UpdateWebhookRequest]) (*connect.Response[todolistpb.UpdateWebhookResponse], error) {
+	return c.updateWebhook.CallUnary(ctx, req)
+}
+
+// DeleteWebhook calls todolist.TodoListService.DeleteWebhook.
+func (c *todoListServiceClient) DeleteWebhook(ctx context.Context, req *connect.Request[todolistpb.DeleteWebhookRequest]) (*connect.Response[todolistpb.DeleteWebhookResponse], error) {
+	return c.deleteWebhook.CallUnary(ctx, req)
+}
+
+// ListWebhooks calls todolist.TodoListService.ListWebhooks.
+func (c *todoListServiceClient) ListWebhooks(ctx context.Context, req *connect.Request[todolistpb.ListWebhooksRequest]) (*connect.ServerStreamForClient[todolistpb.ListWebhooksResponse], error) {
+	return c.listWebhooks.CallServerStream(ctx, req)
+}
+
+// ListWebhookDeliveries calls todolist.TodoListService.ListWebhookDeliveries.
+func (c *todoListServiceClient) ListWebhookDeliveries(ctx context.Context, req *connect.Request[todolistpb.ListWebhookDeliveriesRequest]) (*connect.ServerStreamForClient[todolistpb.ListWebhookDeliveriesResponse], error) {
+	return c.listWebhookDeliveries.CallServerStream(ctx, req)
+}
+
+// TodoListServiceHandler is an implementation of the todolist.TodoListService service.
+type TodoListServiceHandler interface {
+	CreateTodo(context.Context, *connect.Request[todolistpb.CreateTodoRequest]) (*connect.Response[todolistpb.CreateTodoResponse], error)
+	// return NOT_FOUND if not found
+	ReadTodo(context.Context, *connect.Request[todolistpb.ReadTodoRequest]) (*connect.Response[todolistpb.ReadTodoResponse], error)
+	// return NOT_FOUND if not found, ABORTED if the version moved
+	UpdateTodo(context.Context, *connect.Request[todolistpb.UpdateTodoRequest]) (*connect.Response[todolistpb.UpdateTodoResponse], error)
+	// the todo is moved to the trash, return NOT_FOUND if not found, ABORTED if the version moved
+	DeleteTodo(context.Context, *connect.Request[todolistpb.DeleteTodoRequest]) (*connect.Response[todolistpb.DeleteTodoResponse], error)
+	// the todos are streamed one page at a time, over HTTP as one JSON object per line with the response in the result field
+	ListTodos(context.Context, *connect.Request[todolistpb.ListTodosRequest], *connect.ServerStream[todolistpb.ListTodosResponse]) error
+	// the changes of the todos are streamed as they happen until the client cancels the stream,
+	// return OUT_OF_RANGE if the events after the resume token are not kept anymore
+	WatchTodos(context.Context, *connect.Request[todolistpb.WatchTodosRequest], *connect.ServerStream[todolistpb.WatchTodosResponse]) error
+	// return NOT_FOUND if not found
+	CompleteTodo(context.Context, *connect.Request[todolistpb.CompleteTodoRequest]) (*connect.Response[todolistpb.CompleteTodoResponse], error)
+	// return NOT_FOUND if not found
+	ReopenTodo(context.Context, *connect.Request[todolistpb.ReopenTodoRequest]) (*connect.Response[todolistpb.ReopenTodoResponse], error)
+	// the revisions of the todo are streamed one page at a time, the newest first
+	GetTodoHistory(context.Context, *connect.Request[todolistpb.GetTodoHistoryRequest], *connect.ServerStream[todolistpb.GetTodoHistoryResponse]) error
+	// the fields of the todo are set as they were after the revision, return NOT_FOUND if the todo
+	// or the revision is not found, ABORTED if the version moved
+	RevertTodo(context.Context, *connect.Request[todolistpb.RevertTodoRequest]) (*connect.Response[todolistpb.RevertTodoResponse], error)
+	// the todo is moved back from the trash, return NOT_FOUND if it is not in the trash
+	RestoreTodo(context.Context, *connect.Request[todolistpb.RestoreTodoRequest]) (*connect.Response[todolistpb.RestoreTodoResponse], error)
+	// the todos of the trash are streamed, the last deleted first
+	ListTrash(context.Context, *connect.Request[todolistpb.ListTrashRequest], *connect.ServerStream[todolistpb.ListTrashResponse]) error
+	// the todos of the trash are deleted permanently
+	EmptyTrash(context.Context, *connect.Request[todolistpb.EmptyTrashRequest]) (*connect.Response[todolistpb.EmptyTrashResponse], error)
+	CreateTodoList(context.Context, *connect.Request[todolistpb.CreateTodoListRequest]) (*connect.Response[todolistpb.CreateTodoListResponse], error)
+	// return NOT_FOUND if not found
+	ReadTodoList(context.Context, *connect.Request[todolistpb.ReadTodoListRequest]) (*connect.Response[todolistpb.ReadTodoListResponse], error)
+	// return NOT_FOUND if not found
+	UpdateTodoList(context.Context, *connect.Request[todolistpb.UpdateTodoListRequest]) (*connect.Response[todolistpb.UpdateTodoListResponse], error)
+	// return NOT_FOUND if not found, the todos deleted with the list are moved to the trash
+	DeleteTodoList(context.Context, *connect.Request[todolistpb.DeleteTodoListRequest]) (*connect.Response[todolistpb.DeleteTodoListResponse], error)
+	// the lists are streamed, over HTTP as one JSON object per line with the response in the result field
+	ListTodoLists(context.Context, *connect.Request[todolistpb.ListTodoListsRequest], *connect.ServerStream[todolistpb.ListTodoListsResponse]) error
+	// the list is shared with the subject or its role is changed, return NOT_FOUND if the list is not found,
+	// PERMISSION_DENIED if the caller is not an owner of the list
+	ShareList(context.Context, *connect.Request[todolistpb.ShareListRequest]) (*connect.Response[todolistpb.ShareListResponse], error)
+	// the subject is removed from the members of the list, the members can remove themselves,
+	// return NOT_FOUND if the subject is not a member, PERMISSION_DENIED if the caller is not an owner of the list
+	UnshareList(context.Context, *connect.Request[todolistpb.UnshareListRequest]) (*connect.Response[todolistpb.UnshareListResponse], error)
+	// the members of the list are streamed, the owner of the list first, return NOT_FOUND if the list is not found
+	ListMembers(context.Context, *connect.Request[todolistpb.ListMembersRequest], *connect.ServerStream[todolistpb.ListMembersResponse]) error
+	CreateWebhook(context.Context, *connect.Request[todolistpb.CreateWebhookRequest]) (*connect.Response[todolistpb.CreateWebhookResponse], error)
+	// return NOT_FOUND if not found
+	ReadWebhook(context.Context, *connect.Request[todolistpb.ReadWebhookRequest]) (*connect.Response[todolistpb.ReadWebhookResponse], error)
+	// return NOT_FOUND if not found, a disabled webhook is enabled again by setting disabled to false
+	UpdateWebhook(context.Context, *connect.Request[todolistpb.UpdateWebhookRequest]) (*connect.Response[todolistpb.UpdateWebhookResponse], error)
+	// return NOT_FOUND if not found, the deliveries of the webhook are deleted too
+	DeleteWebhook(context.Context, *connect.Request[todolistpb.DeleteWebhookRequest]) (*connect.Response[todolistpb.DeleteWebhookResponse], error)
+	// the webhooks are streamed, over HTTP as one JSON object per line with the response in the result field
+	ListWebhooks(context.Context, *connect.Request[todolistpb.ListWebhooksRequest], *connect.ServerStream[todolistpb.ListWebhooksResponse]) error
+	// the attempts of the deliveries are streamed from the newest, return NOT_FOUND if the webhook is not found
+	ListWebhookDeliveries(context.Context, *connect.Request[todolistpb.ListWebhookDeliveriesRequest], *connect.ServerStream[todolistpb.ListWebhookDeliveriesResponse]) error
+}
+
+// NewTodoListServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTodoListServiceHandler(svc TodoListServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	todoListServiceMethods := todolistpb.File_todolistpb_todolist_proto.Services().ByName("TodoListService").Methods()
+	todoListServiceCreateTodoHandler := connect.NewUnaryHandler(
+		TodoListServiceCreateTodoProcedure,
+		svc.CreateTodo,
+		connect.WithSchema(todoListServiceMethods.ByName("CreateTodo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceReadTodoHandler := connect.NewUnaryHandler(
+		TodoListServiceReadTodoProcedure,
+		svc.ReadTodo,
+		connect.WithSchema(todoListServiceMethods.ByName("ReadTodo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceUpdateTodoHandler := connect.NewUnaryHandler(
+		TodoListServiceUpdateTodoProcedure,
+		svc.UpdateTodo,
+		connect.WithSchema(todoListServiceMethods.ByName("UpdateTodo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceDeleteTodoHandler := connect.NewUnaryHandler(
+		TodoListServiceDeleteTodoProcedure,
+		svc.DeleteTodo,
+		connect.WithSchema(todoListServiceMethods.ByName("DeleteTodo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceListTodosHandler := connect.NewServerStreamHandler(
+		TodoListServiceListTodosProcedure,
+		svc.ListTodos,
+		connect.WithSchema(todoListServiceMethods.ByName("ListTodos")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceWatchTodosHandler := connect.NewServerStreamHandler(
+		TodoListServiceWatchTodosProcedure,
+		svc.WatchTodos,
+		connect.WithSchema(todoListServiceMethods.ByName("WatchTodos")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceCompleteTodoHandler := connect.NewUnaryHandler(
+		TodoListServiceCompleteTodoProcedure,
+		svc.CompleteTodo,
+		connect.WithSchema(todoListServiceMethods.ByName("CompleteTodo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceReopenTodoHandler := connect.NewUnaryHandler(
+		TodoListServiceReopenTodoProcedure,
+		svc.ReopenTodo,
+		connect.WithSchema(todoListServiceMethods.ByName("ReopenTodo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceGetTodoHistoryHandler := connect.NewServerStreamHandler(
+		TodoListServiceGetTodoHistoryProcedure,
+		svc.GetTodoHistory,
+		connect.WithSchema(todoListServiceMethods.ByName("GetTodoHistory")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceRevertTodoHandler := connect.NewUnaryHandler(
+		TodoListServiceRevertTodoProcedure,
+		svc.RevertTodo,
+		connect.WithSchema(todoListServiceMethods.ByName("RevertTodo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceRestoreTodoHandler := connect.NewUnaryHandler(
+		TodoListServiceRestoreTodoProcedure,
+		svc.RestoreTodo,
+		connect.WithSchema(todoListServiceMethods.ByName("RestoreTodo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceListTrashHandler := connect.NewServerStreamHandler(
+		TodoListServiceListTrashProcedure,
+		svc.ListTrash,
+		connect.WithSchema(todoListServiceMethods.ByName("ListTrash")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceEmptyTrashHandler := connect.NewUnaryHandler(
+		TodoListServiceEmptyTrashProcedure,
+		svc.EmptyTrash,
+		connect.WithSchema(todoListServiceMethods.ByName("EmptyTrash")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceCreateTodoListHandler := connect.NewUnaryHandler(
+		TodoListServiceCreateTodoListProcedure,
+		svc.CreateTodoList,
+		connect.WithSchema(todoListServiceMethods.ByName("CreateTodoList")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceReadTodoListHandler := connect.NewUnaryHandler(
+		TodoListServiceReadTodoListProcedure,
+		svc.ReadTodoList,
+		connect.WithSchema(todoListServiceMethods.ByName("ReadTodoList")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceUpdateTodoListHandler := connect.NewUnaryHandler(
+		TodoListServiceUpdateTodoListProcedure,
+		svc.UpdateTodoList,
+		connect.WithSchema(todoListServiceMethods.ByName("UpdateTodoList")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceDeleteTodoListHandler := connect.NewUnaryHandler(
+		TodoListServiceDeleteTodoListProcedure,
+		svc.DeleteTodoList,
+		connect.WithSchema(todoListServiceMethods.ByName("DeleteTodoList")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceListTodoListsHandler := connect.NewServerStreamHandler(
+		TodoListServiceListTodoListsProcedure,
+		svc.ListTodoLists,
+		connect.WithSchema(todoListServiceMethods.ByName("ListTodoLists")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceShareListHandler := connect.NewUnaryHandler(
+		TodoListServiceShareListProcedure,
+		svc.ShareList,
+		connect.WithSchema(todoListServiceMethods.ByName("ShareList")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceUnshareListHandler := connect.NewUnaryHandler(
+		TodoListServiceUnshareListProcedure,
+		svc.UnshareList,
+		connect.WithSchema(todoListServiceMethods.ByName("UnshareList")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceListMembersHandler := connect.NewServerStreamHandler(
+		TodoListServiceListMembersProcedure,
+		svc.ListMembers,
+		connect.WithSchema(todoListServiceMethods.ByName("ListMembers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceCreateWebhookHandler := connect.NewUnaryHandler(
+		TodoListServiceCreateWebhookProcedure,
+		svc.CreateWebhook,
+		connect.WithSchema(todoListServiceMethods.ByName("CreateWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceReadWebhookHandler := connect.NewUnaryHandler(
+		TodoListServiceReadWebhookProcedure,
+		svc.ReadWebhook,
+		connect.WithSchema(todoListServiceMethods.ByName("ReadWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceUpdateWebhookHandler := connect.NewUnaryHandler(
+		TodoListServiceUpdateWebhookProcedure,
+		svc.UpdateWebhook,
+		connect.WithSchema(todoListServiceMethods.ByName("UpdateWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceDeleteWebhookHandler := connect.NewUnaryHandler(
+		TodoListServiceDeleteWebhookProcedure,
+		svc.DeleteWebhook,
+		connect.WithSchema(todoListServiceMethods.ByName("DeleteWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceListWebhooksHandler := connect.NewServerStreamHandler(
+		TodoListServiceListWebhooksProcedure,
+		svc.ListWebhooks,
+		connect.WithSchema(todoListServiceMethods.ByName("ListWebhooks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	todoListServiceListWebhookDeliveriesHandler := connect.NewServerStreamHandler(
+		TodoListServiceListWebhookDeliveriesProcedure,
+		svc.ListWebhookDeliveries,
+		connect.WithSchema(todoListServiceMethods.ByName("ListWebhookDeliveries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/todolist.TodoListService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TodoListServiceCreateTodoProcedure:
+			todoListServiceCreateTodoHandler.ServeHTTP(w, r)
+		case TodoListServiceReadTodoProcedure:
+			todoListServiceReadTodoHandler.ServeHTTP(w, r)
+		case TodoListServiceUpdateTodoProcedure:
+			todoListServiceUpdateTodoHandler.ServeHTTP(w, r)
+		case TodoListServiceDeleteTodoProcedure:
+			todoListServiceDeleteTodoHandler.ServeHTTP(w, r)
+		case TodoListServiceListTodosProcedure:
+			todoListServiceListTodosHandler.ServeHTTP(w, r)
+		case TodoListServiceWatchTodosProcedure:
+			todoListServiceWatchTodosHandler.ServeHTTP(w, r)
+		case TodoListServiceCompleteTodoProcedure:
+			todoListServiceCompleteTodoHandler.ServeHTTP(w, r)
+		case TodoListServiceReopenTodoProcedure:
+			todoListServiceReopenTodoHandler.ServeHTTP(w, r)
+		case TodoListServiceGetTodoHistoryProcedure:
+			todoListServiceGetTodoHistoryHandler.ServeHTTP(w, r)
+		case TodoListServiceRevertTodoProcedure:
+			todoListServiceRevertTodoHandler.ServeHTTP(w, r)
+		case TodoListServiceRestoreTodoProcedure:
+			todoListServiceRestoreTodoHandler.ServeHTTP(w, r)
+		case TodoListServiceListTrashProcedure:
+			todoListServiceListTrashHandler.ServeHTTP(w, r)
+		case TodoListServiceEmptyTrashProcedure:
+			todoListServiceEmptyTrashHandler.ServeHTTP(w, r)
+		case TodoListServiceCreateTodoListProcedure:
+			todoListServiceCreateTodoListHandler.ServeHTTP(w, r)
+		case TodoListServiceReadTodoListProcedure:
+			todoListServiceReadTodoListHandler.ServeHTTP(w, r)
+		case TodoListServiceUpdateTodoListProcedure:
+			todoListServiceUpdateTodoListHandler.ServeHTTP(w, r)
+		case TodoListServiceDeleteTodoListProcedure:
+			todoListServiceDeleteTodoListHandler.ServeHTTP(w, r)
+		case TodoListServiceListTodoListsProcedure:
+			todoListServiceListTodoListsHandler.ServeHTTP(w, r)
+		case TodoListServiceShareListProcedure:
+			todoListServiceShareListHandler.ServeHTTP(w, r)
+		case TodoListServiceUnshareListProcedure:
+			todoListServiceUnshareListHandler.ServeHTTP(w, r)
+		case TodoListServiceListMembersProcedure:
+			todoListServiceListMembersHandler.ServeHTTP(w, r)
+		case TodoListServiceCreateWebhookProcedure:
+			todoListServiceCreateWebhookHandler.ServeHTTP(w, r)
+		case TodoListServiceReadWebhookProcedure:
+			todoListServiceReadWebhookHandler.ServeHTTP(w, r)
+		case TodoListServiceUpdateWebhookProcedure:
+			todoListServiceUpdateWebhookHandler.ServeHTTP(w, r)
+		case TodoListServiceDeleteWebhookProcedure:
+			todoListServiceDeleteWebhookHandler.ServeHTTP(w, r)
+		case TodoListServiceListWebhooksProcedure:
+			todoListServiceListWebhooksHandler.ServeHTTP(w, r)
+		case TodoListServiceListWebhookDeliveriesProcedure:
+			todoListServiceListWebhookDeliveriesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTodoListServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTodoListServiceHandler struct{}
+
+func (UnimplementedTodoListServiceHandler) CreateTodo(context.Context, *connect.Request[todolistpb.CreateTodoRequest]) (*connect.Response[todolistpb.CreateTodoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.CreateTodo is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ReadTodo(context.Context, *connect.Request[todolistpb.ReadTodoRequest]) (*connect.Response[todolistpb.ReadTodoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ReadTodo is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) UpdateTodo(context.Context, *connect.Request[todolistpb.UpdateTodoRequest]) (*connect.Response[todolistpb.UpdateTodoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.UpdateTodo is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) DeleteTodo(context.Context, *connect.Request[todolistpb.DeleteTodoRequest]) (*connect.Response[todolistpb.DeleteTodoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.DeleteTodo is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ListTodos(context.Context, *connect.Request[todolistpb.ListTodosRequest], *connect.ServerStream[todolistpb.ListTodosResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ListTodos is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) WatchTodos(context.Context, *connect.Request[todolistpb.WatchTodosRequest], *connect.ServerStream[todolistpb.WatchTodosResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.WatchTodos is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) CompleteTodo(context.Context, *connect.Request[todolistpb.CompleteTodoRequest]) (*connect.Response[todolistpb.CompleteTodoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.CompleteTodo is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ReopenTodo(context.Context, *connect.Request[todolistpb.ReopenTodoRequest]) (*connect.Response[todolistpb.ReopenTodoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ReopenTodo is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) GetTodoHistory(context.Context, *connect.Request[todolistpb.GetTodoHistoryRequest], *connect.ServerStream[todolistpb.GetTodoHistoryResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.GetTodoHistory is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) RevertTodo(context.Context, *connect.Request[todolistpb.RevertTodoRequest]) (*connect.Response[todolistpb.RevertTodoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.RevertTodo is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) RestoreTodo(context.Context, *connect.Request[todolistpb.RestoreTodoRequest]) (*connect.Response[todolistpb.RestoreTodoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.RestoreTodo is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ListTrash(context.Context, *connect.Request[todolistpb.ListTrashRequest], *connect.ServerStream[todolistpb.ListTrashResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ListTrash is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) EmptyTrash(context.Context, *connect.Request[todolistpb.EmptyTrashRequest]) (*connect.Response[todolistpb.EmptyTrashResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.EmptyTrash is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) CreateTodoList(context.Context, *connect.Request[todolistpb.CreateTodoListRequest]) (*connect.Response[todolistpb.CreateTodoListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.CreateTodoList is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ReadTodoList(context.Context, *connect.Request[todolistpb.ReadTodoListRequest]) (*connect.Response[todolistpb.ReadTodoListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ReadTodoList is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) UpdateTodoList(context.Context, *connect.Request[todolistpb.UpdateTodoListRequest]) (*connect.Response[todolistpb.UpdateTodoListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.UpdateTodoList is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) DeleteTodoList(context.Context, *connect.Request[todolistpb.DeleteTodoListRequest]) (*connect.Response[todolistpb.DeleteTodoListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.DeleteTodoList is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ListTodoLists(context.Context, *connect.Request[todolistpb.ListTodoListsRequest], *connect.ServerStream[todolistpb.ListTodoListsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ListTodoLists is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ShareList(context.Context, *connect.Request[todolistpb.ShareListRequest]) (*connect.Response[todolistpb.ShareListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ShareList is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) UnshareList(context.Context, *connect.Request[todolistpb.UnshareListRequest]) (*connect.Response[todolistpb.UnshareListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.UnshareList is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ListMembers(context.Context, *connect.Request[todolistpb.ListMembersRequest], *connect.ServerStream[todolistpb.ListMembersResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ListMembers is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) CreateWebhook(context.Context, *connect.Request[todolistpb.CreateWebhookRequest]) (*connect.Response[todolistpb.CreateWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.CreateWebhook is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ReadWebhook(context.Context, *connect.Request[todolistpb.ReadWebhookRequest]) (*connect.Response[todolistpb.ReadWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ReadWebhook is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) UpdateWebhook(context.Context, *connect.Request[todolistpb.UpdateWebhookRequest]) (*connect.Response[todolistpb.UpdateWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.UpdateWebhook is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) DeleteWebhook(context.Context, *connect.Request[todolistpb.DeleteWebhookRequest]) (*connect.Response[todolistpb.DeleteWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.DeleteWebhook is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ListWebhooks(context.Context, *connect.Request[todolistpb.ListWebhooksRequest], *connect.ServerStream[todolistpb.ListWebhooksResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ListWebhooks is not implemented"))
+}
+
+func (UnimplementedTodoListServiceHandler) ListWebhookDeliveries(context.Context, *connect.Request[todolistpb.ListWebhookDeliveriesRequest], *connect.ServerStream[todolistpb.ListWebhookDeliveriesResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("todolist.TodoListService.ListWebhookDeliveries is not implemented"))
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import "go.opentelemetry.io/otel/attribute"
+
+// dbQuerySummaryKey is the low cardinality summary of the SQL statement of the span, the name of the statement
+// of the newer semantic conventions
+const dbQuerySummaryKey = attribute.Key("db.query.summary")
+
+// statements are the summaries of the main SQL statement of the operations of the databases,
+// the operation and the table of the statement
+var statements = map[string]string{
+	"insert":           "INSERT todo",
+	"get":              "SELECT todo",
+	"update":           "UPDATE todo",
+	"delete":           "UPDATE todo",
+	"list":             "SELECT todo",
+	"complete":         "UPDATE todo",
+	"reopen":           "UPDATE todo",
+	"restore":          "UPDATE todo",
+	"get_trashed":      "SELECT todo",
+	"list_trash":       "SELECT todo",
+	"count_todos":      "SELECT todo",
+	"purge":            "DELETE todo",
+	"insert_todo_list": "INSERT todo_list",
+	"get_todo_list":    "SELECT todo_list",
+	"update_todo_list": "UPDATE todo_list",
+	"delete_todo_list": "DELETE todo_list",
+	"list_todo_lists":  "SELECT todo_list",
+
+	"process_outbox": "DELETE outbox",
+
+	"insert_webhook":           "INSERT webhook",
+	"get_webhook":              "SELECT webhook",
+	"update_webhook":           "UPDATE webhook",
+	"delete_webhook":           "DELETE webhook",
+	"list_webhooks":            "SELECT webhook",
+	"insert_webhook_delivery":  "INSERT webhook_delivery",
+	"list_webhook_deliveries":  "SELECT webhook_delivery",
+	"record_webhook_result":    "UPDATE webhook",
+	"enqueue_webhook_delivery": "INSERT webhook_queue",
+	"claim_webhook_deliveries": "UPDATE webhook_queue",
+	"retry_webhook_delivery":   "UPDATE webhook_queue",
+	"dequeue_webhook_delivery": "DELETE webhook_queue",
+
+	"list_todo_history": "SELECT todo_history",
+	"get_todo_revision": "SELECT todo_history",
+
+	"put_list_member":    "INSERT list_member",
+	"get_list_member":    "SELECT list_member",
+	"delete_list_member": "DELETE list_member",
+	"list_list_members":  "SELECT list_member",
+	"list_memberships":   "SELECT list_member",
+}
@@ -0,0 +1,164 @@
+// Package tracing is exporting the OpenTelemetry traces of the service and its clients, the trace context
+// is propagated in the W3C traceparent and tracestate metadata
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/halimi/todo-list-service/db"
+)
+
+// instrumentationName is the name of the tracer of the repository calls
+const instrumentationName = "github.com/halimi/todo-list-service/tracing"
+
+// Config is the configuration of the exporter of the traces
+type Config struct {
+	// ServiceName is the service.name of the exported spans
+	ServiceName string
+	// Exporter is none, otlp, stdout or file. The trace context is propagated without an exporter too.
+	Exporter string
+	// OTLPEndpoint is the host:port of the OTLP/HTTP collector, the OTEL_EXPORTER_OTLP_ENDPOINT
+	// environment variable or localhost:4318 is used if it is empty
+	OTLPEndpoint string
+	// OTLPInsecure is sending the spans to the collector without TLS
+	OTLPInsecure bool
+	// File is the JSON lines file of the spans of the file exporter, the spans are appended to it
+	File string
+	// SampleRatio is the ratio of the sampled new traces, the sampling of the caller is followed
+	SampleRatio float64
+}
+
+// Setup is setting the global tracer provider of the exporter and the W3C trace context propagator,
+// the returned function is flushing the spans and stopping the exporter
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	closeFile := func() error { return nil }
+
+	switch config.Exporter {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		var opts []otlptracehttp.Option
+		if config.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(config.OTLPEndpoint))
+		}
+		if config.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		var err error
+		if exporter, err = otlptracehttp.New(ctx, opts...); err != nil {
+			return nil, fmt.Errorf("could not create the OTLP exporter: %v", err)
+		}
+	case "stdout":
+		var err error
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout)); err != nil {
+			return nil, err
+		}
+	case "file":
+		f, err := os.OpenFile(config.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		closeFile = f.Close
+
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(f)); err != nil {
+			f.Close()
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, use one of: none, otlp, stdout, file", config.Exporter)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(config.ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		if err := provider.Shutdown(ctx); err != nil {
+			return err
+		}
+
+		return closeFile()
+	}, nil
+}
+
+// Repository returns with the repository starting a span for every call of repo, the spans are named
+// by the operation of the call and they have the db.system of the database and the summary of the SQL
+// statement of the operation. The returned repository is a db.Watcher if repo is a watcher.
+func Repository(repo db.Repository, system string) db.Repository {
+	return db.Instrument(repo, observer(system))
+}
+
+// Outbox returns with the outbox starting a span for the processing of the events, the spans of the
+// publishers are the children of it
+func Outbox(outbox db.Outbox, system string) db.Outbox {
+	return db.InstrumentOutbox(outbox, observer(system))
+}
+
+// Webhooks returns with the webhook store starting a span for every call of store
+func Webhooks(store db.WebhookStore, system string) db.WebhookStore {
+	return db.InstrumentWebhooks(store, observer(system))
+}
+
+// History returns with the history starting a span for every call of history
+func History(history db.History, system string) db.History {
+	return db.InstrumentHistory(history, observer(system))
+}
+
+// Members returns with the member store starting a span for every call of store
+func Members(store db.MemberStore, system string) db.MemberStore {
+	return db.InstrumentMembers(store, observer(system))
+}
+
+// observer returns with the observer starting the spans of the calls of the database
+func observer(system string) db.Observer {
+	tracer := otel.Tracer(instrumentationName)
+
+	return func(ctx context.Context, operation string) (context.Context, func(error)) {
+		attrs := []attribute.KeyValue{semconv.DBSystemKey.String(system), semconv.DBOperationName(operation)}
+		if statement, ok := statements[operation]; ok {
+			attrs = append(attrs, dbQuerySummaryKey.String(statement))
+		}
+
+		ctx, span := tracer.Start(ctx, "repository."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+		)
+
+		return ctx, func(err error) {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+	}
+}
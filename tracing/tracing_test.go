@@ -0,0 +1,151 @@
+package tracing_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/halimi/todo-list-service/db"
+	"github.com/halimi/todo-list-service/todolistpb"
+	"github.com/halimi/todo-list-service/tracing"
+)
+
+func TestRepository(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := tracing.Repository(memory, "memory")
+
+	// the spans of the repository calls are the children of the span of the request
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	id, err := repo.Insert(ctx, &todolistpb.Todo{Title: "Traced", DueDate: ptypes.TimestampNow()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := repo.Get(canceled, id); err == nil {
+		t.Fatalf("Want: error, Got: nil\n")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Want: 3 spans, Got: %v\n", len(spans))
+	}
+
+	insert := spans[0]
+	if insert.Name() != "repository.insert" || insert.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("Want: repository.insert of the request, Got: %v %v\n", insert.Name(), insert.Parent())
+	}
+
+	attrs := make(map[string]string)
+	for _, attr := range insert.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+
+	if attrs["db.system"] != "memory" || attrs["db.operation.name"] != "insert" || attrs["db.query.summary"] != "INSERT todo" {
+		t.Fatalf("Want: memory insert, Got: %v\n", attrs)
+	}
+
+	get := spans[2]
+	if get.Name() != "repository.get" || get.Status().Code != codes.Error {
+		t.Fatalf("Want: failed repository.get, Got: %v %v\n", get.Name(), get.Status())
+	}
+}
+
+func TestStores(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	memory, err := db.NewMemory("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	webhooks := tracing.Webhooks(memory, "memory")
+	if _, err := webhooks.InsertWebhook(ctx, &todolistpb.Webhook{Url: "http://localhost/hook"}); err != nil {
+		t.Fatal(err)
+	}
+
+	members := tracing.Members(memory, "memory")
+	if _, err := members.ListMemberships(ctx, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	history := tracing.History(memory, "memory")
+	if _, err := history.GetTodoRevision(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	outbox := tracing.Outbox(memory, "memory")
+	if _, err := outbox.ProcessOutbox(ctx, 10, func(*db.OutboxEvent) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"repository.insert_webhook":    "INSERT webhook",
+		"repository.list_memberships":  "SELECT list_member",
+		"repository.get_todo_revision": "SELECT todo_history",
+		"repository.process_outbox":    "DELETE outbox",
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("Want: 4 spans, Got: %v\n", len(spans))
+	}
+
+	for _, span := range spans {
+		attrs := make(map[string]string)
+		for _, attr := range span.Attributes() {
+			attrs[string(attr.Key)] = attr.Value.Emit()
+		}
+
+		if attrs["db.system"] != "memory" || attrs["db.query.summary"] != want[span.Name()] {
+			t.Fatalf("Want: %v, Got: %v %v\n", want[span.Name()], span.Name(), attrs)
+		}
+	}
+}
+
+func TestSetup(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traces.jsonl")
+
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{ServiceName: "test", Exporter: "file", File: file, SampleRatio: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, span := otel.Tracer("test").Start(context.Background(), "exported")
+	span.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(b), `"Name":"exported"`) {
+		t.Fatalf("Want: exported span, Got: %v\n", string(b))
+	}
+
+	if _, err := tracing.Setup(context.Background(), tracing.Config{Exporter: "jaeger"}); err == nil {
+		t.Fatalf("Want: error, Got: nil\n")
+	}
+}